@@ -10,6 +10,7 @@ import { ISuperchainConfig } from "interfaces/L1/ISuperchainConfig.sol";
 import { IAnchorStateRegistry } from "interfaces/L1/proofs/IAnchorStateRegistry.sol";
 import { IProxyAdminOwnedBase } from "interfaces/L1/IProxyAdminOwnedBase.sol";
 
+// @checks:receives-eth
 interface IOptimismPortal2 is IProxyAdminOwnedBase {
     error ContentLengthMismatch();
     error EmptyItem();
@@ -5,6 +5,7 @@ import { ISystemConfig } from "interfaces/L1/ISystemConfig.sol";
 import { ISuperchainConfig } from "interfaces/L1/ISuperchainConfig.sol";
 import { IProxyAdminOwnedBase } from "interfaces/L1/IProxyAdminOwnedBase.sol";
 
+// @checks:receives-eth
 interface IDelayedWETH is IProxyAdminOwnedBase {
     error ReinitializableBase_ZeroInitVersion();
 
@@ -3,6 +3,7 @@ pragma solidity ^0.8.0;
 
 import { IFeeVault } from "interfaces/L2/IFeeVault.sol";
 
+// @checks:receives-eth
 interface IBaseFeeVault is IFeeVault {
     function version() external view returns (string memory);
 }
@@ -4,6 +4,7 @@ pragma solidity ^0.8.0;
 import { IProxyAdminOwnedBase } from "interfaces/L1/IProxyAdminOwnedBase.sol";
 import { Types } from "src/libraries/Types.sol";
 
+// @checks:receives-eth
 interface IFeeVault is IProxyAdminOwnedBase {
     error InvalidInitialization();
     error NotInitializing();
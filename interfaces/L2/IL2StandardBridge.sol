@@ -3,6 +3,7 @@ pragma solidity ^0.8.0;
 
 import { IStandardBridge } from "interfaces/universal/IStandardBridge.sol";
 
+// @checks:receives-eth
 interface IL2StandardBridge is IStandardBridge {
     event DepositFinalized(
         address indexed l1Token,
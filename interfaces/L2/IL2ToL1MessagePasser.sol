@@ -1,6 +1,7 @@
 // SPDX-License-Identifier: MIT
 pragma solidity ^0.8.0;
 
+// @checks:receives-eth
 interface IL2ToL1MessagePasser {
     event MessagePassed(
         uint256 indexed nonce,
@@ -1,6 +1,7 @@
 // SPDX-License-Identifier: MIT
 pragma solidity ^0.8.0;
 
+// @checks:receives-eth
 /// @title IL1ChugSplashProxy
 /// @notice Interface for the L1ChugSplashProxy contract.
 interface IL1ChugSplashProxy {
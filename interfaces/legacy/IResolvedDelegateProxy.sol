@@ -3,6 +3,7 @@ pragma solidity ^0.8.0;
 
 import { IAddressManager } from "interfaces/legacy/IAddressManager.sol";
 
+// @checks:receives-eth
 /// @title IResolvedDelegateProxy
 /// @notice Interface for the ResolvedDelegateProxy contract.
 interface IResolvedDelegateProxy {
@@ -1,6 +1,7 @@
 // SPDX-License-Identifier: MIT
 pragma solidity ^0.8.0;
 
+// @checks:receives-eth
 interface IProxy {
     event AdminChanged(address previousAdmin, address newAdmin);
     event Upgraded(address indexed implementation);
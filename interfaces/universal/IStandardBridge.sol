@@ -3,6 +3,7 @@ pragma solidity ^0.8.0;
 
 import { ICrossDomainMessenger } from "interfaces/universal/ICrossDomainMessenger.sol";
 
+// @checks:receives-eth
 interface IStandardBridge {
     event ERC20BridgeFinalized(
         address indexed localToken,
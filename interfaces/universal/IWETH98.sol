@@ -1,6 +1,7 @@
 // SPDX-License-Identifier: MIT
 pragma solidity ^0.8.0;
 
+// @checks:receives-eth
 /// @title IWETH
 /// @notice Interface for WETH9.
 interface IWETH98 {
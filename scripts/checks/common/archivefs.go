@@ -0,0 +1,222 @@
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/flate"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// tarFS is a read-only fs.FS over a tar archive. Over an uncompressed archive, it indexes where
+// each regular file's data starts and opening a file reads that section of the archive, so only
+// the files being read are ever in memory. A compressed archive cannot be seeked, so its files
+// are kept in memory instead, each compressed on its own so that it can be inflated on Open.
+type tarFS struct {
+	archive io.ReaderAt
+	files   map[string]*archiveEntry
+	dirs    map[string][]string // directory -> sorted names of its children
+}
+
+// archiveEntry is a regular file of a tar archive: a section of the archive, or its data
+// deflated in memory.
+type archiveEntry struct {
+	offset   int64
+	size     int64
+	modTime  time.Time
+	deflated []byte
+}
+
+// countingReader counts the bytes read through it, which is the archive offset tar.Reader has
+// reached, since tar.Reader does not buffer beyond the entry it returns.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// newTarFS indexes the regular files of the tar archive read from archive.
+func newTarFS(archive io.ReaderAt, size int64) (*tarFS, error) {
+	fsys := &tarFS{archive: archive, files: make(map[string]*archiveEntry), dirs: map[string][]string{".": nil}}
+	counter := &countingReader{r: io.NewSectionReader(archive, 0, size)}
+	tr := tar.NewReader(counter)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if !fs.ValidPath(name) || name == "." {
+			continue
+		}
+		fsys.files[name] = &archiveEntry{offset: counter.n, size: header.Size, modTime: header.ModTime}
+		fsys.addParents(name)
+	}
+	fsys.sortDirs()
+	return fsys, nil
+}
+
+// newCompressedTarFS reads the tar archive streamed from r once, keeping the regular files
+// whose name keep accepts.
+func newCompressedTarFS(r io.Reader, keep func(name string) bool) (*tarFS, error) {
+	fsys := &tarFS{files: make(map[string]*archiveEntry), dirs: map[string][]string{".": nil}}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if header.Typeflag != tar.TypeReg || !fs.ValidPath(name) || name == "." || !keep(name) {
+			continue
+		}
+		var deflated bytes.Buffer
+		w, err := flate.NewWriter(&deflated, flate.BestSpeed)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(w, tr); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		fsys.files[name] = &archiveEntry{size: header.Size, modTime: header.ModTime, deflated: deflated.Bytes()}
+		fsys.addParents(name)
+	}
+	fsys.sortDirs()
+	return fsys, nil
+}
+
+// sortDirs sorts and deduplicates the children of every directory.
+func (fsys *tarFS) sortDirs() {
+	for dir := range fsys.dirs {
+		slices.Sort(fsys.dirs[dir])
+		fsys.dirs[dir] = slices.Compact(fsys.dirs[dir])
+	}
+}
+
+// addParents records name as a child of its directory, and each directory as a child of its own
+// parent.
+func (fsys *tarFS) addParents(name string) {
+	for name != "." {
+		dir := path.Dir(name)
+		_, seen := fsys.dirs[dir]
+		fsys.dirs[dir] = append(fsys.dirs[dir], path.Base(name))
+		if seen {
+			return
+		}
+		name = dir
+	}
+}
+
+func (fsys *tarFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if entry, ok := fsys.files[name]; ok {
+		if entry.deflated != nil {
+			inflater := flate.NewReader(bytes.NewReader(entry.deflated))
+			return &tarFile{info: fsys.stat(name), reader: inflater, closer: inflater}, nil
+		}
+		return &tarFile{
+			info:   fsys.stat(name),
+			reader: io.NewSectionReader(fsys.archive, entry.offset, entry.size),
+		}, nil
+	}
+	if _, ok := fsys.dirs[name]; ok {
+		return &tarDir{fsys: fsys, info: fsys.stat(name), path: name, children: fsys.dirs[name]}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// stat describes the file or directory name, which must exist.
+func (fsys *tarFS) stat(name string) archiveInfo {
+	if entry, ok := fsys.files[name]; ok {
+		return archiveInfo{name: path.Base(name), size: entry.size, mode: 0o444, modTime: entry.modTime}
+	}
+	return archiveInfo{name: path.Base(name), mode: fs.ModeDir | 0o555}
+}
+
+// archiveInfo is the fs.FileInfo of a tarFS file or directory.
+type archiveInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i archiveInfo) Name() string       { return i.name }
+func (i archiveInfo) Size() int64        { return i.size }
+func (i archiveInfo) Mode() fs.FileMode  { return i.mode }
+func (i archiveInfo) ModTime() time.Time { return i.modTime }
+func (i archiveInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i archiveInfo) Sys() any           { return nil }
+
+// tarFile is an open regular file of a tarFS.
+type tarFile struct {
+	info   archiveInfo
+	reader io.Reader
+	closer io.Closer
+}
+
+func (f *tarFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *tarFile) Read(p []byte) (int, error) { return f.reader.Read(p) }
+
+func (f *tarFile) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
+
+// tarDir is an open directory of a tarFS.
+type tarDir struct {
+	fsys     *tarFS
+	info     archiveInfo
+	path     string
+	children []string
+	read     int
+}
+
+func (d *tarDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *tarDir) Close() error               { return nil }
+
+func (d *tarDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+func (d *tarDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.children[d.read:]
+	if n > 0 && len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < len(remaining) {
+		remaining = remaining[:n]
+	}
+	entries := make([]fs.DirEntry, len(remaining))
+	for i, child := range remaining {
+		entries[i] = fs.FileInfoToDirEntry(d.fsys.stat(path.Join(d.path, child)))
+	}
+	d.read += len(remaining)
+	return entries, nil
+}
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// BaselineEntry identifies an accepted finding. Severity is deliberately not part of the key so
+// that promoting a check's severity does not invalidate its baseline.
+type BaselineEntry struct {
+	Check    string `json:"check"`
+	File     string `json:"file"`
+	Contract string `json:"contract,omitempty"`
+	Message  string `json:"message"`
+}
+
+// Baseline is a committed set of accepted findings. Findings in the baseline do not fail a run,
+// so that a new check can be adopted without first fixing every existing violation.
+type Baseline struct {
+	Findings []BaselineEntry `json:"findings"`
+}
+
+func baselineKey(f Finding) BaselineEntry {
+	return BaselineEntry{Check: f.Check, File: f.File, Contract: f.Contract, Message: f.Message}
+}
+
+// NewBaseline builds a baseline accepting every warning and error in findings. Entries are
+// deduplicated and sorted so that regenerating an unchanged baseline gives identical output.
+func NewBaseline(findings []Finding) *Baseline {
+	baseline := &Baseline{Findings: []BaselineEntry{}}
+	for _, f := range findings {
+		if f.Severity == SeverityInfo {
+			continue
+		}
+		baseline.Findings = append(baseline.Findings, baselineKey(f))
+	}
+	slices.SortFunc(baseline.Findings, compareBaselineEntries)
+	baseline.Findings = slices.Compact(baseline.Findings)
+	return baseline
+}
+
+func compareBaselineEntries(a, b BaselineEntry) int {
+	return strings.Compare(
+		a.Check+"\x00"+a.File+"\x00"+a.Contract+"\x00"+a.Message,
+		b.Check+"\x00"+b.File+"\x00"+b.Contract+"\x00"+b.Message,
+	)
+}
+
+// ReadBaseline reads the baseline at path. A missing file is an empty baseline.
+func ReadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Baseline{Findings: []BaselineEntry{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	return &baseline, nil
+}
+
+// WriteBaseline atomically replaces the baseline at path.
+func WriteBaseline(path string, baseline *Baseline) error {
+	return WriteJSONAtomic(baseline, path)
+}
+
+// Filter removes the findings accepted by the baseline. It also returns the baseline entries
+// that matched no finding, which can be dropped with a baseline update.
+func (b *Baseline) Filter(findings []Finding) ([]Finding, []BaselineEntry) {
+	accepted := make(map[BaselineEntry]bool, len(b.Findings))
+	for _, entry := range b.Findings {
+		accepted[entry] = false
+	}
+
+	var remaining []Finding
+	for _, f := range findings {
+		key := baselineKey(f)
+		if _, ok := accepted[key]; ok && f.Severity != SeverityInfo {
+			accepted[key] = true
+			continue
+		}
+		remaining = append(remaining, f)
+	}
+
+	var stale []BaselineEntry
+	for _, entry := range b.Findings {
+		if !accepted[entry] {
+			stale = append(stale, entry)
+		}
+	}
+	return remaining, stale
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseline(t *testing.T) {
+	findings := []Finding{
+		{Check: "struct-return", Severity: SeverityWarning, File: "src/B.sol", Contract: "B", Message: "B.get returns a big struct"},
+		{Check: "delegatecall", Severity: SeverityError, File: "src/A.sol", Contract: "A", Message: "A.f uses delegatecall"},
+		{Check: "delegatecall", Severity: SeverityError, File: "src/A.sol", Contract: "A", Message: "A.f uses delegatecall"},
+		{Check: "interfaces", Severity: SeverityInfo, File: "src/C.sol", Contract: "C", Message: "no AST"},
+	}
+
+	t.Run("new baseline is sorted and deduplicated", func(t *testing.T) {
+		baseline := NewBaseline(findings)
+		require.Equal(t, []BaselineEntry{
+			{Check: "delegatecall", File: "src/A.sol", Contract: "A", Message: "A.f uses delegatecall"},
+			{Check: "struct-return", File: "src/B.sol", Contract: "B", Message: "B.get returns a big struct"},
+		}, baseline.Findings)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		baseline := &Baseline{Findings: []BaselineEntry{
+			{Check: "delegatecall", File: "src/A.sol", Contract: "A", Message: "A.f uses delegatecall"},
+			{Check: "delegatecall", File: "src/Gone.sol", Contract: "Gone", Message: "Gone.f uses delegatecall"},
+		}}
+		remaining, stale := baseline.Filter(findings)
+		require.Equal(t, []Finding{findings[0], findings[3]}, remaining)
+		require.Equal(t, []BaselineEntry{baseline.Findings[1]}, stale)
+	})
+
+	t.Run("update twice is byte-identical", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		require.NoError(t, WriteBaseline(path, NewBaseline(findings)))
+		first, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		read, err := ReadBaseline(path)
+		require.NoError(t, err)
+		remaining, _ := read.Filter(findings)
+		require.Len(t, remaining, 1)
+
+		// Reverse the input order to make sure the output does not depend on it.
+		reversed := []Finding{findings[3], findings[2], findings[1], findings[0]}
+		require.NoError(t, WriteBaseline(path, NewBaseline(reversed)))
+		second, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, string(first), string(second))
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		require.Len(t, entries, 1, "temp files must not be left behind")
+	})
+
+	t.Run("missing baseline is empty", func(t *testing.T) {
+		baseline, err := ReadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+		require.NoError(t, err)
+		require.Empty(t, baseline.Findings)
+	})
+}
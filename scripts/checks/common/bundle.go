@@ -0,0 +1,101 @@
+package common
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Bundle is an archive of build artifacts read in place, so that CI jobs can hand artifacts to
+// each other without extracting them.
+type Bundle struct {
+	fs.FS
+	closer io.Closer
+}
+
+// OpenBundle opens a .zip, .tar, .tar.gz or .tgz archive. Zip and plain tar entries are read on
+// demand. A compressed tar is streamed once and only its .json entries are kept, compressed, so
+// nothing is extracted to disk and memory holds about the size of the archive's JSON. When every
+// entry is under a single directory, such as forge-artifacts/, the bundle is rooted at that
+// directory.
+func OpenBundle(name string) (*Bundle, error) {
+	bundle := &Bundle{}
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		reader, err := zip.OpenReader(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open bundle: %w", err)
+		}
+		bundle.FS, bundle.closer = reader, reader
+	case strings.HasSuffix(name, ".tar"), strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		files, closer, err := openTarBundle(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle %s: %w", name, err)
+		}
+		bundle.FS, bundle.closer = files, closer
+	default:
+		return nil, fmt.Errorf("unsupported bundle %s: expected .zip, .tar, .tar.gz or .tgz", name)
+	}
+
+	entries, err := fs.ReadDir(bundle.FS, ".")
+	if err != nil {
+		bundle.Close()
+		return nil, fmt.Errorf("failed to read bundle %s: %w", name, err)
+	}
+	if len(entries) == 1 && entries[0].IsDir() && !strings.HasSuffix(entries[0].Name(), ".sol") {
+		sub, err := fs.Sub(bundle.FS, entries[0].Name())
+		if err != nil {
+			bundle.Close()
+			return nil, err
+		}
+		bundle.FS = sub
+	}
+	return bundle, nil
+}
+
+// Close releases the archive.
+func (b *Bundle) Close() error {
+	if b.closer == nil {
+		return nil
+	}
+	return b.closer.Close()
+}
+
+// openTarBundle opens a tar archive for reading in place. A plain archive is indexed and its
+// files are read from it on demand. A compressed archive cannot be seeked, so it is read once,
+// keeping only its .json entries, each stored recompressed in memory until it is opened.
+func openTarBundle(name string) (*tarFS, io.Closer, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if strings.HasSuffix(name, ".tar") {
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		files, err := newTarFS(file, info.Size())
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return files, file, nil
+	}
+
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gz.Close()
+	files, err := newCompressedTarFS(gz, func(entry string) bool { return strings.HasSuffix(entry, ".json") })
+	if err != nil {
+		return nil, nil, err
+	}
+	return files, nil, nil
+}
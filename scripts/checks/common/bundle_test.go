@@ -0,0 +1,110 @@
+package common
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+var bundleFiles = map[string]string{
+	"forge-artifacts/Foo.sol/Foo.json":     `{"abi":[]}`,
+	"forge-artifacts/IFoo.sol/IFoo.json":   `{"abi":[]}`,
+	"forge-artifacts/build-info/notes.txt": "not an artifact",
+}
+
+func writeTarBundle(t *testing.T, name string, compress bool) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var w io.Writer = file
+	if compress {
+		gz := gzip.NewWriter(file)
+		defer gz.Close()
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "forge-artifacts/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for entry, content := range bundleFiles {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: entry, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	return path
+}
+
+func writeZipBundle(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "artifacts.zip")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	defer zw.Close()
+	for entry, content := range bundleFiles {
+		w, err := zw.Create(entry)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	return path
+}
+
+func TestOpenBundle(t *testing.T) {
+	for name, path := range map[string]string{
+		"tar.gz": writeTarBundle(t, "artifacts.tar.gz", true),
+		"tar":    writeTarBundle(t, "artifacts.tar", false),
+		"zip":    writeZipBundle(t),
+	} {
+		t.Run(name, func(t *testing.T) {
+			bundle, err := OpenBundle(path)
+			require.NoError(t, err)
+			defer bundle.Close()
+
+			files, err := FindFilesFS(bundle, []string{"**/*.json"}, []string{"IFoo.sol/**"})
+			require.NoError(t, err)
+			require.Equal(t, []string{"Foo.sol/Foo.json"}, files)
+
+			data, err := fs.ReadFile(bundle, "IFoo.sol/IFoo.json")
+			require.NoError(t, err)
+			require.JSONEq(t, `{"abi":[]}`, string(data))
+		})
+	}
+
+	_, err := OpenBundle("artifacts.rar")
+	require.ErrorContains(t, err, "unsupported bundle")
+}
+
+func TestOpenBundleCompressedTarWritesNothingToDisk(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	bundle, err := OpenBundle(writeTarBundle(t, "artifacts.tar.gz", true))
+	require.NoError(t, err)
+	defer bundle.Close()
+
+	require.NoError(t, fstest.TestFS(bundle, "Foo.sol/Foo.json", "IFoo.sol/IFoo.json"))
+	spilled, err := os.ReadDir(tmp)
+	require.NoError(t, err)
+	require.Empty(t, spilled)
+	_, err = fs.Stat(bundle, "build-info/notes.txt")
+	require.ErrorIs(t, err, fs.ErrNotExist, "only .json entries are kept")
+}
+
+func TestOpenBundlePlainTar(t *testing.T) {
+	bundle, err := OpenBundle(writeTarBundle(t, "artifacts.tar", false))
+	require.NoError(t, err)
+	defer bundle.Close()
+	require.NoError(t, fstest.TestFS(bundle, "Foo.sol/Foo.json", "IFoo.sol/IFoo.json", "build-info/notes.txt"))
+}
@@ -0,0 +1,322 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Options is passed to every check in a run.
+type Options struct {
+	// Dir is the contracts package root the check runs against.
+	Dir string
+	// Strict asks checks to escalate advisory findings.
+	Strict bool
+	// FailFast asks checks to stop at their first warning or error. RunChecks then skips the
+	// remaining checks.
+	FailFast bool
+}
+
+// Check is a named check that a runner can execute alongside other registered checks.
+type Check interface {
+	Name() string
+	Run(ctx context.Context, opts Options) ([]Finding, error)
+}
+
+var (
+	registryMtx sync.Mutex
+	registry    = make(map[string]Check)
+)
+
+// Register makes a check available to RegisteredChecks. It is meant to be called from init and
+// panics if the name is empty or already registered.
+func Register(check Check) {
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+
+	name := check.Name()
+	if name == "" {
+		panic("common: Register called with an unnamed check")
+	}
+	if _, dup := registry[name]; dup {
+		panic("common: Register called twice for check " + name)
+	}
+	registry[name] = check
+}
+
+// RegisteredChecks returns every registered check, sorted by name.
+func RegisteredChecks() []Check {
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+
+	checks := make([]Check, 0, len(registry))
+	for _, check := range registry {
+		checks = append(checks, check)
+	}
+	slices.SortFunc(checks, func(a, b Check) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return checks
+}
+
+// SelectChecks narrows checks to the names in selected, or every check when selected is empty,
+// then drops the names in deselected. A name is either a check or one of the rules a check
+// describes in its CheckInfo; check names win when they clash. Selecting or deselecting rules of
+// a check keeps the check but runs only the chosen rules, see RuleSelector. Unknown names are an
+// error so that typos in CI configuration do not silently skip checks.
+func SelectChecks(checks []Check, selected, deselected []string) ([]Check, error) {
+	isCheck := make(map[string]bool, len(checks))
+	for _, check := range checks {
+		isCheck[check.Name()] = true
+	}
+	ruleOwner := make(map[string]string)
+	for _, check := range checks {
+		for _, rule := range DescribeCheck(check).Rules {
+			if !isCheck[rule.Name] {
+				ruleOwner[rule.Name] = check.Name()
+			}
+		}
+	}
+	for _, name := range slices.Concat(selected, deselected) {
+		if _, ok := ruleOwner[name]; !ok && !isCheck[name] {
+			return nil, fmt.Errorf("unknown check %q", name)
+		}
+	}
+	rulesOf := func(names []string, check string) []string {
+		var rules []string
+		for _, name := range names {
+			if ruleOwner[name] == check {
+				rules = append(rules, name)
+			}
+		}
+		return rules
+	}
+
+	var out []Check
+	for _, check := range checks {
+		name := check.Name()
+		if slices.Contains(deselected, name) {
+			continue
+		}
+		var keep []string
+		if len(selected) > 0 && !slices.Contains(selected, name) {
+			if keep = rulesOf(selected, name); len(keep) == 0 {
+				continue
+			}
+		}
+		drop := rulesOf(deselected, name)
+		if len(keep) == 0 && len(drop) == 0 {
+			out = append(out, check)
+			continue
+		}
+		out = append(out, &ruleFilter{Check: check, enabled: func(rule string) bool {
+			if rule == "" || rule == name {
+				return len(keep) == 0 && !slices.Contains(drop, rule)
+			}
+			return (len(keep) == 0 || slices.Contains(keep, rule)) && !slices.Contains(drop, rule)
+		}})
+	}
+	return out, nil
+}
+
+// RuleSelector is implemented by checks that can skip the work of rules outside a selection,
+// rather than running every rule and having their findings dropped afterwards.
+type RuleSelector interface {
+	// SelectRules restricts the next runs to the rules enabled reports true for.
+	SelectRules(enabled func(rule string) bool)
+}
+
+// ruleFilter runs a check restricted to some of its rules, and drops the findings of the others
+// in case the check cannot skip them itself.
+type ruleFilter struct {
+	Check
+	enabled func(rule string) bool
+}
+
+// Info describes the wrapped check with only the rules that run.
+func (f *ruleFilter) Info() CheckInfo {
+	info := DescribeCheck(f.Check)
+	info.Rules = slices.DeleteFunc(slices.Clone(info.Rules), func(rule Rule) bool {
+		return !f.enabled(rule.Name)
+	})
+	return info
+}
+
+func (f *ruleFilter) Run(ctx context.Context, opts Options) ([]Finding, error) {
+	if selector, ok := f.Check.(RuleSelector); ok {
+		selector.SelectRules(f.enabled)
+		defer selector.SelectRules(nil)
+	}
+	findings, err := f.Check.Run(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	var out []Finding
+	for _, finding := range findings {
+		if f.enabled(finding.Check) {
+			out = append(out, finding)
+		}
+	}
+	return out, nil
+}
+
+// RunChecks runs checks in order and aggregates their findings. Findings that do not name a
+// check are attributed to the check that returned them. The first error stops the run, as does
+// the first warning or error finding with opts.FailFast.
+func RunChecks(ctx context.Context, checks []Check, opts Options) ([]Finding, error) {
+	var findings []Finding
+	for _, check := range checks {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		results, err := check.Run(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %w", check.Name(), err)
+		}
+		for _, finding := range results {
+			if finding.Check == "" {
+				finding.Check = check.Name()
+			}
+			findings = append(findings, finding)
+		}
+		if opts.FailFast && HasProblems(findings) {
+			break
+		}
+	}
+	return findings, nil
+}
+
+// HasProblems reports whether any finding is a warning or an error, which --fail-fast stops at.
+func HasProblems(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool {
+		return f.Severity >= SeverityWarning
+	})
+}
+
+// HasErrors reports whether any finding is an error, i.e. whether the run should fail.
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool {
+		return f.Severity == SeverityError
+	})
+}
+
+// Exit codes of a check run. An error while running a check also exits with ExitFailure.
+const (
+	// ExitClean means no finding needs attention.
+	ExitClean = 0
+	// ExitFailure means at least one finding is an error.
+	ExitFailure = 1
+	// ExitWarnings means the most severe finding is a warning. It is only used when asked for,
+	// since a run with warnings otherwise succeeds.
+	ExitWarnings = 3
+)
+
+// ExitCode returns the exit code of a run that reported findings. With warningExit, a run whose
+// most severe finding is a warning exits with ExitWarnings instead of ExitClean.
+func ExitCode(findings []Finding, warningExit bool) int {
+	switch {
+	case HasErrors(findings):
+		return ExitFailure
+	case warningExit && slices.ContainsFunc(findings, func(f Finding) bool { return f.Severity == SeverityWarning }):
+		return ExitWarnings
+	default:
+		return ExitClean
+	}
+}
+
+// Rule is one of the individual rules a check enforces.
+type Rule struct {
+	Name        string
+	Severity    Severity
+	Description string
+}
+
+// CheckInfo describes a check for listings and help output.
+type CheckInfo struct {
+	// Description is a one-line summary of what the check enforces.
+	Description string
+	// Severity is the most severe finding the check reports by default.
+	Severity Severity
+	// Flags names the command-line flags that affect the check.
+	Flags []string
+	// Rules lists the individual rules the check enforces, if it has more than one.
+	Rules []Rule
+}
+
+// Describer is implemented by checks that can describe themselves.
+type Describer interface {
+	Info() CheckInfo
+}
+
+// DescribeCheck returns the check's CheckInfo, or an empty one if it is not a Describer.
+func DescribeCheck(check Check) CheckInfo {
+	if d, ok := check.(Describer); ok {
+		return d.Info()
+	}
+	return CheckInfo{}
+}
+
+// WriteCheckList writes one line per check with its name, default severity, description and
+// flags.
+func WriteCheckList(w io.Writer, checks []Check) error {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, check := range checks {
+		info := DescribeCheck(check)
+		description := info.Description
+		if description == "" {
+			description = "-"
+		}
+		flags := make([]string, 0, len(info.Flags))
+		for _, name := range info.Flags {
+			flags = append(flags, "--"+name)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", check.Name(), info.Severity, description, strings.Join(flags, " "))
+	}
+	if err := tw.Flush(); err != nil || buf.Len() == 0 {
+		return err
+	}
+	// Checks without flags would otherwise leave the padding of the description column behind.
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCheckHelp writes detailed usage for the named check, taking flag usage from flags.
+func WriteCheckHelp(w io.Writer, checks []Check, name string, flags *flag.FlagSet) error {
+	idx := slices.IndexFunc(checks, func(c Check) bool { return c.Name() == name })
+	if idx < 0 {
+		return fmt.Errorf("unknown check %q", name)
+	}
+	info := DescribeCheck(checks[idx])
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "%s: %s\n", name, info.Description)
+	_, _ = fmt.Fprintf(tw, "default severity: %s\n", info.Severity)
+	if len(info.Flags) > 0 {
+		_, _ = fmt.Fprintln(tw, "\nflags:")
+		for _, flagName := range info.Flags {
+			usage := ""
+			if f := flags.Lookup(flagName); f != nil {
+				usage = f.Usage
+			}
+			_, _ = fmt.Fprintf(tw, "  --%s\t%s\n", flagName, usage)
+		}
+	}
+	if len(info.Rules) > 0 {
+		_, _ = fmt.Fprintln(tw, "\nrules:")
+		for _, rule := range info.Rules {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\n", rule.Name, rule.Severity, rule.Description)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,248 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCheck struct {
+	name     string
+	findings []Finding
+	err      error
+	ran      *[]string
+}
+
+func (c fakeCheck) Name() string { return c.name }
+
+func (c fakeCheck) Run(_ context.Context, _ Options) ([]Finding, error) {
+	if c.ran != nil {
+		*c.ran = append(*c.ran, c.name)
+	}
+	return c.findings, c.err
+}
+
+func resetRegistry(t *testing.T) {
+	t.Helper()
+	prev := registry
+	registry = make(map[string]Check)
+	t.Cleanup(func() { registry = prev })
+}
+
+func TestRegister(t *testing.T) {
+	resetRegistry(t)
+
+	Register(fakeCheck{name: "b"})
+	Register(fakeCheck{name: "a"})
+	checks := RegisteredChecks()
+	require.Len(t, checks, 2)
+	require.Equal(t, "a", checks[0].Name())
+	require.Equal(t, "b", checks[1].Name())
+
+	require.Panics(t, func() { Register(fakeCheck{name: "a"}) })
+	require.Panics(t, func() { Register(fakeCheck{}) })
+}
+
+func TestSelectChecks(t *testing.T) {
+	checks := []Check{fakeCheck{name: "interfaces"}, fakeCheck{name: "natspec"}, fakeCheck{name: "size"}}
+	names := func(checks []Check) []string {
+		var out []string
+		for _, check := range checks {
+			out = append(out, check.Name())
+		}
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		selected   []string
+		deselected []string
+		want       []string
+		wantErr    string
+	}{
+		{name: "defaults to all", want: []string{"interfaces", "natspec", "size"}},
+		{name: "select", selected: []string{"size", "interfaces"}, want: []string{"interfaces", "size"}},
+		{name: "deselect", deselected: []string{"natspec"}, want: []string{"interfaces", "size"}},
+		{name: "select and deselect", selected: []string{"interfaces", "size"}, deselected: []string{"size"}, want: []string{"interfaces"}},
+		{name: "unknown selected", selected: []string{"gas"}, wantErr: `unknown check "gas"`},
+		{name: "unknown deselected", deselected: []string{"gas"}, wantErr: `unknown check "gas"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectChecks(checks, tt.selected, tt.deselected)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, names(got))
+		})
+	}
+}
+
+// selectingCheck records the rule selections it is given.
+type selectingCheck struct {
+	describedCheck
+	selections *[]func(rule string) bool
+}
+
+func (c selectingCheck) SelectRules(enabled func(rule string) bool) {
+	*c.selections = append(*c.selections, enabled)
+}
+
+func TestSelectChecksRules(t *testing.T) {
+	var selections []func(rule string) bool
+	interfaces := selectingCheck{describedCheck: describedCheck{
+		fakeCheck: fakeCheck{name: "interfaces", findings: []Finding{
+			{Check: "delegatecall", Message: "one"},
+			{Check: "payable", Message: "two"},
+			{Message: "three"},
+		}},
+		info: CheckInfo{Rules: []Rule{{Name: "delegatecall"}, {Name: "payable"}, {Name: "size"}}},
+	}, selections: &selections}
+	checks := []Check{interfaces, fakeCheck{name: "size", findings: []Finding{{Message: "four"}}}}
+	run := func(t *testing.T, selected, deselected []string) []string {
+		t.Helper()
+		got, err := SelectChecks(checks, selected, deselected)
+		require.NoError(t, err)
+		findings, err := RunChecks(context.Background(), got, Options{})
+		require.NoError(t, err)
+		var messages []string
+		for _, finding := range findings {
+			messages = append(messages, finding.Check+": "+finding.Message)
+		}
+		return messages
+	}
+
+	t.Run("select a rule", func(t *testing.T) {
+		selections = nil
+		require.Equal(t, []string{"delegatecall: one"}, run(t, []string{"delegatecall"}, nil))
+		require.Len(t, selections, 2)
+		require.True(t, selections[0]("delegatecall"))
+		require.False(t, selections[0]("payable"))
+		require.False(t, selections[0]("interfaces"))
+		require.Nil(t, selections[1])
+	})
+	t.Run("deselect a rule", func(t *testing.T) {
+		require.Equal(t, []string{"payable: two", "interfaces: three", "size: four"}, run(t, nil, []string{"delegatecall"}))
+	})
+	t.Run("check name wins over a rule", func(t *testing.T) {
+		require.Equal(t, []string{"size: four"}, run(t, []string{"size"}, nil))
+	})
+	t.Run("info lists the selected rules", func(t *testing.T) {
+		got, err := SelectChecks(checks, []string{"payable"}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, []Rule{{Name: "payable"}}, DescribeCheck(got[0]).Rules)
+	})
+}
+
+func TestRunChecks(t *testing.T) {
+	t.Run("aggregates findings", func(t *testing.T) {
+		var ran []string
+		findings, err := RunChecks(context.Background(), []Check{
+			fakeCheck{name: "a", ran: &ran, findings: []Finding{{Message: "one"}}},
+			fakeCheck{name: "b", ran: &ran, findings: []Finding{{Check: "b-sub", Message: "two", Severity: SeverityError}}},
+		}, Options{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, ran)
+		require.Equal(t, []Finding{
+			{Check: "a", Message: "one"},
+			{Check: "b-sub", Message: "two", Severity: SeverityError},
+		}, findings)
+		require.True(t, HasErrors(findings))
+		require.False(t, HasErrors(findings[:1]))
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		var ran []string
+		_, err := RunChecks(context.Background(), []Check{
+			fakeCheck{name: "a", ran: &ran, err: errors.New("boom")},
+			fakeCheck{name: "b", ran: &ran},
+		}, Options{})
+		require.EqualError(t, err, "check a: boom")
+		require.Equal(t, []string{"a"}, ran)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var ran []string
+		_, err := RunChecks(ctx, []Check{fakeCheck{name: "a", ran: &ran}}, Options{})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Empty(t, ran)
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		var ran []string
+		findings, err := RunChecks(context.Background(), []Check{
+			fakeCheck{name: "a", ran: &ran, findings: []Finding{{Message: "info"}}},
+			fakeCheck{name: "b", ran: &ran, findings: []Finding{{Message: "warning", Severity: SeverityWarning}}},
+			fakeCheck{name: "c", ran: &ran},
+		}, Options{FailFast: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, ran)
+		require.Len(t, findings, 2)
+		require.True(t, HasProblems(findings))
+		require.False(t, HasProblems(findings[:1]))
+	})
+}
+
+func TestExitCode(t *testing.T) {
+	info := Finding{Severity: SeverityInfo}
+	warning := Finding{Severity: SeverityWarning}
+	failure := Finding{Severity: SeverityError}
+
+	require.Equal(t, ExitClean, ExitCode(nil, true))
+	require.Equal(t, ExitClean, ExitCode([]Finding{info}, true))
+	require.Equal(t, ExitClean, ExitCode([]Finding{info, warning}, false))
+	require.Equal(t, ExitWarnings, ExitCode([]Finding{info, warning}, true))
+	require.Equal(t, ExitFailure, ExitCode([]Finding{warning, failure}, false))
+	require.Equal(t, ExitFailure, ExitCode([]Finding{warning, failure}, true))
+}
+
+type describedCheck struct {
+	fakeCheck
+	info CheckInfo
+}
+
+func (c describedCheck) Info() CheckInfo { return c.info }
+
+func TestWriteCheckList(t *testing.T) {
+	checks := []Check{
+		describedCheck{fakeCheck: fakeCheck{name: "size"}, info: CheckInfo{Description: "Contracts fit", Severity: SeverityError, Flags: []string{"limit"}}},
+		fakeCheck{name: "plain"},
+	}
+	var out bytes.Buffer
+	require.NoError(t, WriteCheckList(&out, checks))
+	require.Equal(t, "size   error  Contracts fit  --limit\nplain  info   -\n", out.String())
+}
+
+func TestWriteCheckHelp(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Int("limit", 24576, "maximum deployed size in bytes")
+	checks := []Check{describedCheck{fakeCheck: fakeCheck{name: "size"}, info: CheckInfo{
+		Description: "Contracts fit",
+		Severity:    SeverityError,
+		Flags:       []string{"limit"},
+		Rules:       []Rule{{Name: "runtime-size", Severity: SeverityError, Description: "Runtime code fits"}},
+	}}}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteCheckHelp(&out, checks, "size", flags))
+	require.Equal(t, `size: Contracts fit
+default severity: error
+
+flags:
+  --limit  maximum deployed size in bytes
+
+rules:
+  runtime-size  error  Runtime code fits
+`, out.String())
+
+	require.EqualError(t, WriteCheckHelp(&out, checks, "gas", flags), `unknown check "gas"`)
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"fmt"
+	"os"
+)
+
+// ColorMode is a --color setting.
+type ColorMode string
+
+const (
+	// ColorAuto colors output written to a terminal unless NO_COLOR is set.
+	ColorAuto ColorMode = "auto"
+	// ColorAlways colors output even when it is not a terminal, e.g. CI logs that render ANSI.
+	ColorAlways ColorMode = "always"
+	// ColorNever never colors output.
+	ColorNever ColorMode = "never"
+)
+
+// ANSI color codes used by Colorize.
+const (
+	ColorRed    = "31"
+	ColorGreen  = "32"
+	ColorYellow = "33"
+	ColorCyan   = "36"
+)
+
+// ParseColorMode parses a --color value.
+func ParseColorMode(value string) (ColorMode, error) {
+	switch mode := ColorMode(value); mode {
+	case ColorAuto, ColorAlways, ColorNever:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid color mode %q: expected auto, always or never", value)
+	}
+}
+
+// UseColor reports whether output written to f should be colored. An explicit mode wins over the
+// NO_COLOR environment convention, which only disables auto-detection.
+func UseColor(mode ColorMode, f *os.File) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		return os.Getenv("NO_COLOR") == "" && IsTerminal(f)
+	}
+}
+
+// Colorize wraps s in the ANSI escape for code when enabled is set.
+func Colorize(enabled bool, code, s string) string {
+	if !enabled {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColorMode(t *testing.T) {
+	for _, value := range []string{"auto", "always", "never"} {
+		mode, err := ParseColorMode(value)
+		require.NoError(t, err)
+		require.Equal(t, ColorMode(value), mode)
+	}
+	_, err := ParseColorMode("sometimes")
+	require.ErrorContains(t, err, `invalid color mode "sometimes"`)
+}
+
+func TestUseColor(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	require.NoError(t, err)
+	defer f.Close()
+
+	t.Setenv("NO_COLOR", "1")
+	require.True(t, UseColor(ColorAlways, f))
+	require.False(t, UseColor(ColorNever, f))
+	require.False(t, UseColor(ColorAuto, f))
+
+	t.Setenv("NO_COLOR", "")
+	require.False(t, UseColor(ColorAuto, f), "not a terminal")
+}
+
+func TestColorize(t *testing.T) {
+	require.Equal(t, "+ f()", Colorize(false, ColorGreen, "+ f()"))
+	require.Equal(t, "\x1b[32m+ f()\x1b[0m", Colorize(true, ColorGreen, "+ f()"))
+}
@@ -0,0 +1,110 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Configurer is implemented by checks that take options from their section of a checks config
+// file.
+type Configurer interface {
+	// Configure applies the check's section, the JSON value under the check's name.
+	Configure(section json.RawMessage) error
+	// ConfigSchema returns the JSON Schema of the check's section.
+	ConfigSchema() map[string]any
+}
+
+// ReadConfigJSON reads a JSON config file, or a YAML one when path ends in .yaml or .yml. YAML
+// is converted to JSON so that both formats share the json field names.
+func ReadConfigJSON(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		var value interface{}
+		if err := yaml.Unmarshal(data, &value); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+		if value == nil {
+			return []byte("{}"), nil
+		}
+		if data, err = json.Marshal(value); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+	}
+	return data, nil
+}
+
+// ReadConfigSections reads a checks config file, such as checks.json or checks.yaml, whose
+// top-level keys name the checks their values configure.
+func ReadConfigSections(path string) (map[string]json.RawMessage, error) {
+	data, err := ReadConfigJSON(path)
+	if err != nil {
+		return nil, err
+	}
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	delete(sections, "$schema")
+	return sections, nil
+}
+
+// ConfigureChecks hands each section to the check of the same name. A section that names no
+// registered check, or a check that takes no configuration, is likely a typo, so it is reported
+// to warn rather than silently ignored.
+func ConfigureChecks(checks []Check, sections map[string]json.RawMessage, warn io.Writer) error {
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		idx := slices.IndexFunc(checks, func(c Check) bool { return c.Name() == name })
+		if idx < 0 {
+			known := make([]string, len(checks))
+			for i, check := range checks {
+				known[i] = check.Name()
+			}
+			_, _ = fmt.Fprintf(warn, "warning: ignoring config section %q: no check is named %q (checks: %s)\n",
+				name, name, strings.Join(known, ", "))
+			continue
+		}
+		configurer, ok := checks[idx].(Configurer)
+		if !ok {
+			_, _ = fmt.Fprintf(warn, "warning: ignoring config section %q: the check takes no configuration\n", name)
+			continue
+		}
+		if err := configurer.Configure(sections[name]); err != nil {
+			return fmt.Errorf("config section %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema of a checks config file, with the schema of each
+// configurable check's section under its name.
+func ConfigSchema(checks []Check) map[string]any {
+	properties := map[string]any{"$schema": map[string]any{"type": "string"}}
+	for _, check := range checks {
+		if configurer, ok := check.(Configurer); ok {
+			properties[check.Name()] = configurer.ConfigSchema()
+		}
+	}
+	return map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"title":      "checks configuration",
+		"type":       "object",
+		"properties": properties,
+	}
+}
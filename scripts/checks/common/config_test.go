@@ -0,0 +1,69 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type configuredCheck struct {
+	fakeCheck
+	section *json.RawMessage
+	err     error
+}
+
+func (c configuredCheck) Configure(section json.RawMessage) error {
+	*c.section = section
+	return c.err
+}
+
+func (c configuredCheck) ConfigSchema() map[string]any {
+	return map[string]any{"type": "object"}
+}
+
+func TestReadConfigSections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checks.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("$schema: ./checks.schema.json\ninterfaces:\n  exclude:\n    access-control: [Vault]\nsize: {}\n"), 0o644))
+
+	sections, err := ReadConfigSections(path)
+	require.NoError(t, err)
+	require.Len(t, sections, 2)
+	require.JSONEq(t, `{"exclude":{"access-control":["Vault"]}}`, string(sections["interfaces"]))
+	require.JSONEq(t, `{}`, string(sections["size"]))
+}
+
+func TestConfigureChecks(t *testing.T) {
+	var section json.RawMessage
+	checks := []Check{
+		configuredCheck{fakeCheck: fakeCheck{name: "interfaces"}, section: &section},
+		fakeCheck{name: "spacers"},
+	}
+	sections := map[string]json.RawMessage{
+		"interfaces": json.RawMessage(`{"sourceRoots":[]}`),
+		"interface":  json.RawMessage(`{}`),
+		"spacers":    json.RawMessage(`{}`),
+	}
+
+	var warnings bytes.Buffer
+	require.NoError(t, ConfigureChecks(checks, sections, &warnings))
+	require.JSONEq(t, `{"sourceRoots":[]}`, string(section))
+	require.Equal(t, `warning: ignoring config section "interface": no check is named "interface" (checks: interfaces, spacers)
+warning: ignoring config section "spacers": the check takes no configuration
+`, warnings.String())
+
+	checks[0] = configuredCheck{fakeCheck: fakeCheck{name: "interfaces"}, section: &section, err: errors.New("bad value")}
+	require.EqualError(t, ConfigureChecks(checks, sections, &bytes.Buffer{}), "config section interfaces: bad value")
+}
+
+func TestConfigSchema(t *testing.T) {
+	var section json.RawMessage
+	schema := ConfigSchema([]Check{configuredCheck{fakeCheck: fakeCheck{name: "interfaces"}, section: &section}, fakeCheck{name: "spacers"}})
+	properties := schema["properties"].(map[string]any)
+	require.Equal(t, map[string]any{"type": "object"}, properties["interfaces"])
+	require.NotContains(t, properties, "spacers")
+}
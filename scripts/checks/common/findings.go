@@ -0,0 +1,139 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Severity ranks how serious a Finding is.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	for _, severity := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		if string(text) == severity.String() {
+			*s = severity
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity %q", text)
+}
+
+// Finding is a single issue reported by a check.
+type Finding struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"`
+	Contract string   `json:"contract,omitempty"`
+	Message  string   `json:"message"`
+	// Rule names the rule of the check that reported the finding, for checks that enforce
+	// several, e.g. missing-interface or abi-mismatch.
+	Rule string `json:"rule,omitempty"`
+	// Details are supporting lines printed beneath the message, such as the individual ABI
+	// differences behind an ABI mismatch.
+	Details []string `json:"details,omitempty"`
+}
+
+// severityMarker returns the marker printed before a finding of the given severity.
+func severityMarker(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "❌ "
+	case SeverityWarning:
+		return "⚠️ "
+	default:
+		return "ℹ️ "
+	}
+}
+
+// severityColor is the color of a finding's marker and message.
+func severityColor(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return ColorRed
+	case SeverityWarning:
+		return ColorYellow
+	default:
+		return ColorCyan
+	}
+}
+
+// detailColor is the color of a detail line that describes an ABI difference like a diff: green
+// for an item to add to the interface, red for one to remove, yellow for one to change. Other
+// lines are not colored.
+func detailColor(detail string) (string, bool) {
+	detail = strings.TrimSpace(detail)
+	switch {
+	case strings.HasPrefix(detail, "ADD "):
+		return ColorGreen, true
+	case strings.HasPrefix(detail, "REMOVE "):
+		return ColorRed, true
+	case strings.HasPrefix(detail, "CHANGE "), strings.Contains(detail, " mismatch on "):
+		return ColorYellow, true
+	default:
+		return "", false
+	}
+}
+
+// WriteGroupedFindings writes findings under a header per contract, or per file for findings
+// that name no contract, with a blank line between groups. A finding's details are indented
+// beneath it. Groups appear in the order of their first finding, and each group keeps the order
+// of its findings. With color set, findings are colored by severity and ABI differences like a
+// diff.
+func WriteGroupedFindings(w io.Writer, findings []Finding, color bool) error {
+	var headers []string
+	groups := make(map[string][]Finding)
+	for _, finding := range findings {
+		header := finding.File
+		if finding.Contract != "" {
+			header = fmt.Sprintf("%s (%s)", finding.Contract, finding.File)
+		}
+		if _, ok := groups[header]; !ok {
+			headers = append(headers, header)
+		}
+		groups[header] = append(groups[header], finding)
+	}
+
+	var b strings.Builder
+	for i, header := range headers {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(header + "\n")
+		for _, finding := range groups[header] {
+			line := severityMarker(finding.Severity) + " " + finding.Message
+			fmt.Fprintf(&b, "  %s\n", Colorize(color, severityColor(finding.Severity), line))
+			for _, detail := range finding.Details {
+				if code, ok := detailColor(detail); ok {
+					detail = Colorize(color, code, detail)
+				}
+				fmt.Fprintf(&b, "      %s\n", detail)
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
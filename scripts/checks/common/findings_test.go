@@ -0,0 +1,88 @@
+package common
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverityString(t *testing.T) {
+	require.Equal(t, "info", SeverityInfo.String())
+	require.Equal(t, "warning", SeverityWarning.String())
+	require.Equal(t, "error", SeverityError.String())
+	require.Equal(t, "unknown", Severity(42).String())
+}
+
+func TestSeverityText(t *testing.T) {
+	for _, severity := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		text, err := severity.MarshalText()
+		require.NoError(t, err)
+		var parsed Severity
+		require.NoError(t, parsed.UnmarshalText(text))
+		require.Equal(t, severity, parsed)
+	}
+
+	var parsed Severity
+	require.ErrorContains(t, parsed.UnmarshalText([]byte("fatal")), `unknown severity "fatal"`)
+}
+
+func TestWriteGroupedFindings(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, WriteGroupedFindings(&b, []Finding{
+		{Severity: SeverityError, File: "forge-artifacts/IA.sol/IA.json", Contract: "IA", Message: "IA: ABI differs from contract",
+			Details: []string{"REMOVE function from interface: function foo()", "ADD function to interface: function bar()"}},
+		{Severity: SeverityWarning, File: "src/A.sol", Contract: "B", Message: "unnamed"},
+		{Severity: SeverityWarning, File: "forge-artifacts/IA.sol/IA.json", Contract: "IA", Message: "IA: stale ignore"},
+		{Severity: SeverityInfo, File: "src/C.sol", Message: "stale"},
+	}, false))
+	require.Equal(t, `IA (forge-artifacts/IA.sol/IA.json)
+  ❌  IA: ABI differs from contract
+      REMOVE function from interface: function foo()
+      ADD function to interface: function bar()
+  ⚠️  IA: stale ignore
+
+B (src/A.sol)
+  ⚠️  unnamed
+
+src/C.sol
+  ℹ️  stale
+`, b.String())
+}
+
+func TestWriteGroupedFindingsColor(t *testing.T) {
+	findings := []Finding{
+		{Severity: SeverityError, File: "forge-artifacts/IA.sol/IA.json", Contract: "IA", Message: "IA: ABI differs from contract",
+			Details: []string{
+				"function foo is overloaded: 1 in the interface, 2 in the contract",
+				"  ADD function to interface: function foo(uint256 a) [foo(uint256)]",
+				"REMOVE event from interface: event Gone()",
+				"CHANGE function bar(uint256): parameter a: uint256 -> uint128",
+			}},
+		{Severity: SeverityWarning, File: "src/A.sol", Contract: "B", Message: "unnamed"},
+	}
+	write := func(color bool) string {
+		var b strings.Builder
+		require.NoError(t, WriteGroupedFindings(&b, findings, color))
+		return b.String()
+	}
+
+	require.Equal(t, "IA (forge-artifacts/IA.sol/IA.json)\n"+
+		"  \x1b[31m❌  IA: ABI differs from contract\x1b[0m\n"+
+		"      function foo is overloaded: 1 in the interface, 2 in the contract\n"+
+		"      \x1b[32m  ADD function to interface: function foo(uint256 a) [foo(uint256)]\x1b[0m\n"+
+		"      \x1b[31mREMOVE event from interface: event Gone()\x1b[0m\n"+
+		"      \x1b[33mCHANGE function bar(uint256): parameter a: uint256 -> uint128\x1b[0m\n"+
+		"\n"+
+		"B (src/A.sol)\n"+
+		"  \x1b[33m⚠️  unnamed\x1b[0m\n", write(true))
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	require.NoError(t, err)
+	defer f.Close()
+	t.Setenv("NO_COLOR", "1")
+	for _, mode := range []ColorMode{ColorNever, ColorAuto} {
+		require.NotContains(t, write(UseColor(mode, f)), "\x1b[", "--color=%s with NO_COLOR set", mode)
+	}
+}
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr,omitempty"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes findings as JUnit XML for test dashboards. Each check becomes a testsuite
+// with a testcase per contract it reported on, which fails when any of the contract's findings
+// is an error. Warnings and info are attached to the testcase as output without failing it. A
+// check with no findings gets a single passing testcase so that it still shows up. checks names
+// the checks that ran; checks that only appear in findings are added. A non-zero elapsed is
+// reported as the run's time.
+func WriteJUnit(w io.Writer, checks []string, findings []Finding, elapsed time.Duration) error {
+	byCheck := make(map[string]map[string][]Finding)
+	for _, check := range checks {
+		byCheck[check] = make(map[string][]Finding)
+	}
+	for _, finding := range findings {
+		if byCheck[finding.Check] == nil {
+			byCheck[finding.Check] = make(map[string][]Finding)
+		}
+		name := finding.Contract
+		if name == "" {
+			name = finding.File
+		}
+		byCheck[finding.Check][name] = append(byCheck[finding.Check][name], finding)
+	}
+
+	root := junitTestSuites{Name: "checks"}
+	if elapsed > 0 {
+		root.Time = fmt.Sprintf("%.3f", elapsed.Seconds())
+	}
+	checkNames := make([]string, 0, len(byCheck))
+	for check := range byCheck {
+		checkNames = append(checkNames, check)
+	}
+	slices.Sort(checkNames)
+
+	for _, check := range checkNames {
+		suite := junitTestSuite{Name: check}
+		contracts := byCheck[check]
+		if len(contracts) == 0 {
+			suite.Cases = append(suite.Cases, junitTestCase{ClassName: check, Name: check})
+		}
+		names := make([]string, 0, len(contracts))
+		for name := range contracts {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			testCase := junitTestCase{ClassName: check, Name: name}
+			var failures, output []string
+			for _, finding := range contracts[name] {
+				line := fmt.Sprintf("%s: %s: %s", finding.Severity, finding.File, finding.Message)
+				for _, detail := range finding.Details {
+					line += "\n  " + detail
+				}
+				if finding.Severity == SeverityError {
+					failures = append(failures, line)
+				} else {
+					output = append(output, line)
+				}
+			}
+			if len(failures) > 0 {
+				message := fmt.Sprintf("%d errors", len(failures))
+				if len(failures) == 1 {
+					message = "1 error"
+				}
+				testCase.Failure = &junitFailure{
+					Message: message,
+					Type:    SeverityError.String(),
+					Text:    strings.Join(failures, "\n"),
+				}
+				suite.Failures++
+			}
+			testCase.SystemOut = strings.Join(output, "\n")
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Tests = len(suite.Cases)
+		root.Tests += suite.Tests
+		root.Failures += suite.Failures
+		root.Suites = append(root.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
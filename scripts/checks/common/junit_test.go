@@ -0,0 +1,42 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	findings := []Finding{
+		{Check: "interfaces", Severity: SeverityError, File: "interfaces/IPortal.sol", Contract: "IPortal", Message: "ABI differs"},
+		{Check: "interfaces", Severity: SeverityWarning, File: "interfaces/IPortal.sol", Contract: "IPortal", Message: "stale ignore"},
+		{Check: "interfaces", Severity: SeverityWarning, File: "src/Bridge.sol", Contract: "Bridge", Message: "no interface <yet>"},
+		{Check: "spacers", Severity: SeverityError, File: "src/Spacer.sol", Message: "bad spacer"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnit(&buf, []string{"interfaces", "gas-budget"}, findings, 1500*time.Millisecond))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="checks" tests="4" failures="2" time="1.500">
+  <testsuite name="gas-budget" tests="1" failures="0">
+    <testcase classname="gas-budget" name="gas-budget"></testcase>
+  </testsuite>
+  <testsuite name="interfaces" tests="2" failures="1">
+    <testcase classname="interfaces" name="Bridge">
+      <system-out>warning: src/Bridge.sol: no interface &lt;yet&gt;</system-out>
+    </testcase>
+    <testcase classname="interfaces" name="IPortal">
+      <failure message="1 error" type="error">error: interfaces/IPortal.sol: ABI differs</failure>
+      <system-out>warning: interfaces/IPortal.sol: stale ignore</system-out>
+    </testcase>
+  </testsuite>
+  <testsuite name="spacers" tests="1" failures="1">
+    <testcase classname="spacers" name="src/Spacer.sol">
+      <failure message="1 error" type="error">error: src/Spacer.sol: bad spacer</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// progressRedrawInterval limits how often an interactive progress line is redrawn.
+const progressRedrawInterval = 100 * time.Millisecond
+
+// Progress reports how many of a known number of items have been processed. On a terminal it
+// redraws a single line; otherwise it prints a line every Every items. A nil *Progress is a
+// no-op, so callers can leave it unset when progress is disabled.
+type Progress struct {
+	w     io.Writer
+	label string
+	total int
+	tty   bool
+	every int
+
+	mtx      sync.Mutex
+	done     int
+	lastDraw time.Time
+}
+
+// NewProgress creates a Progress for total items, described by label (e.g. "artifacts").
+func NewProgress(w io.Writer, label string, total int, tty bool, every int) *Progress {
+	if every <= 0 {
+		every = 1
+	}
+	return &Progress{w: w, label: label, total: total, tty: tty, every: every}
+}
+
+// Increment records one processed item.
+func (p *Progress) Increment() {
+	if p == nil {
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.done++
+	if p.tty {
+		if now := time.Now(); now.Sub(p.lastDraw) >= progressRedrawInterval {
+			p.lastDraw = now
+			_, _ = fmt.Fprintf(p.w, "\rprocessed %d/%d %s", p.done, p.total, p.label)
+		}
+		return
+	}
+	if p.done%p.every == 0 && p.done != p.total {
+		_, _ = fmt.Fprintf(p.w, "processed %d/%d %s\n", p.done, p.total, p.label)
+	}
+}
+
+// Finish prints the final count and ends the progress line.
+func (p *Progress) Finish() {
+	if p == nil {
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.tty {
+		_, _ = fmt.Fprintf(p.w, "\rprocessed %d/%d %s\n", p.done, p.total, p.label)
+		return
+	}
+	_, _ = fmt.Fprintf(p.w, "processed %d/%d %s\n", p.done, p.total, p.label)
+}
+
+// TrackProgress wraps processor so that every processed file increments p.
+func TrackProgress[T any](p *Progress, processor FileProcessor[T]) FileProcessor[T] {
+	return func(path string) (T, []error) {
+		defer p.Increment()
+		return processor(path)
+	}
+}
+
+// IsTerminal reports whether f is attached to a terminal.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
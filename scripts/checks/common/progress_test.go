@@ -0,0 +1,47 @@
+package common
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgress(t *testing.T) {
+	t.Run("non-interactive", func(t *testing.T) {
+		var out bytes.Buffer
+		p := NewProgress(&out, "artifacts", 5, false, 2)
+		processor := TrackProgress(p, func(path string) (string, []error) { return path, nil })
+		for i := 0; i < 5; i++ {
+			_, _ = processor("file")
+		}
+		p.Finish()
+		require.Equal(t, "processed 2/5 artifacts\nprocessed 4/5 artifacts\nprocessed 5/5 artifacts\n", out.String())
+	})
+
+	t.Run("interactive", func(t *testing.T) {
+		var out bytes.Buffer
+		p := NewProgress(&out, "artifacts", 3, true, 0)
+		for i := 0; i < 3; i++ {
+			p.Increment()
+		}
+		p.Finish()
+		// Redraws are rate limited, so only the first and the final line are guaranteed.
+		require.Contains(t, out.String(), "\rprocessed 1/3 artifacts")
+		require.True(t, bytes.HasSuffix(out.Bytes(), []byte("\rprocessed 3/3 artifacts\n")))
+	})
+
+	t.Run("nil is a no-op", func(t *testing.T) {
+		var p *Progress
+		p.Increment()
+		p.Finish()
+	})
+}
+
+func TestIsTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	require.NoError(t, err)
+	defer f.Close()
+	require.False(t, IsTerminal(f))
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Shard selects a stable slice of the contracts checked by a run, so that a large run can be
+// split across CI runners. The zero value selects every contract.
+type Shard struct {
+	// Index is 1-based.
+	Index int
+	Count int
+}
+
+// ParseShard parses a shard written as "i/n", e.g. "2/4".
+func ParseShard(value string) (Shard, error) {
+	index, count, ok := strings.Cut(value, "/")
+	if !ok {
+		return Shard{}, fmt.Errorf("invalid shard %q: expected i/n", value)
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return Shard{}, fmt.Errorf("invalid shard %q: %w", value, err)
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return Shard{}, fmt.Errorf("invalid shard %q: %w", value, err)
+	}
+	if n < 1 || i < 1 || i > n {
+		return Shard{}, fmt.Errorf("invalid shard %q: need 1 <= i <= n", value)
+	}
+	return Shard{Index: i, Count: n}, nil
+}
+
+func (s Shard) String() string {
+	if s.Count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// Contains reports whether the shard checks the named contract. Contracts are assigned by a
+// hash of their name, so the assignment does not depend on which other contracts exist.
+func (s Shard) Contains(name string) bool {
+	if s.Count <= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return int(h.Sum32()%uint32(s.Count)) == s.Index-1
+}
+
+// Report is the JSON form of the findings of a run, optionally restricted to a shard.
+type Report struct {
+	Shard    string    `json:"shard,omitempty"`
+	Findings []Finding `json:"findings"`
+}
+
+// WriteReport writes the findings of a run to path.
+func WriteReport(path string, shard Shard, findings []Finding) error {
+	if findings == nil {
+		findings = []Finding{}
+	}
+	return WriteJSONAtomic(Report{Shard: shard.String(), Findings: findings}, path)
+}
+
+// ReadReport reads a report written by WriteReport.
+func ReadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// MergeReports combines the reports of a sharded run. Every shard of the run must be present
+// exactly once, so that a missing runner cannot turn a failing run into a passing one. Reports
+// without a shard are run as a single shard.
+func MergeReports(reports []*Report) ([]Finding, error) {
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no reports to merge")
+	}
+
+	seen := make(map[int]bool)
+	var count int
+	var findings []Finding
+	for _, report := range reports {
+		shard := Shard{Index: 1, Count: 1}
+		if report.Shard != "" {
+			var err error
+			if shard, err = ParseShard(report.Shard); err != nil {
+				return nil, err
+			}
+		}
+		if count != 0 && shard.Count != count {
+			return nil, fmt.Errorf("reports are from runs split into %d and %d shards", count, shard.Count)
+		}
+		count = shard.Count
+		if seen[shard.Index] {
+			return nil, fmt.Errorf("shard %s appears more than once", shard)
+		}
+		seen[shard.Index] = true
+		findings = append(findings, report.Findings...)
+	}
+	if len(seen) != count {
+		return nil, fmt.Errorf("got %d of %d shards", len(seen), count)
+	}
+	return findings, nil
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShard(t *testing.T) {
+	shard, err := ParseShard("2/4")
+	require.NoError(t, err)
+	require.Equal(t, Shard{Index: 2, Count: 4}, shard)
+	require.Equal(t, "2/4", shard.String())
+
+	for _, value := range []string{"2", "0/4", "5/4", "a/4", "1/0"} {
+		_, err := ParseShard(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestShardContains(t *testing.T) {
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = fmt.Sprintf("Contract%d", i)
+	}
+
+	counts := make(map[string]int)
+	for i := 1; i <= 3; i++ {
+		shard := Shard{Index: i, Count: 3}
+		for _, name := range names {
+			if shard.Contains(name) {
+				counts[name]++
+			}
+		}
+	}
+	for _, name := range names {
+		require.Equal(t, 1, counts[name], "%s must be in exactly one shard", name)
+		require.True(t, Shard{}.Contains(name))
+	}
+
+	// The assignment is part of the CI contract, so it must not change between releases.
+	require.True(t, Shard{Index: 2, Count: 3}.Contains("OptimismPortal2"))
+}
+
+func TestMergeReports(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, shard Shard, findings ...Finding) *Report {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		require.NoError(t, WriteReport(path, shard, findings))
+		report, err := ReadReport(path)
+		require.NoError(t, err)
+		return report
+	}
+
+	first := write("1.json", Shard{Index: 1, Count: 2}, Finding{Check: "a", Severity: SeverityError, File: "src/A.sol", Message: "bad"})
+	second := write("2.json", Shard{Index: 2, Count: 2}, Finding{Check: "b", Severity: SeverityWarning, File: "src/B.sol", Message: "meh"})
+	require.Equal(t, SeverityError, first.Findings[0].Severity)
+
+	findings, err := MergeReports([]*Report{second, first})
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	require.True(t, HasErrors(findings))
+
+	_, err = MergeReports([]*Report{first})
+	require.ErrorContains(t, err, "got 1 of 2 shards")
+	_, err = MergeReports([]*Report{first, first})
+	require.ErrorContains(t, err, "shard 1/2 appears more than once")
+	_, err = MergeReports([]*Report{first, write("3.json", Shard{Index: 2, Count: 3})})
+	require.ErrorContains(t, err, "split into 2 and 3 shards")
+
+	unsharded := write("all.json", Shard{})
+	require.Empty(t, unsharded.Findings)
+	findings, err = MergeReports([]*Report{unsharded})
+	require.NoError(t, err)
+	require.Empty(t, findings)
+}
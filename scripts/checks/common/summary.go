@@ -0,0 +1,124 @@
+package common
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+)
+
+// SummaryTopFindings is how many findings a Summary lists.
+const SummaryTopFindings = 10
+
+// Summary is a condensed view of a run for chat notifications, small enough to template into a
+// message without reading the full report.
+type Summary struct {
+	// Pass reports whether the run has no errors.
+	Pass   bool          `json:"pass"`
+	Totals SummaryTotals `json:"totals"`
+	// Checks holds the totals of each check that reported findings.
+	Checks map[string]SummaryTotals `json:"checks"`
+	// Rules holds the totals of each rule that reported findings, for findings that name one.
+	Rules map[string]SummaryTotals `json:"rules"`
+	// TopFindings lists up to SummaryTopFindings errors and warnings, errors first.
+	TopFindings []Finding `json:"topFindings"`
+}
+
+// SummaryTotals counts findings by severity, and the contracts with an error or a warning.
+type SummaryTotals struct {
+	Errors    int `json:"errors"`
+	Warnings  int `json:"warnings"`
+	Info      int `json:"info"`
+	Contracts int `json:"contracts"`
+}
+
+// Summarize condenses findings, which are expected in their reported order, into a Summary.
+// The result depends only on the findings, so the same run always produces the same summary.
+func Summarize(findings []Finding) Summary {
+	summary := Summary{
+		Pass:        !HasErrors(findings),
+		Checks:      make(map[string]SummaryTotals),
+		Rules:       make(map[string]SummaryTotals),
+		TopFindings: []Finding{},
+	}
+	var all summaryCounter
+	checks := make(map[string]*summaryCounter)
+	rules := make(map[string]*summaryCounter)
+	count := func(counters map[string]*summaryCounter, key string, finding Finding) {
+		if counters[key] == nil {
+			counters[key] = &summaryCounter{}
+		}
+		counters[key].add(finding)
+	}
+	for _, finding := range findings {
+		all.add(finding)
+		count(checks, finding.Check, finding)
+		if finding.Rule != "" {
+			count(rules, finding.Rule, finding)
+		}
+	}
+	summary.Totals = all.totals()
+	for name, counter := range checks {
+		summary.Checks[name] = counter.totals()
+	}
+	for name, counter := range rules {
+		summary.Rules[name] = counter.totals()
+	}
+
+	for _, finding := range findings {
+		if finding.Severity >= SeverityWarning {
+			summary.TopFindings = append(summary.TopFindings, finding)
+		}
+	}
+	slices.SortStableFunc(summary.TopFindings, func(a, b Finding) int {
+		return int(b.Severity) - int(a.Severity)
+	})
+	if len(summary.TopFindings) > SummaryTopFindings {
+		summary.TopFindings = summary.TopFindings[:SummaryTopFindings]
+	}
+	return summary
+}
+
+// summaryCounter accumulates the SummaryTotals of a set of findings.
+type summaryCounter struct {
+	SummaryTotals
+	contracts map[string]bool
+}
+
+func (c *summaryCounter) add(finding Finding) {
+	switch finding.Severity {
+	case SeverityError:
+		c.Errors++
+	case SeverityWarning:
+		c.Warnings++
+	default:
+		c.Info++
+	}
+	if finding.Severity >= SeverityWarning {
+		name := finding.Contract
+		if name == "" {
+			name = finding.File
+		}
+		if c.contracts == nil {
+			c.contracts = make(map[string]bool)
+		}
+		c.contracts[name] = true
+	}
+}
+
+func (c *summaryCounter) totals() SummaryTotals {
+	totals := c.SummaryTotals
+	totals.Contracts = len(c.contracts)
+	return totals
+}
+
+// WriteSummaryFile writes the Summary of findings to path.
+func WriteSummaryFile(path string, findings []Finding) error {
+	return WriteJSONAtomic(Summarize(findings), path)
+}
+
+// WriteSummary writes the Summary of findings to w as indented JSON.
+func WriteSummary(w io.Writer, findings []Finding) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Summarize(findings))
+}
@@ -0,0 +1,58 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	findings := []Finding{
+		{Check: "interfaces", Severity: SeverityWarning, File: "src/A.sol", Contract: "A", Message: "a"},
+		{Check: "interfaces", Severity: SeverityError, File: "src/B.sol", Contract: "B", Message: "b"},
+		{Check: "interfaces", Severity: SeverityError, File: "src/B.sol", Contract: "B", Message: "b2"},
+		{Check: "spacers", Severity: SeverityInfo, File: "src/C.sol", Contract: "C", Message: "c"},
+	}
+	summary := Summarize(findings)
+	require.False(t, summary.Pass)
+	require.Equal(t, SummaryTotals{Errors: 2, Warnings: 1, Info: 1, Contracts: 2}, summary.Totals)
+	require.Equal(t, map[string]SummaryTotals{
+		"interfaces": {Errors: 2, Warnings: 1, Contracts: 2},
+		"spacers":    {Info: 1},
+	}, summary.Checks)
+	require.Equal(t, []Finding{findings[1], findings[2], findings[0]}, summary.TopFindings)
+
+	require.True(t, Summarize(findings[:1]).Pass)
+}
+
+func TestSummarizeRules(t *testing.T) {
+	summary := Summarize([]Finding{
+		{Check: "interfaces", Rule: "missing-interface", Severity: SeverityError, Contract: "A"},
+		{Check: "interfaces", Rule: "missing-interface", Severity: SeverityError, Contract: "B"},
+		{Check: "interfaces", Rule: "abi-mismatch", Severity: SeverityError, Contract: "IC"},
+		{Check: "interfaces", Severity: SeverityInfo, Contract: "ID"},
+	})
+	require.Equal(t, SummaryTotals{Errors: 3, Info: 1, Contracts: 3}, summary.Checks["interfaces"])
+	require.Equal(t, map[string]SummaryTotals{
+		"missing-interface": {Errors: 2, Contracts: 2},
+		"abi-mismatch":      {Errors: 1, Contracts: 1},
+	}, summary.Rules)
+}
+
+func TestSummarizeLimitsTopFindings(t *testing.T) {
+	var findings []Finding
+	for i := range SummaryTopFindings + 5 {
+		findings = append(findings, Finding{Check: "interfaces", Severity: SeverityError, Contract: fmt.Sprintf("C%d", i)})
+	}
+	summary := Summarize(findings)
+	require.Len(t, summary.TopFindings, SummaryTopFindings)
+	require.Equal(t, SummaryTopFindings+5, summary.Totals.Errors)
+}
+
+func TestWriteSummary(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSummary(&buf, nil))
+	require.JSONEq(t, `{"pass":true,"totals":{"errors":0,"warnings":0,"info":0,"contracts":0},"checks":{},"rules":{},"topFindings":[]}`, buf.String())
+}
@@ -2,10 +2,15 @@ package common
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -17,6 +22,14 @@ import (
 // EnvSuppressErrorReporter, when set, silences ErrorReporter stderr output. Used by tests.
 const EnvSuppressErrorReporter = "SUPPRESS_ERROR_REPORTER"
 
+// DefaultExcludedDirs are the vendored and generated trees that file discovery skips.
+var DefaultExcludedDirs = []string{"lib", "node_modules", "src/vendor"}
+
+// ExcludedDirs are the directories, relative to the root being searched, whose files FindFiles
+// and FindFilesFS never return, whatever the include patterns. Tools add to it with
+// --exclude-dir.
+var ExcludedDirs = slices.Clone(DefaultExcludedDirs)
+
 type ErrorReporter struct {
 	hasErr atomic.Bool
 	outMtx sync.Mutex
@@ -35,6 +48,14 @@ func (e *ErrorReporter) Fail(msg string, args ...any) {
 	e.hasErr.Store(true)
 }
 
+func (e *ErrorReporter) Warn(msg string, args ...any) {
+	e.outMtx.Lock()
+	if os.Getenv(EnvSuppressErrorReporter) == "" {
+		_, _ = fmt.Fprintf(os.Stderr, "⚠️  "+msg+"\n", args...)
+	}
+	e.outMtx.Unlock()
+}
+
 func (e *ErrorReporter) HasError() bool {
 	return e.hasErr.Load()
 }
@@ -44,6 +65,12 @@ type Void struct{}
 type FileProcessor[T any] func(path string) (T, []error)
 
 func ProcessFiles[T any](files []string, processor FileProcessor[T]) (map[string]T, error) {
+	return ProcessFilesContext(context.Background(), files, processor)
+}
+
+// ProcessFilesContext is ProcessFiles that stops starting new files once ctx is done. It then
+// returns the results of the files already processed along with ctx's error.
+func ProcessFilesContext[T any](ctx context.Context, files []string, processor FileProcessor[T]) (map[string]T, error) {
 	g := errgroup.Group{}
 	g.SetLimit(runtime.NumCPU())
 
@@ -52,7 +79,13 @@ func ProcessFiles[T any](files []string, processor FileProcessor[T]) (map[string
 	var mtx sync.Mutex
 
 	for _, path := range files {
+		if ctx.Err() != nil {
+			break
+		}
 		g.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
 			result, errs := processor(path)
 			if len(errs) > 0 {
 				for _, err := range errs {
@@ -71,7 +104,7 @@ func ProcessFiles[T any](files []string, processor FileProcessor[T]) (map[string
 	if reporter.HasError() {
 		return nil, fmt.Errorf("processing failed")
 	}
-	return results, nil
+	return results, ctx.Err()
 }
 
 func ProcessFilesGlob[T any](includes, excludes []string, processor FileProcessor[T]) (map[string]T, error) {
@@ -83,28 +116,45 @@ func ProcessFilesGlob[T any](includes, excludes []string, processor FileProcesso
 }
 
 func FindFiles(includes, excludes []string) ([]string, error) {
-	included, err := globAll(includes)
+	return FindFilesFS(os.DirFS("."), includes, excludes)
+}
+
+// FindFilesFS is FindFiles over fsys, such as an artifact Bundle.
+func FindFilesFS(fsys fs.FS, includes, excludes []string) ([]string, error) {
+	included, err := globAll(fsys, includes)
 	if err != nil {
 		return nil, err
 	}
-	excluded, err := globAll(excludes)
+	excluded, err := globAll(fsys, excludes)
 	if err != nil {
 		return nil, err
 	}
 
 	files := make([]string, 0, len(included))
 	for path := range included {
-		if _, skip := excluded[path]; !skip {
+		if _, skip := excluded[path]; !skip && !InExcludedDir(path) {
 			files = append(files, path)
 		}
 	}
 	return files, nil
 }
 
-func globAll(patterns []string) (map[string]struct{}, error) {
+// InExcludedDir reports whether a slash-separated relative path is inside one of ExcludedDirs.
+func InExcludedDir(path string) bool {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "./")
+	for _, dir := range ExcludedDirs {
+		dir = strings.Trim(filepath.ToSlash(filepath.Clean(dir)), "/")
+		if path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func globAll(fsys fs.FS, patterns []string) (map[string]struct{}, error) {
 	out := make(map[string]struct{})
 	for _, pattern := range patterns {
-		matches, err := doublestar.Glob(os.DirFS("."), pattern)
+		matches, err := doublestar.Glob(fsys, pattern)
 		if err != nil {
 			return nil, fmt.Errorf("glob pattern error: %w", err)
 		}
@@ -130,16 +180,54 @@ func ReadForgeArtifact(path string) (*solc.ForgeArtifact, error) {
 }
 
 func WriteJSON(data any, path string) error {
+	jsonData, err := encodeJSON(data)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// WriteJSONAtomic is like WriteJSON, but writes to a temporary file in the same directory and
+// renames it over path, so readers never see a partially written file.
+func WriteJSONAtomic(data any, path string) error {
+	jsonData, err := encodeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(jsonData); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+	return nil
+}
+
+func encodeJSON(data any) ([]byte, error) {
 	var out bytes.Buffer
 	enc := json.NewEncoder(&out)
 	enc.SetEscapeHTML(false)
 	enc.SetIndent("", "  ")
 	if err := enc.Encode(data); err != nil {
-		return fmt.Errorf("failed to encode data: %w", err)
+		return nil, fmt.Errorf("failed to encode data: %w", err)
 	}
-	jsonData := bytes.TrimRight(out.Bytes(), "\n")
-	if err := os.WriteFile(path, jsonData, 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-	return nil
+	return bytes.TrimRight(out.Bytes(), "\n"), nil
 }
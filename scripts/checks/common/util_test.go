@@ -1,9 +1,11 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"sync"
 	"testing"
@@ -19,6 +21,7 @@ func suppressErrorReporter(t *testing.T) {
 func writeFiles(t *testing.T, files map[string]string) {
 	t.Helper()
 	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
 		require.NoError(t, os.WriteFile(name, []byte(content), 0644))
 	}
 }
@@ -40,6 +43,9 @@ func TestErrorReporter(t *testing.T) {
 	reporter := NewErrorReporter()
 	require.False(t, reporter.HasError(), "new reporter should not have errors")
 
+	reporter.Warn("test warning")
+	require.False(t, reporter.HasError(), "warnings should not mark the reporter as failed")
+
 	reporter.Fail("test error")
 	require.True(t, reporter.HasError(), "reporter should have error after Fail")
 }
@@ -84,6 +90,16 @@ func TestProcessFiles(t *testing.T) {
 		require.Len(t, results, 2)
 		require.Equal(t, 5, results["path1"].Counter)
 	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		results, err := ProcessFilesContext(ctx, files, func(path string) (string, []error) {
+			return path, nil
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Empty(t, results)
+	})
 }
 
 func TestProcessFilesGlob(t *testing.T) {
@@ -135,6 +151,25 @@ func TestFindFiles(t *testing.T) {
 	require.Equal(t, []string{"test1.txt", "test2.txt"}, found)
 }
 
+func TestFindFilesExcludedDirs(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeFiles(t, map[string]string{
+		"src/L1/Portal.sol":           "",
+		"src/vendor/Vendored.sol":     "",
+		"src/vendors/NotVendored.sol": "",
+		"lib/forge-std/Test.sol":      "",
+		"generated/Bindings.sol":      "",
+	})
+	prev := ExcludedDirs
+	ExcludedDirs = append(slices.Clone(DefaultExcludedDirs), "generated/")
+	t.Cleanup(func() { ExcludedDirs = prev })
+
+	found, err := FindFiles([]string{"**/*.sol"}, nil)
+	require.NoError(t, err)
+	sort.Strings(found)
+	require.Equal(t, []string{"src/L1/Portal.sol", "src/vendors/NotVendored.sol"}, found)
+}
+
 func TestReadForgeArtifact(t *testing.T) {
 	tmpFile := filepath.Join(t.TempDir(), "Test.json")
 	artifactContent := `{
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// abiIgnoreRegex matches `// @checks:abi-ignore <item>` comments in interface sources, where
+// <item> is an ABI item as printed by formatABIItem in the ADD/REMOVE output, e.g.
+// `function deprecatedThing()`.
+var abiIgnoreRegex = regexp.MustCompile(`(?m)^\s*//+\s*@checks:abi-ignore\s+(.+?)\s*$`)
+
+// readABIIgnores returns the ABI differences that the interface source at path allows. A
+// missing source, e.g. for a vendored artifact, allows nothing.
+func readABIIgnores(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cwd, path)
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ignores []string
+	for _, match := range abiIgnoreRegex.FindAllStringSubmatch(string(content), -1) {
+		ignores = append(ignores, strings.Join(strings.Fields(match[1]), " "))
+	}
+	return ignores, nil
+}
+
+// applyABIIgnores drops the diffs allowed by ignores and returns the remaining diffs along with
+// the ignores that matched nothing.
+func applyABIIgnores(diffs []abiDiff, ignores []string) ([]abiDiff, []string) {
+	used := make(map[string]bool, len(ignores))
+	var remaining []abiDiff
+	for _, diff := range diffs {
+		formatted := formatABIItem(diff.item)
+		ignored := false
+		for _, ignore := range ignores {
+			if ignore == formatted {
+				used[ignore] = true
+				ignored = true
+			}
+		}
+		if !ignored {
+			remaining = append(remaining, diff)
+		}
+	}
+
+	var stale []string
+	for _, ignore := range ignores {
+		if !used[ignore] {
+			stale = append(stale, ignore)
+		}
+	}
+	return remaining, stale
+}
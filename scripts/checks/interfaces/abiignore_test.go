@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadABIIgnores(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"interfaces/IThing.sol": `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+// @checks:abi-ignore function deprecatedThing()
+/// @checks:abi-ignore   event  Legacy(uint256 value)
+interface IThing {}
+`,
+	})
+
+	ignores, err := readABIIgnores("interfaces/IThing.sol")
+	require.NoError(t, err)
+	require.Equal(t, []string{"function deprecatedThing()", "event Legacy(uint256 value)"}, ignores)
+
+	ignores, err = readABIIgnores("interfaces/IMissing.sol")
+	require.NoError(t, err)
+	require.Empty(t, ignores)
+}
+
+func TestCompareInterfaceABIIgnores(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"interfaces/IThing.sol": "// @checks:abi-ignore function deprecatedThing()\n" +
+			"// @checks:abi-ignore function removedLongAgo()\n" +
+			"interface IThing {}\n",
+		"forge-artifacts/Thing.sol/Thing.json": `{"abi":[
+			{"type":"function","name":"thing","inputs":[],"outputs":[]},
+			{"type":"function","name":"deprecatedThing","inputs":[],"outputs":[]}
+		]}`,
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	target := func(abi string) *checkTarget {
+		return &checkTarget{
+			path:     "forge-artifacts/IThing.sol/IThing.json",
+			name:     "IThing",
+			artifact: &Artifact{ABI: json.RawMessage(abi), AST: ArtifactAST{AbsolutePath: "interfaces/IThing.sol"}},
+		}
+	}
+
+	findings, err := compareInterfaceABI(target(`[{"type":"function","name":"thing","inputs":[],"outputs":[]}]`))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	require.Equal(t, `IThing: @checks:abi-ignore "function removedLongAgo()" matches no ABI difference and can be removed`, findings[0].Message)
+
+	require.NoError(t, os.WriteFile("interfaces/IThing.sol", []byte("interface IThing {}\n"), 0644))
+	findings, err = compareInterfaceABI(target(`[{"type":"function","name":"thing","inputs":[],"outputs":[]}]`))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, "IThing: ABI differs from contract", findings[0].Message)
+}
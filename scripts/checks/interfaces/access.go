@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// defaultPrivilegedPatterns are the function name patterns that usually need access control.
+var defaultPrivilegedPatterns = []string{"set*", "upgrade*", "pause*", "unpause*", "withdraw*"}
+
+// checkAccessControl flags public and external functions whose names suggest a privileged
+// action but that have no modifiers at all, prompting an access-control review. Functions that
+// check permissions inline can opt out with ignoreTag.
+func checkAccessControl(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || t.definition.ContractKind != "contract" || config.isExcluded("access-control", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	patterns := config.AccessControl.Patterns
+	if len(patterns) == 0 {
+		patterns = defaultPrivilegedPatterns
+	}
+
+	var findings []common.Finding
+	for _, fn := range node.children("nodes") {
+		if fn.nodeType() != "FunctionDefinition" || getString(fn, "kind") != "function" {
+			continue
+		}
+		if visibility := getString(fn, "visibility"); visibility != "public" && visibility != "external" {
+			continue
+		}
+		if mutability := getString(fn, "stateMutability"); mutability == "view" || mutability == "pure" {
+			continue
+		}
+		if len(fn.children("modifiers")) > 0 || hasIgnoreTag(fn, "access-control") {
+			continue
+		}
+
+		pattern, err := matchingPattern(patterns, fn.name())
+		if err != nil {
+			return nil, err
+		}
+		if pattern == "" {
+			continue
+		}
+		findings = append(findings, newFinding("access-control", common.SeverityWarning, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s matches privileged pattern %q but has no modifiers; review its access control",
+				t.name, fn.name(), pattern)))
+	}
+	return findings, nil
+}
+
+// matchingPattern returns the first pattern that matches name, or "" if none does.
+func matchingPattern(patterns []string, name string) (string, error) {
+	for _, pattern := range patterns {
+		ok, err := path.Match(pattern, name)
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if ok {
+			return pattern, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// accessControlArtifact has an unguarded setter, a guarded setter, an opted-out setter, an
+// internal setter, a view getter matching the pattern and an unrelated function.
+const accessControlArtifact = `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"setFee","kind":"function","visibility":"external","stateMutability":"nonpayable","modifiers":[]},
+		{"nodeType":"FunctionDefinition","name":"setOwner","kind":"function","visibility":"external","stateMutability":"nonpayable","modifiers":[
+			{"nodeType":"ModifierInvocation","modifierName":{"nodeType":"IdentifierPath","name":"onlyOwner"}}]},
+		{"nodeType":"FunctionDefinition","name":"setGuarded","kind":"function","visibility":"public","stateMutability":"nonpayable","modifiers":[],
+			"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore access-control"}},
+		{"nodeType":"FunctionDefinition","name":"_setFee","kind":"function","visibility":"internal","stateMutability":"nonpayable","modifiers":[]},
+		{"nodeType":"FunctionDefinition","name":"settings","kind":"function","visibility":"external","stateMutability":"view","modifiers":[]},
+		{"nodeType":"FunctionDefinition","name":"deposit","kind":"function","visibility":"external","stateMutability":"payable","modifiers":[]}
+	]}
+]}}`
+
+func TestCheckAccessControl(t *testing.T) {
+	t.Run("default patterns", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkAccessControl(delegatecallTarget(t, accessControlArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, `Test.setFee matches privileged pattern "set*" but has no modifiers; review its access control`, findings[0].Message)
+	})
+
+	t.Run("configured patterns", func(t *testing.T) {
+		setConfig(t, &Config{AccessControl: AccessControlConfig{Patterns: []string{"deposit"}}})
+		findings, err := checkAccessControl(delegatecallTarget(t, accessControlArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Contains(t, findings[0].Message, "Test.deposit")
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"access-control": {"Test"}}})
+		findings, err := checkAccessControl(delegatecallTarget(t, accessControlArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
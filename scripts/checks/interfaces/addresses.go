@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// addressRegistryCache holds the canonical addresses loaded from AddressRegistryConfig.Registry.
+type addressRegistryCache struct {
+	once      sync.Once
+	addresses map[string]string
+	err       error
+}
+
+var addressRegistry = &addressRegistryCache{}
+
+// loadAddressRegistry reads the registry once per run. Keys are either a constant name or
+// <Contract>.<constant> to disambiguate constants with the same name.
+func loadAddressRegistry() (map[string]string, error) {
+	addressRegistry.once.Do(func() {
+		path := config.AddressRegistry.Registry
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cwd, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			addressRegistry.err = fmt.Errorf("failed to read address registry: %w", err)
+			return
+		}
+		if err := json.Unmarshal(data, &addressRegistry.addresses); err != nil {
+			addressRegistry.err = fmt.Errorf("failed to parse address registry %s: %w", path, err)
+		}
+	})
+	return addressRegistry.addresses, addressRegistry.err
+}
+
+// checkAddressConstants fails when an address constant in one of the configured files differs
+// from the canonical address registry. Constants missing from the registry are not checked.
+func checkAddressConstants(t *checkTarget) ([]common.Finding, error) {
+	cfg := config.AddressRegistry
+	if cfg.Registry == "" || !slices.Contains(cfg.Files, t.sourcePath()) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	registry, err := loadAddressRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []common.Finding
+	for _, decl := range node.children("nodes") {
+		found, ok := addressConstant(decl)
+		if !ok {
+			continue
+		}
+		expected, ok := registry[t.name+"."+decl.name()]
+		if !ok {
+			expected, ok = registry[decl.name()]
+		}
+		if !ok || strings.EqualFold(expected, found) {
+			continue
+		}
+		findings = append(findings, newFinding("address-registry", common.SeverityError, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s is %s but the address registry expects %s", t.name, decl.name(), found, expected)))
+	}
+	return findings, nil
+}
+
+// addressConstant returns the literal value of an `address constant` declaration, unwrapping an
+// `address(...)` conversion.
+func addressConstant(decl astNode) (string, bool) {
+	if decl.nodeType() != "VariableDeclaration" || decl["constant"] != true || decl.child("typeName").name() != "address" {
+		return "", false
+	}
+	value := decl.child("value")
+	if value.nodeType() == "FunctionCall" && value.child("expression").nodeType() == "ElementaryTypeNameExpression" {
+		args := value.children("arguments")
+		if len(args) != 1 {
+			return "", false
+		}
+		value = args[0]
+	}
+	if value.nodeType() != "Literal" || getString(value, "kind") != "number" {
+		return "", false
+	}
+	return getString(value, "value"), true
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// addressArtifact declares a matching constant, a mismatching one wrapped in address(...), one
+// missing from the registry and a non-address constant.
+const addressArtifact = `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"library","nodes":[
+		{"nodeType":"VariableDeclaration","name":"WETH","constant":true,
+			"typeName":{"nodeType":"ElementaryTypeName","name":"address"},
+			"value":{"nodeType":"Literal","kind":"number","value":"0x4200000000000000000000000000000000000006"}},
+		{"nodeType":"VariableDeclaration","name":"L2_BRIDGE","constant":true,
+			"typeName":{"nodeType":"ElementaryTypeName","name":"address"},
+			"value":{"nodeType":"FunctionCall","expression":{"nodeType":"ElementaryTypeNameExpression"},
+				"arguments":[{"nodeType":"Literal","kind":"number","value":"0x4200000000000000000000000000000000000011"}]}},
+		{"nodeType":"VariableDeclaration","name":"UNLISTED","constant":true,
+			"typeName":{"nodeType":"ElementaryTypeName","name":"address"},
+			"value":{"nodeType":"Literal","kind":"number","value":"0x4200000000000000000000000000000000000099"}},
+		{"nodeType":"VariableDeclaration","name":"COUNT","constant":true,
+			"typeName":{"nodeType":"ElementaryTypeName","name":"uint256"},
+			"value":{"nodeType":"Literal","kind":"number","value":"2048"}}
+	]}
+]}}`
+
+func TestCheckAddressConstants(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"addresses.json": `{
+			"WETH": "0x4200000000000000000000000000000000000006",
+			"L2_BRIDGE": "0x4200000000000000000000000000000000000099",
+			"Test.L2_BRIDGE": "0x4200000000000000000000000000000000000010"
+		}`,
+	})
+	reset := func() {
+		addressRegistry = &addressRegistryCache{}
+	}
+	reset()
+	t.Cleanup(reset)
+
+	t.Run("flags mismatches", func(t *testing.T) {
+		setConfig(t, &Config{AddressRegistry: AddressRegistryConfig{Registry: "addresses.json", Files: []string{"src/Test.sol"}}})
+		findings, err := checkAddressConstants(delegatecallTarget(t, addressArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, "Test.L2_BRIDGE is 0x4200000000000000000000000000000000000011 but the address registry expects 0x4200000000000000000000000000000000000010", findings[0].Message)
+	})
+
+	t.Run("file not designated", func(t *testing.T) {
+		setConfig(t, &Config{AddressRegistry: AddressRegistryConfig{Registry: "addresses.json", Files: []string{"src/Other.sol"}}})
+		findings, err := checkAddressConstants(delegatecallTarget(t, addressArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("missing registry", func(t *testing.T) {
+		reset()
+		setConfig(t, &Config{AddressRegistry: AddressRegistryConfig{Registry: "missing.json", Files: []string{"src/Test.sol"}}})
+		_, err := checkAddressConstants(delegatecallTarget(t, addressArtifact))
+		require.ErrorContains(t, err, "failed to read address registry")
+	})
+}
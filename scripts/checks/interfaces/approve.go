@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkApproveRace warns when a source contract exposes ERC20 approve(address,uint256) but no
+// increaseAllowance or decreaseAllowance, and its approve does not require the old or new
+// allowance to be zero. Changing an allowance from one nonzero value to another lets the spender
+// front-run the change and spend both. This is a heuristic: the reset-to-zero pattern is any
+// comparison with a literal 0 in the body of the contract's own approve. The contract or its
+// approve can opt out with ignoreTag.
+func checkApproveRace(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || t.definition.ContractKind != "contract" || config.isExcluded("approve-race", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	signatures := make(map[string]bool)
+	for _, item := range items {
+		if getString(item, "type") == "function" {
+			signatures[abiSignature(item)] = true
+		}
+	}
+	if !signatures["approve(address,uint256)"] ||
+		signatures["increaseAllowance(address,uint256)"] || signatures["decreaseAllowance(address,uint256)"] {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if hasIgnoreTag(node, "approve-race") {
+		return nil, nil
+	}
+	for _, fn := range node.children("nodes") {
+		if fn.nodeType() != "FunctionDefinition" || fn.name() != "approve" ||
+			len(fn.child("parameters").children("parameters")) != 2 {
+			continue
+		}
+		if hasIgnoreTag(fn, "approve-race") || comparesWithZero(fn.child("body")) {
+			return nil, nil
+		}
+	}
+
+	return []common.Finding{newFinding("approve-race", common.SeverityWarning, t.sourcePath(), t.name,
+		fmt.Sprintf("%s exposes approve(address,uint256) without increaseAllowance/decreaseAllowance or a reset-to-zero check; "+
+			"changing a nonzero allowance can be front-run", t.name))}, nil
+}
+
+// comparesWithZero reports whether body compares any expression with the literal 0, e.g.
+// require(_amount == 0 || allowance[msg.sender][_spender] == 0).
+func comparesWithZero(body astNode) bool {
+	found := false
+	walkAST(body, func(n astNode, _ []astNode) bool {
+		if found {
+			return false
+		}
+		if n.nodeType() == "BinaryOperation" && (getString(n, "operator") == "==" || getString(n, "operator") == "!=") {
+			for _, side := range []astNode{n.child("leftExpression"), n.child("rightExpression")} {
+				if side.nodeType() == "Literal" && getString(side, "value") == "0" {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckApproveRace(t *testing.T) {
+	const approveABI = `{"type":"function","name":"approve","inputs":[{"name":"_spender","type":"address"},{"name":"_amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}`
+	const increaseABI = `{"type":"function","name":"increaseAllowance","inputs":[{"name":"_spender","type":"address"},{"name":"_added","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}`
+	artifact := func(abi []string, body string) string {
+		return `{"abi":[` + strings.Join(abi, ",") + `],"ast":{"absolutePath":"src/Test.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+				{"nodeType":"FunctionDefinition","name":"approve","parameters":{"parameters":[
+					{"nodeType":"VariableDeclaration","name":"_spender"},{"nodeType":"VariableDeclaration","name":"_amount"}
+				]},"body":{"nodeType":"Block","statements":[` + body + `]}}
+			]}
+		]}}`
+	}
+	resetToZero := `{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall","arguments":[
+		{"nodeType":"BinaryOperation","operator":"==","leftExpression":{"nodeType":"Identifier","name":"_amount"},
+			"rightExpression":{"nodeType":"Literal","kind":"number","value":"0"}}
+	]}}`
+
+	t.Run("flags bare approve", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkApproveRace(delegatecallTarget(t, artifact([]string{approveABI}, "")))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test exposes approve(address,uint256) without increaseAllowance/decreaseAllowance or a reset-to-zero check; changing a nonzero allowance can be front-run", findings[0].Message)
+	})
+
+	t.Run("allowance helpers", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkApproveRace(delegatecallTarget(t, artifact([]string{approveABI, increaseABI}, "")))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("reset to zero", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkApproveRace(delegatecallTarget(t, artifact([]string{approveABI}, resetToZero)))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"approve-race": {"Test"}}})
+		findings, err := checkApproveRace(delegatecallTarget(t, artifact([]string{approveABI}, "")))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
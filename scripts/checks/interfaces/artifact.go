@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// decodeArtifact reads the sections of a forge artifact the checks use, streaming over the top
+// level object so that the rest of it, mostly bytecode and the metadata sources, never has to be
+// held in memory at once. Decoding a whole artifact buffers the entire file first.
+func decodeArtifact(r io.Reader) (*Artifact, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var ast, abi json.RawMessage
+	var gasEstimates *GasEstimates
+	var metadata *ArtifactMetadata
+	var storageLayout *StorageLayout
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %v in artifact", token)
+		}
+		switch key {
+		case "ast":
+			err = dec.Decode(&ast)
+		case "abi":
+			err = dec.Decode(&abi)
+		case "gasEstimates":
+			err = dec.Decode(&gasEstimates)
+		case "metadata":
+			err = dec.Decode(&metadata)
+		case "storageLayout":
+			err = dec.Decode(&storageLayout)
+		default:
+			err = dec.Decode(&discard{})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %q: %w", key, err)
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	artifact := Artifact{StorageLayout: storageLayout}
+	if err := artifact.setSections(ast, abi, gasEstimates, metadata); err != nil {
+		return nil, err
+	}
+	return &artifact, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v in artifact, got %v", delim, token)
+	}
+	return nil
+}
+
+// discard is a json.Unmarshaler that ignores the value it is given, whatever its type.
+type discard struct{}
+
+func (*discard) UnmarshalJSON([]byte) error {
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// forgeArtifact returns a forge-shaped artifact with bytecode and metadata sized roughly like
+// those of a large contract.
+func forgeArtifact(functions int) []byte {
+	var abi, nodes []string
+	for i := range functions {
+		abi = append(abi, fmt.Sprintf(`{"type":"function","name":"f%d","inputs":[{"name":"x","type":"uint256","internalType":"uint256"}],"outputs":[],"stateMutability":"nonpayable"}`, i))
+		nodes = append(nodes, fmt.Sprintf(`{"nodeType":"FunctionDefinition","name":"f%d","src":"%d:10:0","body":{"nodeType":"Block","statements":[]}}`, i, i*10))
+	}
+	code := strings.Repeat("6080604052", 10000)
+	return []byte(fmt.Sprintf(`{
+		"abi":[%s],
+		"bytecode":{"object":"0x%s","sourceMap":"%s","linkReferences":{}},
+		"deployedBytecode":{"object":"0x%s","sourceMap":"%s","linkReferences":{},"immutableReferences":{}},
+		"methodIdentifiers":{"f0(uint256)":"00000000"},
+		"rawMetadata":"%s",
+		"metadata":{"compiler":{"version":"0.8.15"},"settings":{"optimizer":{"enabled":true,"runs":999999}},"sources":{"src/Test.sol":{"keccak256":"0x00","urls":["bzz-raw://00"]}}},
+		"storageLayout":{"storage":[],"types":{}},
+		"gasEstimates":{"external":{"f0(uint256)":"2400"}},
+		"ast":{"absolutePath":"src/Test.sol","nodes":[
+			{"nodeType":"PragmaDirective","literals":["solidity","0.8",".15"]},
+			{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[%s]}
+		]},
+		"id":0
+	}`, strings.Join(abi, ","), code, strings.Repeat("1:2:0;", 5000), code, strings.Repeat("1:2:0;", 5000),
+		strings.Repeat("x", 20000), strings.Join(nodes, ",")))
+}
+
+func TestDecodeArtifact(t *testing.T) {
+	data := forgeArtifact(3)
+
+	var full Artifact
+	require.NoError(t, json.Unmarshal(data, &full))
+	streamed, err := decodeArtifact(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	require.JSONEq(t, string(full.ABI), string(streamed.ABI))
+	require.JSONEq(t, string(full.RawAST), string(streamed.RawAST))
+	require.Equal(t, full.AST, streamed.AST)
+	require.Equal(t, full.GasEstimates, streamed.GasEstimates)
+	require.Equal(t, &OptimizerSettings{Enabled: true, Runs: 999999}, streamed.Metadata.Settings.Optimizer)
+	require.Equal(t, full.Metadata, streamed.Metadata)
+	require.Equal(t, &StorageLayout{Storage: []StorageEntry{}, Types: map[string]StorageTypeInfo{}}, streamed.StorageLayout)
+	require.Equal(t, full.StorageLayout, streamed.StorageLayout)
+	require.Equal(t, "Test", getContractDefinition(streamed, "Test").Name)
+
+	_, err = decodeArtifact(strings.NewReader(`[]`))
+	require.ErrorContains(t, err, "expected { in artifact")
+	_, err = decodeArtifact(strings.NewReader(`{"abi":[`))
+	require.ErrorContains(t, err, `failed to decode "abi"`)
+}
+
+// BenchmarkReadArtifact compares decoding a whole artifact with streaming over it. It uses a
+// real artifact when forge-artifacts has been built, and a synthetic one otherwise.
+func BenchmarkReadArtifact(b *testing.B) {
+	path := filepath.Join("..", "..", "..", "forge-artifacts", "OptimismPortal2.sol", "OptimismPortal2.json")
+	if _, err := os.Stat(path); err != nil {
+		path = filepath.Join(b.TempDir(), "Test.json")
+		require.NoError(b, os.WriteFile(path, forgeArtifact(200), 0644))
+	}
+
+	b.Run("full", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			file, err := os.Open(path)
+			require.NoError(b, err)
+			var artifact Artifact
+			require.NoError(b, json.NewDecoder(file).Decode(&artifact))
+			file.Close()
+		}
+	})
+
+	b.Run("streamed", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, err := readArtifact(path)
+			require.NoError(b, err)
+		}
+	})
+}
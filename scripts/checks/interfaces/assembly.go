@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkAssemblyComments warns about inline assembly blocks in source contracts that are not
+// directly preceded by a comment explaining why assembly is needed.
+func checkAssemblyComments(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("assembly-comment", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var source []byte
+	var findings []common.Finding
+	var readErr error
+	walkAST(node, func(n astNode, parents []astNode) bool {
+		if n.nodeType() != "InlineAssembly" || readErr != nil {
+			return true
+		}
+		offset, ok := srcOffset(n)
+		if !ok {
+			return false
+		}
+		if source == nil {
+			source, readErr = readSource(t.sourcePath())
+			if readErr != nil {
+				return false
+			}
+		}
+		if offset > len(source) {
+			return false
+		}
+
+		line := bytes.Count(source[:offset], []byte("\n")) + 1
+		if !isCommentLine(sourceLine(source, line-1)) {
+			findings = append(findings, newFinding("assembly-comment", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s has an assembly block at %s:%d without a comment explaining it",
+					t.name, functionLabel(enclosingFunction(parents)), t.sourcePath(), line)))
+		}
+		return false
+	})
+	if readErr != nil {
+		return nil, readErr
+	}
+	return findings, nil
+}
+
+// srcOffset returns the byte offset of a node from its "start:length:file" src attribute.
+func srcOffset(n astNode) (int, bool) {
+	offset, _, ok := srcRange(n)
+	return offset, ok
+}
+
+// srcRange returns the byte offset and length of a node from its src attribute.
+func srcRange(n astNode) (int, int, bool) {
+	parts := strings.Split(getString(n, "src"), ":")
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	offset, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	length, err := strconv.Atoi(parts[1])
+	return offset, length, err == nil
+}
+
+func readSource(path string) ([]byte, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cwd, path)
+	}
+	return os.ReadFile(path)
+}
+
+// sourceLine returns the 1-indexed line of source, or "" if it does not exist.
+func sourceLine(source []byte, line int) string {
+	lines := strings.Split(string(source), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return lines[line-1]
+}
+
+// isCommentLine reports whether line is, or ends with, a comment.
+func isCommentLine(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "//") || strings.HasPrefix(line, "/*") || strings.HasPrefix(line, "*") ||
+		strings.HasSuffix(line, "*/") || strings.Contains(line, "//")
+}
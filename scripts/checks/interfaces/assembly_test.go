@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAssemblyComments(t *testing.T) {
+	source := `contract Test {
+    function documented() external {
+        // Read the slot directly to avoid an extra SLOAD.
+        assembly {
+            pop(sload(0))
+        }
+    }
+
+    function undocumented() external {
+        uint256 x = 1;
+        assembly ("memory-safe") {
+            pop(x)
+        }
+    }
+}
+`
+	setupSourceFixture(t, map[string]string{"src/Test.sol": source})
+
+	documented := strings.Index(source, "assembly {")
+	undocumented := strings.Index(source, `assembly ("memory-safe")`)
+	artifact := fmt.Sprintf(`{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+			{"nodeType":"FunctionDefinition","name":"documented","body":{"nodeType":"Block","statements":[
+				{"nodeType":"InlineAssembly","src":"%d:40:0"}]}},
+			{"nodeType":"FunctionDefinition","name":"undocumented","body":{"nodeType":"Block","statements":[
+				{"nodeType":"InlineAssembly","src":"%d:40:0"}]}}
+		]}
+	]}}`, documented, undocumented)
+
+	t.Run("flags undocumented blocks", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkAssemblyComments(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.undocumented has an assembly block at src/Test.sol:11 without a comment explaining it", findings[0].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"assembly-comment": {"Test"}}})
+		findings, err := checkAssemblyComments(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
+
+func TestIsCommentLine(t *testing.T) {
+	for _, line := range []string{"// why", "  /// @dev why", "/* why */", " * why", "x = 1; // why"} {
+		require.True(t, isCommentLine(line), line)
+	}
+	for _, line := range []string{"", "uint256 x = 1;", "}"} {
+		require.False(t, isCommentLine(line), line)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// astNode is a loosely-typed view of a solc AST node. ASTNode only carries the top-level
+// fields the interface comparison needs; checks that descend into function bodies walk the
+// raw tree instead.
+type astNode map[string]interface{}
+
+func (n astNode) nodeType() string {
+	return getString(n, "nodeType")
+}
+
+func (n astNode) name() string {
+	return getString(n, "name")
+}
+
+// child returns the node stored under key, or nil if there is none.
+func (n astNode) child(key string) astNode {
+	if m, ok := n[key].(map[string]interface{}); ok {
+		return m
+	}
+	return nil
+}
+
+// children returns the nodes stored in the list under key.
+func (n astNode) children(key string) []astNode {
+	list, _ := n[key].([]interface{})
+	out := make([]astNode, 0, len(list))
+	for _, elem := range list {
+		if m, ok := elem.(map[string]interface{}); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// walkAST calls visit for node and every node beneath it in depth-first order, passing the
+// chain of ancestors (outermost first). Keys are visited in sorted order so that traversal is
+// deterministic. Returning false from visit skips the node's children. The parents slice is
+// reused between calls and must not be retained.
+func walkAST(node astNode, visit func(node astNode, parents []astNode) bool) {
+	walkValue(map[string]interface{}(node), nil, visit)
+}
+
+func walkValue(value interface{}, parents []astNode, visit func(astNode, []astNode) bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		node := astNode(v)
+		if _, ok := v["nodeType"]; ok {
+			if !visit(node, parents) {
+				return
+			}
+			parents = append(parents, node)
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			walkValue(v[key], parents, visit)
+		}
+	case []interface{}:
+		for _, elem := range v {
+			walkValue(elem, parents, visit)
+		}
+	}
+}
+
+// enclosingFunction returns the innermost function or modifier definition in parents.
+func enclosingFunction(parents []astNode) astNode {
+	for i := len(parents) - 1; i >= 0; i-- {
+		switch parents[i].nodeType() {
+		case "FunctionDefinition", "ModifierDefinition":
+			return parents[i]
+		}
+	}
+	return nil
+}
+
+// functionLabel returns a human-readable name for a function definition, falling back to its
+// kind for unnamed functions such as constructors, fallbacks and receive functions.
+func functionLabel(fn astNode) string {
+	if fn == nil {
+		return "<top level>"
+	}
+	if name := fn.name(); name != "" {
+		return name
+	}
+	if kind := getString(fn, "kind"); kind != "" {
+		return kind
+	}
+	return "<unnamed>"
+}
+
+// tree returns the artifact's full AST, decoding it on first use.
+func (a *Artifact) tree() astNode {
+	if a.astTree == nil && len(a.RawAST) > 0 {
+		var tree map[string]interface{}
+		if err := json.Unmarshal(a.RawAST, &tree); err == nil {
+			a.astTree = tree
+		}
+	}
+	return a.astTree
+}
+
+// contractNode returns the raw ContractDefinition node for contractName, or nil.
+func (a *Artifact) contractNode(contractName string) astNode {
+	tree := a.tree()
+	if tree == nil {
+		return nil
+	}
+	for _, node := range tree.children("nodes") {
+		if node.nodeType() == "ContractDefinition" && node.name() == contractName {
+			return node
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func parseAST(t *testing.T, src string) astNode {
+	t.Helper()
+	var node map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(src), &node))
+	return node
+}
+
+func TestWalkAST(t *testing.T) {
+	root := parseAST(t, `{
+		"nodeType": "ContractDefinition",
+		"name": "Test",
+		"nodes": [
+			{"nodeType": "FunctionDefinition", "name": "a", "body": {"nodeType": "Block", "statements": []}},
+			{"nodeType": "FunctionDefinition", "name": "b", "typeDescriptions": {"typeString": "x"}}
+		]
+	}`)
+
+	var visited []string
+	walkAST(root, func(n astNode, parents []astNode) bool {
+		visited = append(visited, n.nodeType()+":"+n.name())
+		return n.name() != "b"
+	})
+	require.Equal(t, []string{"ContractDefinition:Test", "FunctionDefinition:a", "Block:", "FunctionDefinition:b"}, visited)
+}
+
+func TestEnclosingFunction(t *testing.T) {
+	root := parseAST(t, `{
+		"nodeType": "ContractDefinition",
+		"nodes": [
+			{"nodeType": "FunctionDefinition", "name": "", "kind": "constructor", "body": {"nodeType": "Block", "statements": [
+				{"nodeType": "ExpressionStatement"}
+			]}}
+		]
+	}`)
+
+	var label string
+	walkAST(root, func(n astNode, parents []astNode) bool {
+		if n.nodeType() == "ExpressionStatement" {
+			label = functionLabel(enclosingFunction(parents))
+		}
+		return true
+	})
+	require.Equal(t, "constructor", label)
+	require.Equal(t, "<top level>", functionLabel(nil))
+}
+
+func TestContractNode(t *testing.T) {
+	var artifact Artifact
+	require.NoError(t, json.Unmarshal([]byte(`{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"PragmaDirective","literals":["solidity","^","0.8.0"]},
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract"}
+	]}}`), &artifact))
+
+	require.Equal(t, "src/Test.sol", artifact.AST.AbsolutePath)
+	require.NotNil(t, artifact.contractNode("Test"))
+	require.Nil(t, artifact.contractNode("Other"))
+}
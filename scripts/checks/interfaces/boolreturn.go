@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkBoolReturns reports interface functions whose only return value is an unnamed bool, the
+// ERC20-style success flag that callers too often ignore. It is advisory unless --strict is set:
+// the finding prompts a review of whether the function should revert instead. A function can opt
+// out with ignoreTag.
+func checkBoolReturns(t *checkTarget) ([]common.Finding, error) {
+	if t.definition.ContractKind != "interface" || !strings.HasPrefix(t.name, "I") ||
+		config.isExcluded("bool-return", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	declared := declaredFunctions(t.artifact.contractNode(t.name))
+
+	severity := common.SeverityInfo
+	if strict {
+		severity = common.SeverityWarning
+	}
+
+	var findings []common.Finding
+	for _, item := range items {
+		if getString(item, "type") != "function" {
+			continue
+		}
+		outputs, _ := item["outputs"].([]interface{})
+		if len(outputs) != 1 {
+			continue
+		}
+		output, _ := outputs[0].(map[string]interface{})
+		if getString(output, "type") != "bool" || getString(output, "name") != "" {
+			continue
+		}
+		if fn, ok := declared[abiParamNamesKey(item)]; ok && hasIgnoreTag(fn, "bool-return") {
+			continue
+		}
+		findings = append(findings, newFinding("bool-return", severity, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s returns an unnamed bool success flag; consider reverting on failure instead, or name the return value if it is not one",
+				t.name, abiSignature(item))))
+	}
+	return findings, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// boolReturnArtifact is an interface with a success flag, a named bool result, a bool returned
+// alongside another value and an opted-out success flag.
+const boolReturnArtifact = `{"abi":[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"paused","inputs":[],"outputs":[{"name":"paused_","type":"bool"}]},
+	{"type":"function","name":"tryGet","inputs":[],"outputs":[{"name":"","type":"bool"},{"name":"","type":"uint256"}]},
+	{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"bool"}]}
+],"ast":{"absolutePath":"interfaces/ITest.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"ITest","contractKind":"interface","nodes":[
+		{"nodeType":"FunctionDefinition","name":"transfer","kind":"function","parameters":{"parameters":[{"name":"to"}]}},
+		{"nodeType":"FunctionDefinition","name":"approve","kind":"function","parameters":{"parameters":[{"name":"spender"}]},
+			"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore bool-return"}}
+	]}
+]}}`
+
+func TestCheckBoolReturns(t *testing.T) {
+	target := func(t *testing.T) *checkTarget {
+		var artifact Artifact
+		require.NoError(t, json.Unmarshal([]byte(boolReturnArtifact), &artifact))
+		return &checkTarget{path: "forge-artifacts/ITest.sol/ITest.json", name: "ITest", artifact: &artifact, definition: getContractDefinition(&artifact, "ITest")}
+	}
+
+	t.Run("flags success flags", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkBoolReturns(target(t))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityInfo, findings[0].Severity)
+		require.Contains(t, findings[0].Message, "ITest.transfer(address) returns an unnamed bool success flag")
+	})
+
+	t.Run("strict escalates", func(t *testing.T) {
+		setConfig(t, &Config{})
+		prev := strict
+		strict = true
+		t.Cleanup(func() { strict = prev })
+
+		findings, err := checkBoolReturns(target(t))
+		require.NoError(t, err)
+		require.NotEmpty(t, findings)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"bool-return": {"ITest"}}})
+		findings, err := checkBoolReturns(target(t))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("contracts", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkBoolReturns(delegatecallTarget(t, `{"abi":[{"type":"function","name":"f","inputs":[],"outputs":[{"name":"","type":"bool"}]}],
+			"ast":{"absolutePath":"src/Test.sol","nodes":[{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[]}]}}`))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
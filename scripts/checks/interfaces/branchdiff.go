@@ -0,0 +1,341 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// interfaceSurfaceChange describes how one interface's ABI differs between two builds.
+type interfaceSurfaceChange struct {
+	Interface string `json:"interface"`
+	// Status is "added" or "removed" when the interface exists in only one build, otherwise
+	// "changed".
+	Status string `json:"status"`
+	// Score counts the added, removed and changed members.
+	Score   int            `json:"score"`
+	Added   []string       `json:"added,omitempty"`
+	Removed []string       `json:"removed,omitempty"`
+	Changed []memberChange `json:"changed,omitempty"`
+}
+
+// memberChange is an ABI member whose signature is unchanged but whose outputs, mutability,
+// parameter names or indexing differ.
+type memberChange struct {
+	Base string `json:"base"`
+	Head string `json:"head"`
+}
+
+// parseCompareBranches splits the --compare-branches value into the base and head artifact
+// directories.
+func parseCompareBranches(value string) (base, head string, err error) {
+	dirs := splitList(value)
+	if len(dirs) != 2 {
+		return "", "", fmt.Errorf("--compare-branches expects <base dir>,<head dir>, got %q", value)
+	}
+	return dirs[0], dirs[1], nil
+}
+
+// readInterfaceABIs reads the normalized ABI of every interface declared under interfaces/ from
+// a forge artifacts directory built from one branch. Building the branch is left to the caller.
+func readInterfaceABIs(dir string) (map[string][]map[string]interface{}, error) {
+	abis := make(map[string][]map[string]interface{})
+	err := walkBranchArtifacts(dir, func(path, name string, artifact *Artifact) error {
+		definition := getContractDefinition(artifact, name)
+		if definition == nil || definition.ContractKind != "interface" ||
+			!strings.HasPrefix(filepath.ToSlash(artifact.AST.AbsolutePath), "interfaces/") {
+			return nil
+		}
+		abi, err := normalizeABI(artifact.ABI)
+		if err != nil {
+			return fmt.Errorf("%s: failed to normalize ABI: %w", path, err)
+		}
+		abis[name] = abi
+		return nil
+	})
+	return abis, err
+}
+
+// walkBranchArtifacts calls visit with the first artifact of every contract in a forge artifacts
+// directory, in path order.
+func walkBranchArtifacts(dir string, visit func(path, name string, artifact *Artifact) error) error {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".json") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("artifacts directory %s does not exist; build the branch first", dir)
+	}
+	if err != nil {
+		return err
+	}
+	slices.Sort(paths)
+
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		name := contractNameFromArtifactPath(path)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		artifact, err := readArtifact(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := visit(path, name, artifact); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contractSurface is the external functions of a source contract in one build, and the
+// visibility of the functions it declares without exposing them.
+type contractSurface struct {
+	functions map[string]map[string]interface{} // by canonical signature
+	hidden    map[string]string                 // name -> internal or private
+}
+
+// readContractSurfaces reads the surface of every contract declared under src/ from a forge
+// artifacts directory built from one branch.
+func readContractSurfaces(dir string) (map[string]contractSurface, error) {
+	surfaces := make(map[string]contractSurface)
+	err := walkBranchArtifacts(dir, func(path, name string, artifact *Artifact) error {
+		definition := getContractDefinition(artifact, name)
+		if definition == nil || definition.ContractKind != "contract" ||
+			!strings.HasPrefix(filepath.ToSlash(artifact.AST.AbsolutePath), "src/") {
+			return nil
+		}
+		abi, err := normalizeABI(artifact.ABI)
+		if err != nil {
+			return fmt.Errorf("%s: failed to normalize ABI: %w", path, err)
+		}
+		surface := contractSurface{
+			functions: make(map[string]map[string]interface{}),
+			hidden:    nonExternalFunctions(artifact.contractNode(name)),
+		}
+		for _, item := range abi {
+			if getString(item, "type") == "function" {
+				surface.functions[abiSignature(item)] = item
+			}
+		}
+		surfaces[name] = surface
+		return nil
+	})
+	return surfaces, err
+}
+
+// diffContractFunctions describes, sorted, the functions of contracts in both builds that left
+// the external ABI: demoted to internal or private, replaced by an external function of the same
+// name with other parameters, or removed entirely. Integrators calling them break either way.
+func diffContractFunctions(base, head map[string]contractSurface) []string {
+	var lines []string
+	for name, baseSurface := range base {
+		headSurface, ok := head[name]
+		if !ok {
+			continue
+		}
+		for signature, item := range baseSurface.functions {
+			if _, ok := headSurface.functions[signature]; ok {
+				continue
+			}
+			fn := getString(item, "name")
+			nature := "removed"
+			if visibility, ok := headSurface.hidden[fn]; ok {
+				nature = "demoted to " + visibility
+			} else {
+				var overloads []string
+				for headSignature, headItem := range headSurface.functions {
+					if getString(headItem, "name") == fn {
+						overloads = append(overloads, headSignature)
+					}
+				}
+				if len(overloads) > 0 {
+					slices.Sort(overloads)
+					nature = "replaced by " + strings.Join(overloads, ", ")
+				}
+			}
+			lines = append(lines, fmt.Sprintf("%s.%s left the external ABI: %s", name, signature, nature))
+		}
+	}
+	slices.Sort(lines)
+	return lines
+}
+
+// diffInterfaceSurfaces compares the interface ABIs of two builds, matching members by type and
+// canonical signature. Interfaces without changes are omitted; the result is sorted by name.
+func diffInterfaceSurfaces(base, head map[string][]map[string]interface{}) []interfaceSurfaceChange {
+	names := make([]string, 0, len(base)+len(head))
+	for name := range base {
+		names = append(names, name)
+	}
+	for name := range head {
+		if _, ok := base[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var changes []interfaceSurfaceChange
+	for _, name := range names {
+		baseABI, inBase := base[name]
+		headABI, inHead := head[name]
+		change := interfaceSurfaceChange{Interface: name, Status: "changed"}
+		switch {
+		case !inBase:
+			change.Status = "added"
+		case !inHead:
+			change.Status = "removed"
+		}
+
+		baseMembers := abiMembers(baseABI)
+		headMembers := abiMembers(headABI)
+		for _, key := range sortedKeys(headMembers) {
+			baseItem, ok := baseMembers[key]
+			switch {
+			case !ok:
+				change.Added = append(change.Added, formatABIMember(headMembers[key]))
+			case !abiItemsEqual(baseItem, headMembers[key]):
+				change.Changed = append(change.Changed, memberChange{
+					Base: formatABIMember(baseItem),
+					Head: formatABIMember(headMembers[key]),
+				})
+			}
+		}
+		for _, key := range sortedKeys(baseMembers) {
+			if _, ok := headMembers[key]; !ok {
+				change.Removed = append(change.Removed, formatABIMember(baseMembers[key]))
+			}
+		}
+
+		change.Score = len(change.Added) + len(change.Removed) + len(change.Changed)
+		if change.Status != "changed" || change.Score > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// abiMembers indexes an ABI by item type and canonical signature. Constructors are skipped
+// since normalizeABI adds one to every ABI.
+func abiMembers(abi []map[string]interface{}) map[string]map[string]interface{} {
+	members := make(map[string]map[string]interface{}, len(abi))
+	for _, item := range abi {
+		if getString(item, "type") == "constructor" {
+			continue
+		}
+		members[getString(item, "type")+" "+abiSignature(item)] = item
+	}
+	return members
+}
+
+func sortedKeys(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func abiItemsEqual(a, b map[string]interface{}) bool {
+	aJSON, _ := json.Marshal(a)
+	bJSON, _ := json.Marshal(b)
+	return string(aJSON) == string(bJSON)
+}
+
+// formatABIMember is formatABIItem plus the state mutability of functions that declare one
+// other than nonpayable, so that mutability-only changes are visible.
+func formatABIMember(item map[string]interface{}) string {
+	formatted := formatABIItem(item)
+	mutability := getString(item, "stateMutability")
+	if mutability == "" || mutability == "nonpayable" {
+		return formatted
+	}
+	if head, returns, ok := strings.Cut(formatted, " returns ("); ok {
+		return head + " " + mutability + " returns (" + returns
+	}
+	return formatted + " " + mutability
+}
+
+// printInterfaceSurfaceDiff writes the interface changes between two artifact directories as
+// JSON and returns them.
+func printInterfaceSurfaceDiff(baseDir, headDir string) ([]interfaceSurfaceChange, error) {
+	base, err := readInterfaceABIs(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	head, err := readInterfaceABIs(headDir)
+	if err != nil {
+		return nil, err
+	}
+	changes := diffInterfaceSurfaces(base, head)
+	if changes == nil {
+		changes = []interfaceSurfaceChange{}
+	}
+	return changes, printJSON(changes)
+}
+
+// writeContractFunctionRemovals writes the functions that left the external ABI of a source
+// contract between two artifact directories to w, one per line.
+func writeContractFunctionRemovals(w io.Writer, baseDir, headDir string) error {
+	base, err := readContractSurfaces(baseDir)
+	if err != nil {
+		return err
+	}
+	head, err := readContractSurfaces(headDir)
+	if err != nil {
+		return err
+	}
+	for _, line := range diffContractFunctions(base, head) {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gateInterfaceSurfaceChanges enforces sign-off on interface changes: it writes what changed to
+// w and reports whether the run passes, which it does when nothing changed or approved is set.
+// The gate counts added and removed interfaces even when they have no members. With color set,
+// added, removed and changed members are colored like a diff.
+func gateInterfaceSurfaceChanges(w io.Writer, changes []interfaceSurfaceChange, approved, color bool) bool {
+	if len(changes) == 0 {
+		return true
+	}
+	total := 0
+	var b strings.Builder
+	for _, change := range changes {
+		total += change.Score
+		fmt.Fprintf(&b, "%s (%s, score %d)\n", change.Interface, change.Status, change.Score)
+		for _, member := range change.Added {
+			fmt.Fprintf(&b, "  %s\n", common.Colorize(color, common.ColorGreen, "+ "+member))
+		}
+		for _, member := range change.Removed {
+			fmt.Fprintf(&b, "  %s\n", common.Colorize(color, common.ColorRed, "- "+member))
+		}
+		for _, member := range change.Changed {
+			fmt.Fprintf(&b, "  %s\n", common.Colorize(color, common.ColorYellow, "~ "+member.Base+" -> "+member.Head))
+		}
+	}
+	if approved {
+		fmt.Fprintf(&b, "interface changes approved: %d interfaces, score %d\n", len(changes), total)
+	} else {
+		fmt.Fprintf(&b, "error: %d interfaces changed (score %d) without approval; rerun with --abi-change-approved once the change is signed off\n", len(changes), total)
+	}
+	_, _ = io.WriteString(w, b.String())
+	return approved
+}
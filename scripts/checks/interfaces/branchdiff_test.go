@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompareBranches(t *testing.T) {
+	base, head, err := parseCompareBranches("base-artifacts, head-artifacts")
+	require.NoError(t, err)
+	require.Equal(t, "base-artifacts", base)
+	require.Equal(t, "head-artifacts", head)
+
+	_, _, err = parseCompareBranches("only-one")
+	require.ErrorContains(t, err, "<base dir>,<head dir>")
+}
+
+func TestInterfaceSurfaceDiff(t *testing.T) {
+	artifact := func(name, path, kind, abi string) string {
+		return `{"abi":` + abi + `,"ast":{"absolutePath":"` + path + `","nodes":[
+			{"nodeType":"ContractDefinition","name":"` + name + `","contractKind":"` + kind + `"}]}}`
+	}
+	setupSourceFixture(t, map[string]string{
+		"base/IPortal.sol/IPortal.json": artifact("IPortal", "interfaces/L1/IPortal.sol", "interface", `[
+			{"type":"function","name":"deposit","inputs":[{"name":"to","type":"address","internalType":"address"}],"outputs":[],"stateMutability":"payable"},
+			{"type":"function","name":"paused","inputs":[],"outputs":[{"name":"","type":"bool","internalType":"bool"}],"stateMutability":"view"},
+			{"type":"event","name":"Deposited","inputs":[{"name":"to","type":"address","internalType":"address","indexed":true}]}
+		]`),
+		"base/IOld.sol/IOld.json":       artifact("IOld", "interfaces/L1/IOld.sol", "interface", `[]`),
+		"base/IStable.sol/IStable.json": artifact("IStable", "interfaces/L1/IStable.sol", "interface", `[{"type":"function","name":"f","inputs":[],"outputs":[]}]`),
+		"base/Portal.sol/Portal.json":   artifact("Portal", "src/L1/Portal.sol", "contract", `[]`),
+		"head/IStable.sol/IStable.json": artifact("IStable", "interfaces/L1/IStable.sol", "interface", `[{"type":"function","name":"f","inputs":[],"outputs":[]}]`),
+		"head/IERC20.sol/IERC20.json":   artifact("IERC20", "lib/token/IERC20.sol", "interface", `[]`),
+		"head/INew.sol/INew.json":       artifact("INew", "interfaces/L2/INew.sol", "interface", `[{"type":"function","name":"g","inputs":[],"outputs":[]}]`),
+		"head/IPortal.sol/IPortal.json": artifact("IPortal", "interfaces/L1/IPortal.sol", "interface", `[
+			{"type":"function","name":"deposit","inputs":[{"name":"to","type":"address","internalType":"address"}],"outputs":[],"stateMutability":"nonpayable"},
+			{"type":"function","name":"depositTo","inputs":[{"name":"to","type":"address","internalType":"address"},{"name":"amount","type":"uint256","internalType":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+			{"type":"event","name":"Deposited","inputs":[{"name":"to","type":"address","internalType":"address","indexed":true}]}
+		]`),
+	})
+
+	base, err := readInterfaceABIs("base")
+	require.NoError(t, err)
+	require.Len(t, base, 3, "only interfaces under interfaces/ are read")
+	head, err := readInterfaceABIs("head")
+	require.NoError(t, err)
+	require.Len(t, head, 3)
+
+	require.Equal(t, []interfaceSurfaceChange{
+		{Interface: "INew", Status: "added", Score: 1, Added: []string{"function g()"}},
+		{Interface: "IOld", Status: "removed"},
+		{
+			Interface: "IPortal",
+			Status:    "changed",
+			Score:     3,
+			Added:     []string{"function depositTo(address to, uint256 amount)"},
+			Removed:   []string{"function paused() view returns (bool)"},
+			Changed:   []memberChange{{Base: "function deposit(address to) payable", Head: "function deposit(address to)"}},
+		},
+	}, diffInterfaceSurfaces(base, head))
+
+	_, err = readInterfaceABIs("missing")
+	require.ErrorContains(t, err, "build the branch first")
+}
+
+func TestGateInterfaceSurfaceChanges(t *testing.T) {
+	changes := []interfaceSurfaceChange{
+		{Interface: "IOld", Status: "removed"},
+		{
+			Interface: "IPortal",
+			Status:    "changed",
+			Score:     2,
+			Added:     []string{"function depositTo(address to)"},
+			Changed:   []memberChange{{Base: "function deposit() payable", Head: "function deposit()"}},
+		},
+	}
+	report := `IOld (removed, score 0)
+IPortal (changed, score 2)
+  + function depositTo(address to)
+  ~ function deposit() payable -> function deposit()
+`
+
+	var b strings.Builder
+	require.False(t, gateInterfaceSurfaceChanges(&b, changes, false, false))
+	require.Equal(t, report+"error: 2 interfaces changed (score 2) without approval; rerun with --abi-change-approved once the change is signed off\n", b.String())
+
+	b.Reset()
+	require.True(t, gateInterfaceSurfaceChanges(&b, changes, true, false))
+	require.Equal(t, report+"interface changes approved: 2 interfaces, score 2\n", b.String())
+
+	b.Reset()
+	require.True(t, gateInterfaceSurfaceChanges(&b, nil, false, false))
+	require.Empty(t, b.String())
+
+	b.Reset()
+	require.True(t, gateInterfaceSurfaceChanges(&b, changes, true, true))
+	require.Contains(t, b.String(), "  \x1b[32m+ function depositTo(address to)\x1b[0m\n")
+}
+
+func TestContractFunctionRemovals(t *testing.T) {
+	artifact := func(abi, functions string) string {
+		return `{"abi":` + abi + `,"ast":{"absolutePath":"src/L1/Portal.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"Portal","contractKind":"contract","nodes":[` + functions + `]}]}}`
+	}
+	fn := func(name string) string { return `{"type":"function","name":"` + name + `","inputs":[],"outputs":[]}` }
+	setupSourceFixture(t, map[string]string{
+		"base/Portal.sol/Portal.json": artifact(`[`+fn("deposit")+`,`+fn("prove")+`,`+fn("finalize")+`,`+fn("paused")+`]`, ``),
+		"head/Portal.sol/Portal.json": artifact(`[
+			{"type":"function","name":"prove","inputs":[{"name":"id","type":"bytes32"}],"outputs":[]},`+fn("paused")+`
+		]`, `{"nodeType":"FunctionDefinition","name":"deposit","visibility":"internal"}`),
+	})
+
+	var b strings.Builder
+	require.NoError(t, writeContractFunctionRemovals(&b, "base", "head"))
+	require.Equal(t, `Portal.deposit() left the external ABI: demoted to internal
+Portal.finalize() left the external ABI: removed
+Portal.prove() left the external ABI: replaced by prove(bytes32)
+`, b.String())
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// artifactBundle, when set by --artifacts-bundle, stands in for artifactsDir: artifact paths
+// keep their usual form and are read from the bundle, so findings and baselines do not depend
+// on where the artifacts came from.
+var artifactBundle *common.Bundle
+
+// artifactsFS returns the artifacts as a file system rooted at artifactsDir.
+func artifactsFS() fs.FS {
+	if artifactBundle != nil {
+		return artifactBundle
+	}
+	return os.DirFS(artifactsDir)
+}
+
+// artifactEntry returns the slash-separated name of an artifact path within artifactsFS, or
+// false when the path is outside artifactsDir.
+func artifactEntry(path string) (string, bool) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cwd, path)
+	}
+	rel, err := filepath.Rel(artifactsDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// openArtifactFile opens an artifact path, from the bundle when one is in use.
+func openArtifactFile(path string) (fs.File, error) {
+	if entry, ok := artifactEntry(path); ok && artifactBundle != nil {
+		return artifactBundle.Open(entry)
+	}
+	return os.Open(path)
+}
+
+// findArtifacts returns the paths of every artifact.
+func findArtifacts() ([]string, error) {
+	if artifactBundle == nil {
+		return common.FindFiles([]string{"forge-artifacts/**/*.json"}, []string{})
+	}
+	entries, err := common.FindFilesFS(artifactBundle, []string{"**/*.json"}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = filepath.Join("forge-artifacts", filepath.FromSlash(entry))
+	}
+	return paths, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactsBundle(t *testing.T) {
+	setupSourceFixture(t, nil)
+	file, err := os.Create("artifacts.zip")
+	require.NoError(t, err)
+	zw := zip.NewWriter(file)
+	for _, entry := range []string{"forge-artifacts/Portal.sol/Portal.json", "forge-artifacts/IPortal.sol/IPortal.json"} {
+		w, err := zw.Create(entry)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(`{"abi":[],"ast":{"absolutePath":"src/Portal.sol","nodes":[]}}`))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, file.Close())
+
+	bundle, err := common.OpenBundle("artifacts.zip")
+	require.NoError(t, err)
+	t.Cleanup(func() { bundle.Close() })
+	prevBundle, prevDir := artifactBundle, artifactsDir
+	artifactBundle, artifactsDir = bundle, filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactBundle, artifactsDir = prevBundle, prevDir })
+	artifactIndexes.Clear()
+	t.Cleanup(artifactIndexes.Clear)
+
+	files, err := findArtifacts()
+	require.NoError(t, err)
+	slices.Sort(files)
+	require.Equal(t, []string{"forge-artifacts/IPortal.sol/IPortal.json", "forge-artifacts/Portal.sol/Portal.json"}, files)
+
+	artifact, err := readArtifact(files[1])
+	require.NoError(t, err)
+	require.Equal(t, "src/Portal.sol", artifact.AST.AbsolutePath)
+
+	path, err := contractArtifactPath("Portal")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(artifactsDir, "Portal.sol", "Portal.json"), path)
+	_, err = readArtifact(path)
+	require.NoError(t, err)
+
+	_, err = readArtifact(filepath.Join(artifactsDir, "Missing.sol", "Missing.json"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkInterfaceCalldata warns when an external function of an interface under interfaces/
+// takes a reference-type parameter in memory instead of calldata. The ABI does not record data
+// locations, so this reads the parameters' storageLocation from the AST. A function can opt out
+// with ignoreTag.
+func checkInterfaceCalldata(t *checkTarget) ([]common.Finding, error) {
+	if t.definition.ContractKind != "interface" || !strings.HasPrefix(t.sourcePath(), "interfaces/") ||
+		config.isExcluded("interface-calldata", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	for _, fn := range node.children("nodes") {
+		if fn.nodeType() != "FunctionDefinition" || getString(fn, "visibility") != "external" ||
+			hasIgnoreTag(fn, "interface-calldata") {
+			continue
+		}
+		for i, param := range fn.child("parameters").children("parameters") {
+			if getString(param, "storageLocation") != "memory" {
+				continue
+			}
+			name := param.name()
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			typeName := getString(param.child("typeName").child("typeDescriptions"), "typeString")
+			findings = append(findings, newFinding("interface-calldata", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s takes parameter %s as %s memory; external interface functions should take it as calldata",
+					t.name, fn.name(), name, typeName)))
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInterfaceCalldata(t *testing.T) {
+	param := func(name, location, typeString string) string {
+		return `{"nodeType":"VariableDeclaration","name":"` + name + `","storageLocation":"` + location +
+			`","typeName":{"typeDescriptions":{"typeString":"` + typeString + `"}}}`
+	}
+	target := interfaceTarget(t, `[]`)
+	require.NoError(t, target.artifact.setSections(json.RawMessage(`{"absolutePath":"interfaces/ITest.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"ITest","contractKind":"interface","nodes":[
+			{"nodeType":"FunctionDefinition","name":"relay","visibility":"external","parameters":{"parameters":[
+				`+param("_target", "default", "address")+`,`+param("_data", "memory", "bytes")+`,`+param("", "memory", "uint256[]")+`
+			]}},
+			{"nodeType":"FunctionDefinition","name":"prove","visibility":"external","parameters":{"parameters":[
+				`+param("_proof", "calldata", "bytes[]")+`
+			]}},
+			{"nodeType":"FunctionDefinition","name":"legacy","visibility":"external",
+				"documentation":{"text":"@custom:interfaces-ignore interface-calldata"},"parameters":{"parameters":[
+				`+param("_data", "memory", "bytes")+`
+			]}}
+		]}
+	]}`), target.artifact.ABI, nil, nil))
+
+	t.Run("flags memory parameters", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkInterfaceCalldata(target)
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "ITest.relay takes parameter _data as bytes memory; external interface functions should take it as calldata", findings[0].Message)
+		require.Equal(t, "ITest.relay takes parameter #3 as uint256[] memory; external interface functions should take it as calldata", findings[1].Message)
+	})
+
+	t.Run("contracts are skipped", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkInterfaceCalldata(delegatecallTarget(t, accessControlArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"interface-calldata": {"ITest"}}})
+		findings, err := checkInterfaceCalldata(target)
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
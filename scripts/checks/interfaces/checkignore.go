@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// checkIgnoreFile lists, one per line, contracts in its directory tree that need no interface.
+const checkIgnoreFile = ".checkignore"
+
+var checkIgnoreCache sync.Map // directory -> []string, or nil when the directory has no file
+
+// includeTests, set by --include-tests, requires interfaces for test contracts as well.
+var includeTests bool
+
+// isExcludedSourceContract reports whether a source contract is exempt from needing an
+// interface. Test contracts are exempt unless includeTests is set, and the Go list, the
+// "interfaces" config exclusions and the nearest .checkignore above the contract all apply; a
+// .checkignore can only add exclusions.
+func isExcludedSourceContract(name, sourcePath string) bool {
+	if (!includeTests && isTestSource(sourcePath)) || slices.Contains(excludeSourceContracts, name) ||
+		config.isExcluded("interfaces", name) {
+		return true
+	}
+	return slices.Contains(nearestCheckIgnore(filepath.Dir(sourcePath)), name)
+}
+
+// isTestSource reports whether a source file holds test contracts, going by its path alone so
+// that new tests and mocks are covered without listing them: a .t.sol file, or any file under a
+// test/ or tests/ directory.
+func isTestSource(sourcePath string) bool {
+	if rel, err := filepath.Rel(cwd, sourcePath); err == nil && filepath.IsAbs(sourcePath) {
+		sourcePath = rel
+	}
+	if strings.HasSuffix(sourcePath, ".t.sol") {
+		return true
+	}
+	for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(sourcePath)), "/") {
+		if dir == "test" || dir == "tests" {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestCheckIgnore returns the entries of the first .checkignore found walking up from dir to
+// the working directory.
+func nearestCheckIgnore(dir string) []string {
+	for {
+		if names, ok := readCheckIgnore(dir); ok {
+			return names
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || dir == "." {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+func readCheckIgnore(dir string) ([]string, bool) {
+	if cached, ok := checkIgnoreCache.Load(dir); ok {
+		names, _ := cached.([]string)
+		return names, names != nil
+	}
+
+	file, err := os.Open(filepath.Join(dir, checkIgnoreFile))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("failed to read %s: %v", filepath.Join(dir, checkIgnoreFile), err)
+		}
+		checkIgnoreCache.Store(dir, nil)
+		return nil, false
+	}
+	defer file.Close()
+
+	names := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	checkIgnoreCache.Store(dir, names)
+	return names, true
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIgnore(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"src/.checkignore":             "# legacy contracts\nLegacy\n",
+		"src/L1/Legacy.sol":            "contract Legacy {}\n",
+		"src/L1/Portal.sol":            "contract Portal {}\n",
+		"src/L2/.checkignore":          "Predeploy # deployed at genesis\n",
+		"src/L2/Predeploy.sol":         "contract Predeploy {}\n",
+		"src/L2/Legacy2.sol":           "contract Legacy {}\n",
+		"src/L2/nested/Configured.sol": "contract Configured {}\n",
+		"src/L2/nested/Unignored.sol":  "contract Unignored {}\n",
+		"interfaces/L1/IPortal.sol":    "interface IPortal {}\n",
+		"interfaces/L2/IPredeploy.sol": "interface IPredeploy {}\n",
+	})
+	setConfig(t, &Config{Exclude: map[string][]string{"interfaces": {"Configured"}}})
+
+	require.True(t, isExcludedSourceContract("Legacy", "src/L1/Legacy.sol"))
+	require.True(t, isExcludedSourceContract("WETH", "src/L2/WETH.sol"))
+	require.True(t, isExcludedSourceContract("Configured", "src/L2/nested/Configured.sol"))
+	require.True(t, isExcludedSourceContract("Predeploy", "src/L2/Predeploy.sol"))
+	// Only the nearest .checkignore applies, so src/.checkignore does not cover src/L2.
+	require.False(t, isExcludedSourceContract("Legacy", "src/L2/Legacy2.sol"))
+	require.False(t, isExcludedSourceContract("Unignored", "src/L2/nested/Unignored.sol"))
+
+	findings, err := verifyAllContractsHaveInterfaces([]SourceRoot{{Root: "src", ExpectedInterfaceRoot: "interfaces"}})
+	require.NoError(t, err)
+	var missing []string
+	for _, finding := range findings {
+		missing = append(missing, finding.Contract)
+	}
+	require.Equal(t, []string{"Legacy", "Unignored"}, missing)
+}
+
+func TestTestContractsExcluded(t *testing.T) {
+	setupSourceFixture(t, map[string]string{})
+	setConfig(t, &Config{})
+
+	require.True(t, isExcludedSourceContract("Portal_Test", "src/L1/Portal.t.sol"))
+	require.True(t, isExcludedSourceContract("MockERC20", "src/test/MockERC20.sol"))
+	require.True(t, isExcludedSourceContract("TestERC20", cwd+"/src/L2/tests/TestERC20.sol"))
+	require.False(t, isExcludedSourceContract("Tester", "src/L2/Tester.sol"))
+
+	includeTests = true
+	t.Cleanup(func() { includeTests = false })
+	require.False(t, isExcludedSourceContract("MockERC20", "src/test/MockERC20.sol"))
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// contractTypeRegex captures the contract name of an ABI internalType such as
+// "contract SystemConfig" or "contract IPortal[]".
+var contractTypeRegex = regexp.MustCompile(`^contract (\w+)(\[\d*\])*$`)
+
+// interfaceNameRegex matches the I<Name> naming convention for interfaces.
+var interfaceNameRegex = regexp.MustCompile(`^I[A-Z]`)
+
+// checkConcreteTypes warns when an interface's functions, events or errors use a concrete
+// contract type rather than that contract's interface. Interfaces from dependencies are skipped.
+func checkConcreteTypes(t *checkTarget) ([]common.Finding, error) {
+	if t.definition.ContractKind != "interface" || strings.HasPrefix(t.sourcePath(), "lib/") || config.isExcluded("interface-concrete-type", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []common.Finding
+	for _, item := range items {
+		var concrete []string
+		collectConcreteTypes(item["inputs"], &concrete)
+		collectConcreteTypes(item["outputs"], &concrete)
+		for _, name := range concrete {
+			findings = append(findings, newFinding("interface-concrete-type", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s: %s uses concrete type %s; use I%s instead", t.name, formatABIItem(item), name, name)))
+		}
+	}
+	return findings, nil
+}
+
+// collectConcreteTypes appends the non-interface contract types used by an ABI parameter list,
+// including inside tuple components.
+func collectConcreteTypes(raw interface{}, out *[]string) {
+	params, _ := raw.([]interface{})
+	for _, p := range params {
+		param, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if match := contractTypeRegex.FindStringSubmatch(getString(param, "internalType")); match != nil && !interfaceNameRegex.MatchString(match[1]) {
+			*out = append(*out, match[1])
+		}
+		collectConcreteTypes(param["components"], out)
+	}
+}
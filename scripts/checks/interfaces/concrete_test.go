@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func interfaceTarget(t *testing.T, abi string) *checkTarget {
+	t.Helper()
+	return &checkTarget{
+		path:       "forge-artifacts/ITest.sol/ITest.json",
+		name:       "ITest",
+		artifact:   &Artifact{ABI: json.RawMessage(abi), AST: ArtifactAST{AbsolutePath: "interfaces/ITest.sol"}},
+		definition: &ContractDefinition{ContractKind: "interface", Name: "ITest"},
+	}
+}
+
+func TestCheckConcreteTypes(t *testing.T) {
+	abi := `[
+		{"type":"function","name":"systemConfig","inputs":[],"outputs":[{"name":"","type":"address","internalType":"contract SystemConfig"}]},
+		{"type":"function","name":"portal","inputs":[],"outputs":[{"name":"","type":"address","internalType":"contract IOptimismPortal"}]},
+		{"type":"function","name":"setGames","inputs":[{"name":"_params","type":"tuple","internalType":"struct ITest.Params","components":[
+			{"name":"games","type":"address[]","internalType":"contract FaultDisputeGame[]"}
+		]}],"outputs":[]},
+		{"type":"function","name":"token","inputs":[],"outputs":[{"name":"","type":"address","internalType":"contract Initializable"}]}
+	]`
+
+	t.Run("flags concrete types", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkConcreteTypes(interfaceTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 3)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "ITest: function systemConfig() returns (SystemConfig) uses concrete type SystemConfig; use ISystemConfig instead", findings[0].Message)
+		require.Contains(t, findings[1].Message, "uses concrete type FaultDisputeGame")
+		require.Contains(t, findings[2].Message, "uses concrete type Initializable")
+	})
+
+	t.Run("contracts are skipped", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkConcreteTypes(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"interface-concrete-type": {"ITest"}}})
+		findings, err := checkConcreteTypes(interfaceTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
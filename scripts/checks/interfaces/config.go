@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// defaultConfigPath is the repo-relative location of the shared check configuration.
+const defaultConfigPath = "scripts/checks/interfaces/interface-check.json"
+
+// Config holds the check policy that lives outside the Go source.
+type Config struct {
+	// SourceRoots lists the source trees whose contracts must have interfaces. Defaults to
+	// defaultSourceRoots when empty.
+	SourceRoots []SourceRoot `json:"sourceRoots,omitempty"`
+	// Exclude maps a check name to the contracts that check skips.
+	Exclude           map[string][]string     `json:"exclude,omitempty"`
+	Delegatecall      DelegatecallConfig      `json:"delegatecall"`
+	StructReturn      StructReturnConfig      `json:"structReturn"`
+	ExternalFunctions ExternalFunctionsConfig `json:"externalFunctions"`
+	SelectorClash     SelectorClashConfig     `json:"selectorClash"`
+	Parameters        ParametersConfig        `json:"parameters"`
+	ReturnValues      ReturnValuesConfig      `json:"returnValues"`
+	AccessControl     AccessControlConfig     `json:"accessControl"`
+	AddressRegistry   AddressRegistryConfig   `json:"addressRegistry"`
+	ProxyAdmin        ProxyAdminConfig        `json:"proxyAdmin"`
+	GasBudget         GasBudgetConfig         `json:"gasBudget"`
+	Upgradeable       UpgradeableConfig       `json:"upgradeable"`
+	MagicNumbers      MagicNumbersConfig      `json:"magicNumbers"`
+	ForbiddenOpcodes  ForbiddenOpcodesConfig  `json:"forbiddenOpcodes"`
+	FunctionOrder     FunctionOrderConfig     `json:"functionOrder"`
+	Predeploys        PredeploysConfig        `json:"predeploys"`
+	PayableCoverage   PayableCoverageConfig   `json:"payableCoverage"`
+	// StandaloneInterfaces configures the standalone-interface check.
+	StandaloneInterfaces StandaloneInterfacesConfig `json:"standaloneInterfaces"`
+	// EventRules lists the indexed parameters that matching events must declare.
+	EventRules []EventRule `json:"eventRules,omitempty"`
+	// ModifierRules lists the modifiers that matching functions must apply.
+	ModifierRules []ModifierRule `json:"modifierRules,omitempty"`
+	// RequiredEvents lists the events that matching contracts must declare.
+	RequiredEvents []RequiredEventsRule `json:"requiredEvents,omitempty"`
+	// EventNaming lists the events that matching functions must have a counterpart for.
+	EventNaming []EventNamingRule `json:"eventNaming,omitempty"`
+	// PragmaExempt lists the interfaces allowed to declare a pragma other than solidity ^0.8.0.
+	PragmaExempt []PragmaExemption `json:"pragmaExempt,omitempty"`
+}
+
+type StructReturnConfig struct {
+	// MaxFields is the largest struct a public function may return without a finding.
+	MaxFields int `json:"maxFields,omitempty"`
+}
+
+type ExternalFunctionsConfig struct {
+	// Max is the largest number of external functions a contract may expose without a finding.
+	Max int `json:"max,omitempty"`
+}
+
+type ParametersConfig struct {
+	// Max is the largest number of parameters a function may take without a finding.
+	Max int `json:"max,omitempty"`
+}
+
+type ReturnValuesConfig struct {
+	// Max is the largest number of flattened fields a function may return without a finding.
+	Max int `json:"max,omitempty"`
+}
+
+type AccessControlConfig struct {
+	// Patterns are globs over function names that indicate a privileged action. Defaults to
+	// defaultPrivilegedPatterns when empty.
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+type AddressRegistryConfig struct {
+	// Registry is the path to a JSON object mapping constant names, optionally qualified as
+	// <Contract>.<constant>, to their canonical addresses. The check is disabled when empty.
+	Registry string `json:"registry,omitempty"`
+	// Files lists the source files whose address constants are checked.
+	Files []string `json:"files,omitempty"`
+}
+
+type ProxyAdminConfig struct {
+	// Proxies lists the contracts allowed to declare proxy admin functions. Defaults to
+	// defaultProxyContracts when empty.
+	Proxies []string `json:"proxies,omitempty"`
+	// Patterns are globs over function names that indicate a proxy admin function. Defaults to
+	// defaultProxyAdminPatterns when empty.
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+type GasBudgetConfig struct {
+	// Budget is the path to a JSON object mapping contract names to the gas budget of each of
+	// their external functions, keyed by signature. The check is disabled when empty.
+	Budget string `json:"budget,omitempty"`
+	// Margin is the percentage by which an estimate may exceed its budget without a finding.
+	Margin float64 `json:"margin,omitempty"`
+}
+
+type PayableCoverageConfig struct {
+	// Report is the path to an lcov coverage report, e.g. from forge coverage --report lcov. The
+	// check is disabled when empty, and skipped when the report does not exist.
+	Report string `json:"report,omitempty"`
+}
+
+type UpgradeableConfig struct {
+	// Contracts lists the upgradeable contracts that must reserve a storage gap or use ERC-7201
+	// namespaced storage.
+	Contracts []string `json:"contracts,omitempty"`
+}
+
+type StandaloneInterfacesConfig struct {
+	// Allow are globs over source paths, e.g. "lib/openzeppelin-contracts/**", that interfaces
+	// may import even though they declare implementations.
+	Allow []string `json:"allow,omitempty"`
+}
+
+type MagicNumbersConfig struct {
+	// Max is the largest literal a function may use inline without a finding. Defaults to
+	// defaultMaxMagicNumber when zero.
+	Max uint64 `json:"max,omitempty"`
+	// Allow lists literal values, e.g. "1e18", that may be used inline regardless of size.
+	Allow []string `json:"allow,omitempty"`
+}
+
+type ForbiddenOpcodesConfig struct {
+	// Opcodes lists the opcodes, e.g. "selfdestruct", that contracts must not use. The check is
+	// disabled when empty.
+	Opcodes []string `json:"opcodes,omitempty"`
+	// Contracts are globs over contract names that the opcodes are forbidden in. Defaults to
+	// every contract when empty.
+	Contracts []string `json:"contracts,omitempty"`
+}
+
+type FunctionOrderConfig struct {
+	// Policy is the order interface functions must be declared in, "alphabetical" or
+	// "mutability". The check is disabled when empty.
+	Policy string `json:"policy,omitempty"`
+}
+
+type PredeploysConfig struct {
+	// Library is the library whose address constants are the canonical predeploy addresses.
+	// Defaults to defaultPredeploysLibrary when empty.
+	Library string `json:"library,omitempty"`
+	// Addresses maps constant names to canonical predeploy addresses, overriding the library.
+	Addresses map[string]string `json:"addresses,omitempty"`
+}
+
+type SelectorClashConfig struct {
+	// Reserved lists the canonical signatures, e.g. "admin()", whose selectors implementation
+	// contracts must not use. Defaults to defaultReservedSelectors when empty.
+	Reserved []string `json:"reserved,omitempty"`
+}
+
+// isExcluded reports whether the config excludes contract from check.
+func (c *Config) isExcluded(check, contract string) bool {
+	return slices.Contains(c.Exclude[check], contract)
+}
+
+type DelegatecallConfig struct {
+	// Allow lists contracts that are permitted to use delegatecall.
+	Allow []string `json:"allow"`
+}
+
+// loadConfig reads the configuration at path, resolved by resolveConfigPath.
+func loadConfig(path string) (*Config, error) {
+	return readConfig(resolveConfigPath(path))
+}
+
+// resolveConfigPath returns path, or when it is empty the config file next to the binary,
+// falling back to the repo-relative path when running with `go run`.
+func resolveConfigPath(path string) string {
+	if path != "" {
+		return path
+	}
+	nextToBinary := filepath.Join(filepath.Dir(os.Args[0]), filepath.Base(defaultConfigPath))
+	if _, err := os.Stat(nextToBinary); errors.Is(err, fs.ErrNotExist) {
+		return defaultConfigPath
+	}
+	return nextToBinary
+}
+
+// readConfig parses a JSON config, or a YAML one when path ends in .yaml or .yml.
+func readConfig(path string) (*Config, error) {
+	data, err := common.ReadConfigJSON(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// configureChecks reads a --checks-config file and hands its sections to the registered checks.
+// The interfaces section replaces the --config file, so setting both is an error; without the
+// section the defaults apply.
+func configureChecks(path, configPath string) error {
+	if configPath != "" {
+		return fmt.Errorf("--checks-config and --config cannot be combined")
+	}
+	sections, err := common.ReadConfigSections(path)
+	if err != nil {
+		return err
+	}
+	config = &Config{}
+	return common.ConfigureChecks(common.RegisteredChecks(), sections, os.Stderr)
+}
+
+// Configure applies the interfaces section of a --checks-config file, which holds the same
+// settings as the interfaces config file.
+func (c *interfacesCheck) Configure(section json.RawMessage) error {
+	var cfg Config
+	if err := json.Unmarshal(section, &cfg); err != nil {
+		return err
+	}
+	config = &cfg
+	return nil
+}
+
+func (c *interfacesCheck) ConfigSchema() map[string]any {
+	return typeSchema(reflect.TypeFor[Config]())
+}
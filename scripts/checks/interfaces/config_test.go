@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func setConfig(t *testing.T, cfg *Config) {
+	t.Helper()
+	prev := config
+	config = cfg
+	t.Cleanup(func() { config = prev })
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"delegatecall":{"allow":["Proxy"]}}`), 0644))
+
+	cfg, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Proxy"}, cfg.Delegatecall.Allow)
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestDefaultConfigParses(t *testing.T) {
+	_, err := readConfig(filepath.Base(defaultConfigPath))
+	require.NoError(t, err)
+}
+
+func TestReadConfigYAML(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := os.ReadFile("interface-check.json")
+		require.NoError(t, err)
+		var generic interface{}
+		require.NoError(t, yaml.Unmarshal(data, &generic))
+		yamlData, err := yaml.Marshal(generic)
+		require.NoError(t, err)
+
+		for _, ext := range []string{".yaml", ".yml"} {
+			path := filepath.Join(dir, "interface-check"+ext)
+			require.NoError(t, os.WriteFile(path, yamlData, 0644))
+
+			fromJSON, err := readConfig("interface-check.json")
+			require.NoError(t, err)
+			fromYAML, err := readConfig(path)
+			require.NoError(t, err)
+			require.Equal(t, fromJSON, fromYAML)
+		}
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		path := filepath.Join(dir, "commented.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`# Contracts that may delegatecall.
+delegatecall:
+  allow:
+    - Proxy # the transparent proxy
+structReturn:
+  maxFields: 3
+exclude:
+  struct-return: [Legacy]
+`), 0644))
+		cfg, err := readConfig(path)
+		require.NoError(t, err)
+		require.Equal(t, &Config{
+			Delegatecall: DelegatecallConfig{Allow: []string{"Proxy"}},
+			StructReturn: StructReturnConfig{MaxFields: 3},
+			Exclude:      map[string][]string{"struct-return": {"Legacy"}},
+		}, cfg)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.yml")
+		require.NoError(t, os.WriteFile(path, nil, 0644))
+		cfg, err := readConfig(path)
+		require.NoError(t, err)
+		require.Equal(t, &Config{}, cfg)
+	})
+}
+
+func TestConfigureChecks(t *testing.T) {
+	setConfig(t, nil)
+	path := filepath.Join(t.TempDir(), "checks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"interfaces":{"exclude":{"access-control":["Vault"]}}}`), 0o644))
+
+	require.NoError(t, configureChecks(path, ""))
+	require.True(t, config.isExcluded("access-control", "Vault"))
+
+	require.EqualError(t, configureChecks(path, "interface-check.json"), "--checks-config and --config cannot be combined")
+}
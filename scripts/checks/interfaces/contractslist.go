@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// listedContract is an entry of a --contracts-list file: a contract that must have an interface,
+// with both paths given explicitly instead of derived from the source roots.
+type listedContract struct {
+	Contract  string `json:"contract"`
+	Source    string `json:"source"`
+	Interface string `json:"interface"`
+}
+
+// contractsList replaces the source root scan for missing interfaces when set.
+var contractsList []listedContract
+
+// readContractsList parses a JSON array of listed contracts. Paths are relative to the working
+// directory.
+func readContractsList(path string) ([]listedContract, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contracts list: %w", err)
+	}
+	var list []listedContract
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse contracts list %s: %w", path, err)
+	}
+	for i, entry := range list {
+		if entry.Contract == "" || entry.Source == "" || entry.Interface == "" {
+			return nil, fmt.Errorf("contracts list %s: entry %d needs contract, source and interface", path, i)
+		}
+	}
+	return list, nil
+}
+
+// verifyListedContractsHaveInterfaces reports each listed contract whose source does not exist or
+// does not declare it, and each whose interface does not exist. Unlike the source root scan,
+// exclusions do not apply: listing a contract is the decision that it needs an interface.
+func verifyListedContractsHaveInterfaces(list []listedContract) ([]common.Finding, error) {
+	var findings []common.Finding
+	for _, entry := range list {
+		if !shard.Contains(entry.Contract) || (!isChanged(entry.Source) && !isChanged(entry.Interface)) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(cwd, entry.Source))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			findings = append(findings, newFinding("interfaces", common.SeverityError, entry.Source, entry.Contract,
+				fmt.Sprintf("%s: listed source %s does not exist", entry.Contract, entry.Source)))
+		case err != nil:
+			return nil, err
+		case !declaresContract(content, entry.Contract):
+			findings = append(findings, newFinding("interfaces", common.SeverityError, entry.Source, entry.Contract,
+				fmt.Sprintf("%s: listed source %s does not declare the contract", entry.Contract, entry.Source)))
+		}
+
+		if _, err := os.Stat(filepath.Join(cwd, entry.Interface)); errors.Is(err, os.ErrNotExist) {
+			finding := newFinding("interfaces", common.SeverityError, entry.Source, entry.Contract,
+				fmt.Sprintf("%s: contract in %s has no corresponding interface at %s", entry.Contract, entry.Source, entry.Interface))
+			finding.Rule = ruleMissingInterface
+			findings = append(findings, finding)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return findings, nil
+}
+
+func declaresContract(content []byte, name string) bool {
+	return slices.Contains(declaredContractNames(content), name)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyListedContractsHaveInterfaces(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"src/L1/Portal.sol":         "contract Portal {}\n",
+		"src/L1/Bridge.sol":         "contract Bridge {}\n",
+		"src/L1/Renamed.sol":        "contract Other {}\n",
+		"interfaces/L1/IPortal.sol": "interface IPortal {}\n",
+		"elsewhere/IRenamed.sol":    "interface IRenamed {}\n",
+		"contracts.json": `[
+			{"contract": "Portal", "source": "src/L1/Portal.sol", "interface": "interfaces/L1/IPortal.sol"},
+			{"contract": "Bridge", "source": "src/L1/Bridge.sol", "interface": "interfaces/L1/IBridge.sol"},
+			{"contract": "Renamed", "source": "src/L1/Renamed.sol", "interface": "elsewhere/IRenamed.sol"},
+			{"contract": "Gone", "source": "src/L1/Gone.sol", "interface": "interfaces/L1/IGone.sol"}
+		]`,
+	})
+	setConfig(t, &Config{})
+
+	list, err := readContractsList("contracts.json")
+	require.NoError(t, err)
+	findings, err := verifyListedContractsHaveInterfaces(list)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, finding := range findings {
+		messages = append(messages, finding.Message)
+	}
+	require.Equal(t, []string{
+		"Bridge: contract in src/L1/Bridge.sol has no corresponding interface at interfaces/L1/IBridge.sol",
+		"Renamed: listed source src/L1/Renamed.sol does not declare the contract",
+		"Gone: listed source src/L1/Gone.sol does not exist",
+		"Gone: contract in src/L1/Gone.sol has no corresponding interface at interfaces/L1/IGone.sol",
+	}, messages)
+}
+
+func TestReadContractsList(t *testing.T) {
+	setupSourceFixture(t, map[string]string{"contracts.json": `[{"contract": "Portal", "source": "src/Portal.sol"}]`})
+	_, err := readContractsList("contracts.json")
+	require.ErrorContains(t, err, "entry 0 needs contract, source and interface")
+
+	_, err = readContractsList("missing.json")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
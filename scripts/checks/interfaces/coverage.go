@@ -0,0 +1,39 @@
+package main
+
+import (
+	"math"
+)
+
+// interfaceCoverage summarizes how many eligible source contracts have interfaces.
+type interfaceCoverage struct {
+	Total            int      `json:"total"`
+	Excluded         int      `json:"excluded"`
+	WithInterface    int      `json:"withInterface"`
+	Missing          int      `json:"missing"`
+	CoveragePct      float64  `json:"coveragePct"`
+	MissingContracts []string `json:"missingContracts"`
+}
+
+// computeCoverage derives interface coverage metrics from a source scan. Excluded contracts
+// count towards the total but not towards the eligible set the percentage is taken over.
+func computeCoverage(contracts []sourceContract) interfaceCoverage {
+	coverage := interfaceCoverage{Total: len(contracts), MissingContracts: []string{}}
+	for _, contract := range contracts {
+		switch {
+		case contract.Excluded:
+			coverage.Excluded++
+		case contract.HasInterface:
+			coverage.WithInterface++
+		default:
+			coverage.Missing++
+			coverage.MissingContracts = append(coverage.MissingContracts, contract.Name)
+		}
+	}
+
+	coverage.CoveragePct = 100
+	if eligible := coverage.Total - coverage.Excluded; eligible > 0 {
+		pct := float64(coverage.WithInterface) / float64(eligible) * 100
+		coverage.CoveragePct = math.Round(pct*100) / 100
+	}
+	return coverage
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeCoverage(t *testing.T) {
+	contracts := []sourceContract{
+		{Name: "A", HasInterface: true},
+		{Name: "B", HasInterface: true},
+		{Name: "C"},
+		{Name: "D", Excluded: true},
+	}
+
+	require.Equal(t, interfaceCoverage{
+		Total:            4,
+		Excluded:         1,
+		WithInterface:    2,
+		Missing:          1,
+		CoveragePct:      66.67,
+		MissingContracts: []string{"C"},
+	}, computeCoverage(contracts))
+
+	empty := computeCoverage(nil)
+	require.Equal(t, float64(100), empty.CoveragePct)
+	require.Empty(t, empty.MissingContracts)
+}
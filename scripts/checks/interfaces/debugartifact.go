@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// artifactDebug is what --artifact prints: the findings for one artifact and the normalized ABIs
+// of it and its counterpart, side by side, with the differences between them.
+type artifactDebug struct {
+	Interface   *debugABI        `json:"interface"`
+	Contract    *debugABI        `json:"contract"`
+	Differences []string         `json:"differences"`
+	Findings    []common.Finding `json:"findings"`
+}
+
+// debugABI is one side of an interface comparison, as compareInterfaceABI sees it.
+type debugABI struct {
+	Name string                   `json:"name"`
+	Path string                   `json:"path"`
+	ABI  []map[string]interface{} `json:"abi"`
+}
+
+// debugArtifact runs the artifact checks on the artifact at path alone and works out its
+// interface or contract counterpart from forge's <File>.sol/<Contract>.json layout. Either side
+// is nil when it has no artifact.
+func debugArtifact(path string) (*artifactDebug, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(absPath); err != nil {
+		return nil, err
+	}
+	// The counterpart lives next to the artifact, wherever that artifact directory is.
+	artifactsDir = filepath.Dir(filepath.Dir(absPath))
+
+	findings, errs := processFile(absPath)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	sortFindings(findings)
+	debug := &artifactDebug{Differences: []string{}, Findings: findings}
+
+	name := contractNameFromArtifactPath(absPath)
+	interfacePath, contractPath := absPath, ""
+	if strings.HasPrefix(name, "I") {
+		if contractPath, err = contractArtifactPath(name[1:]); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := os.Stat(contractPath); contractPath == "" || err != nil {
+		if interfacePath, err = contractArtifactPath("I" + name); err != nil {
+			return nil, err
+		}
+		contractPath = absPath
+	}
+
+	if debug.Interface, err = readDebugABI(interfacePath); err != nil {
+		return nil, err
+	}
+	if debug.Contract, err = readDebugABI(contractPath); err != nil {
+		return nil, err
+	}
+	if debug.Interface != nil && debug.Contract != nil {
+		debug.Differences = append(debug.Differences,
+			formatABIDiffs(diffABIs(debug.Interface.ABI, debug.Contract.ABI), debug.Interface.ABI, debug.Contract.ABI)...)
+	}
+	return debug, nil
+}
+
+func readDebugABI(path string) (*debugABI, error) {
+	artifact, err := readArtifact(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	abi, err := normalizeABI(artifact.ABI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize ABI of %s: %w", path, err)
+	}
+	return &debugABI{Name: contractNameFromArtifactPath(path), Path: path, ABI: abi}, nil
+}
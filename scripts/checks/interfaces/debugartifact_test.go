@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugArtifact(t *testing.T) {
+	fixture := func(name string) string {
+		data, err := os.ReadFile(filepath.Join("testdata", "array-bounds", name+".sol", name+".json"))
+		require.NoError(t, err)
+		return string(data)
+	}
+	interfaceJSON, contractJSON := fixture("IBounded"), fixture("Bounded")
+	setupSourceFixture(t, map[string]string{
+		"artifacts/IBounded.sol/IBounded.json": interfaceJSON,
+		"artifacts/Bounded.sol/Bounded.json":   contractJSON,
+		"artifacts/Lone.sol/Lone.json":         contractJSON,
+	})
+	prev := artifactsDir
+	t.Cleanup(func() { artifactsDir = prev })
+	setConfig(t, &Config{})
+
+	for _, path := range []string{"artifacts/IBounded.sol/IBounded.json", "artifacts/Bounded.sol/Bounded.json"} {
+		debug, err := debugArtifact(path)
+		require.NoError(t, err)
+		require.Equal(t, "IBounded", debug.Interface.Name)
+		require.Equal(t, "Bounded", debug.Contract.Name)
+		require.Len(t, debug.Contract.ABI, 4, "normalization adds the default constructor")
+		require.Equal(t, []string{
+			"ARRAY mismatch on owner(): interface address vs contract address[]",
+			"ARRAY mismatch on setWeights(uint256[]): interface uint256[] vs contract uint256[3]",
+		}, debug.Differences)
+	}
+
+	debug, err := debugArtifact("artifacts/Lone.sol/Lone.json")
+	require.NoError(t, err)
+	require.Nil(t, debug.Interface)
+	require.Equal(t, "Lone", debug.Contract.Name)
+	require.Empty(t, debug.Differences)
+
+	_, err = debugArtifact("artifacts/Missing.sol/Missing.json")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
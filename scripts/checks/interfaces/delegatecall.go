@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkDelegatecall flags every `.delegatecall` in a source contract that is not on the
+// configured allowlist, so that each new usage gets a deliberate review.
+func checkDelegatecall(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || slices.Contains(config.Delegatecall.Allow, t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	walkAST(node, func(n astNode, parents []astNode) bool {
+		if n.nodeType() != "FunctionCall" || !isDelegatecall(n.child("expression")) {
+			return true
+		}
+		findings = append(findings, newFinding("delegatecall", common.SeverityError, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s uses delegatecall; add the contract to the delegatecall allowlist if this is intended",
+				t.name, functionLabel(enclosingFunction(parents)))))
+		return true
+	})
+	return findings, nil
+}
+
+// isDelegatecall reports whether expr is the callee of an `<address>.delegatecall(...)` call,
+// which may be wrapped in call options such as `{gas: ...}`.
+func isDelegatecall(expr astNode) bool {
+	if expr.nodeType() == "FunctionCallOptions" {
+		expr = expr.child("expression")
+	}
+	return expr.nodeType() == "MemberAccess" && getString(expr, "memberName") == "delegatecall"
+}
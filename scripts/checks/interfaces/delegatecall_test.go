@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// delegatecallArtifact has a contract that delegatecalls from a function and a modifier.
+const delegatecallArtifact = `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"forward","body":{"nodeType":"Block","statements":[
+			{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall","expression":
+				{"nodeType":"MemberAccess","memberName":"delegatecall"}}}
+		]}},
+		{"nodeType":"FunctionDefinition","name":"call","body":{"nodeType":"Block","statements":[
+			{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall","expression":
+				{"nodeType":"MemberAccess","memberName":"call"}}}
+		]}},
+		{"nodeType":"ModifierDefinition","name":"viaProxy","body":{"nodeType":"Block","statements":[
+			{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall","expression":
+				{"nodeType":"FunctionCallOptions","expression":{"nodeType":"MemberAccess","memberName":"delegatecall"}}}}
+		]}}
+	]}
+]}}`
+
+func delegatecallTarget(t *testing.T, src string) *checkTarget {
+	t.Helper()
+	var artifact Artifact
+	require.NoError(t, json.Unmarshal([]byte(src), &artifact))
+	return &checkTarget{
+		path:       "forge-artifacts/Test.sol/Test.json",
+		name:       "Test",
+		artifact:   &artifact,
+		definition: getContractDefinition(&artifact, "Test"),
+	}
+}
+
+func TestCheckDelegatecall(t *testing.T) {
+	t.Run("flags usages", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkDelegatecall(delegatecallTarget(t, delegatecallArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, "src/Test.sol", findings[0].File)
+		require.Contains(t, findings[0].Message, "Test.forward")
+		require.Contains(t, findings[1].Message, "Test.viaProxy")
+	})
+
+	t.Run("allowlisted contract", func(t *testing.T) {
+		setConfig(t, &Config{Delegatecall: DelegatecallConfig{Allow: []string{"Test"}}})
+		findings, err := checkDelegatecall(delegatecallTarget(t, delegatecallArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("non-source contract", func(t *testing.T) {
+		setConfig(t, &Config{})
+		target := delegatecallTarget(t, delegatecallArtifact)
+		target.artifact.AST.AbsolutePath = "test/Test.sol"
+		findings, err := checkDelegatecall(target)
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// deprecatedTagRegex matches the NatSpec tag that marks a function as deprecated.
+var deprecatedTagRegex = regexp.MustCompile(`(?m)^\s*@custom:deprecated\b`)
+
+// typeStringContractRegex extracts the contract or library a typeString refers to, e.g.
+// "type(library SafeCall)" or "contract GasPriceOracle".
+var typeStringContractRegex = regexp.MustCompile(`^(?:type\()?(?:contract|library) (\w+)\)?$`)
+
+// deprecatedIndex caches the deprecated functions found by deprecatedFunctions, keyed by
+// artifacts directory and contract name.
+var deprecatedIndex sync.Map
+
+// checkDeprecatedFunctions warns when a source contract references a function tagged
+// `@custom:deprecated`, whether declared in its own file, a base contract or a library it calls,
+// and when a deprecated public function of the contract is not tagged in its interface as well.
+// Deprecated functions may still reference each other, and a function can opt out with
+// ignoreTag.
+func checkDeprecatedFunctions(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("deprecated-function", t.name) {
+		return nil, nil
+	}
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	// AST ids are only unique within a compiler run, so references resolve within the build of
+	// the artifact; deprecated functions of other builds never match them.
+	build := compilerBuild(t.artifact)
+	deprecated := make(map[astKey]string)
+	var related []string
+	for _, contract := range t.artifact.tree().children("nodes") {
+		if contract.nodeType() == "ContractDefinition" {
+			collectDeprecated(contract, build, deprecated)
+			related = append(related, baseContractNames(contract)...)
+		}
+	}
+	walkAST(t.artifact.tree(), func(n astNode, _ []astNode) bool {
+		switch n.nodeType() {
+		case "UsingForDirective":
+			related = append(related, usingForName(n.child("libraryName")))
+		case "Identifier", "MemberAccess":
+			if match := typeStringContractRegex.FindStringSubmatch(getString(n.child("typeDescriptions"), "typeString")); match != nil {
+				related = append(related, match[1])
+			}
+		}
+		return true
+	})
+	slices.Sort(related)
+	for _, name := range slices.Compact(related) {
+		functions, err := deprecatedFunctions(name, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		for key, label := range functions {
+			deprecated[key] = label
+		}
+	}
+
+	var findings []common.Finding
+	reported := make(map[string]bool)
+	walkAST(node, func(n astNode, parents []astNode) bool {
+		if n.nodeType() != "Identifier" && n.nodeType() != "MemberAccess" {
+			return true
+		}
+		id, _ := n["referencedDeclaration"].(float64)
+		target, ok := deprecated[astKey{build, int(id)}]
+		caller := enclosingFunction(parents)
+		if !ok || (caller != nil && (isDeprecated(caller) || hasIgnoreTag(caller, "deprecated-function"))) {
+			return true
+		}
+		msg := fmt.Sprintf("%s.%s references deprecated %s", t.name, functionLabel(caller), target)
+		if !reported[msg] {
+			reported[msg] = true
+			findings = append(findings, newFinding("deprecated-function", common.SeverityWarning, t.sourcePath(), t.name, msg))
+		}
+		return true
+	})
+
+	mirrored, err := interfaceDeprecations(t.name, declaredFunctions(node))
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range mirrored {
+		findings = append(findings, newFinding("deprecated-function", common.SeverityWarning, t.sourcePath(), t.name, msg))
+	}
+	return findings, nil
+}
+
+// interfaceDeprecations reports the deprecated public functions of the named contract whose
+// counterpart in I<name> is not tagged `@custom:deprecated`. A missing interface is reported by
+// verifyAllContractsHaveInterfaces.
+func interfaceDeprecations(name string, functions map[string]astNode) ([]string, error) {
+	path, ok, err := artifactPathForContract("I" + name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	artifact, err := readArtifact(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact of I%s: %w", name, err)
+	}
+	declared := declaredFunctions(artifact.contractNode("I" + name))
+
+	var msgs []string
+	for key, fn := range functions {
+		visibility := getString(fn, "visibility")
+		if (visibility != "public" && visibility != "external") || !isDeprecated(fn) {
+			continue
+		}
+		if counterpart, ok := declared[key]; ok && !isDeprecated(counterpart) {
+			msgs = append(msgs, fmt.Sprintf("%s.%s is deprecated but I%s.%s is not tagged @custom:deprecated",
+				name, fn.name(), name, fn.name()))
+		}
+	}
+	slices.Sort(msgs)
+	return msgs, nil
+}
+
+// deprecatedFunctions returns the deprecated functions of the named contract and its bases by
+// build and AST id, labeled <Contract>.<function>. Contracts without an artifact have none.
+func deprecatedFunctions(name string, seen map[string]bool) (map[astKey]string, error) {
+	key := artifactsDir + "\x00" + name
+	if cached, ok := deprecatedIndex.Load(key); ok {
+		return cached.(map[astKey]string), nil
+	}
+	functions := make(map[astKey]string)
+	if seen[name] {
+		return functions, nil
+	}
+	seen[name] = true
+
+	path, ok, err := artifactPathForContract(name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if ok {
+		artifact, err := readArtifact(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read artifact of %s: %w", name, err)
+		}
+		contract := artifact.contractNode(name)
+		collectDeprecated(contract, compilerBuild(artifact), functions)
+		for _, base := range baseContractNames(contract) {
+			inherited, err := deprecatedFunctions(base, seen)
+			if err != nil {
+				return nil, err
+			}
+			for key, label := range inherited {
+				functions[key] = label
+			}
+		}
+	}
+	deprecatedIndex.Store(key, functions)
+	return functions, nil
+}
+
+// collectDeprecated adds the deprecated functions declared directly in contract, which was
+// compiled in build, to functions.
+func collectDeprecated(contract astNode, build string, functions map[astKey]string) {
+	for _, fn := range contract.children("nodes") {
+		if id, ok := astID(fn); ok && fn.nodeType() == "FunctionDefinition" && isDeprecated(fn) {
+			functions[astKey{build, id}] = contract.name() + "." + functionLabel(fn)
+		}
+	}
+}
+
+func isDeprecated(fn astNode) bool {
+	return deprecatedTagRegex.MatchString(getString(fn.child("documentation"), "text"))
+}
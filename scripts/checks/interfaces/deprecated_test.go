@@ -0,0 +1,96 @@
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDeprecatedFunctions(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Base.sol/Base.json": `{"abi":[],"ast":{"absolutePath":"src/Base.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"Base","contractKind":"contract","nodes":[
+				{"nodeType":"FunctionDefinition","id":10,"name":"legacy","visibility":"internal",
+					"documentation":{"text":" @custom:deprecated Use modern instead."}}
+			]}
+		]}}`,
+		"forge-artifacts/ITest.sol/ITest.json": `{"abi":[],"ast":{"absolutePath":"interfaces/ITest.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"ITest","contractKind":"interface","nodes":[
+				{"nodeType":"FunctionDefinition","name":"old","parameters":{"parameters":[]}}
+			]}
+		]}}`,
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	call := func(id int) string {
+		return `{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall","expression":
+			{"nodeType":"Identifier","referencedDeclaration":` + strconv.Itoa(id) + `}}}`
+	}
+	artifact := `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract",
+			"baseContracts":[{"baseName":{"nodeType":"IdentifierPath","name":"Base"}}],"nodes":[
+			{"nodeType":"FunctionDefinition","id":20,"name":"old","visibility":"external","parameters":{"parameters":[]},
+				"documentation":{"text":" @custom:deprecated"},"body":{"nodeType":"Block","statements":[` + call(10) + `]}},
+			{"nodeType":"FunctionDefinition","name":"run","visibility":"external","parameters":{"parameters":[]},
+				"body":{"nodeType":"Block","statements":[` + call(10) + `,` + call(20) + `,` + call(10) + `]}},
+			{"nodeType":"FunctionDefinition","name":"migrate","visibility":"external","parameters":{"parameters":[]},
+				"documentation":{"text":" @custom:interfaces-ignore deprecated-function"},
+				"body":{"nodeType":"Block","statements":[` + call(20) + `]}}
+		]}
+	]}}`
+
+	setConfig(t, &Config{})
+	findings, err := checkDeprecatedFunctions(delegatecallTarget(t, artifact))
+	require.NoError(t, err)
+	require.Len(t, findings, 3)
+	require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	require.Equal(t, "Test.run references deprecated Base.legacy", findings[0].Message)
+	require.Equal(t, "Test.run references deprecated Test.old", findings[1].Message)
+	require.Equal(t, "Test.old is deprecated but ITest.old is not tagged @custom:deprecated", findings[2].Message)
+}
+
+func TestCheckDeprecatedFunctionsSeparatesBuilds(t *testing.T) {
+	withCompiler := func(src, version string) string {
+		return strings.Replace(src, `{"abi":[],`, `{"abi":[],"metadata":{"compiler":{"version":"`+version+`"}},`, 1)
+	}
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Base.sol/Base.json": withCompiler(`{"abi":[],"ast":{"absolutePath":"src/Base.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"Base","contractKind":"contract","nodes":[
+				{"nodeType":"FunctionDefinition","id":10,"name":"legacy","visibility":"internal",
+					"documentation":{"text":" @custom:deprecated"}}
+			]}
+		]}}`, "0.8.15"),
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	// Test inherits Base, but its artifact comes from another build, where id 10 is some other
+	// declaration of Test's own.
+	artifact := func(version string) string {
+		return withCompiler(`{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract",
+				"baseContracts":[{"baseName":{"nodeType":"IdentifierPath","name":"Base"}}],"nodes":[
+				{"nodeType":"FunctionDefinition","name":"run","visibility":"external","parameters":{"parameters":[]},
+					"body":{"nodeType":"Block","statements":[{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall",
+						"expression":{"nodeType":"Identifier","referencedDeclaration":10}}}]}}
+			]}
+		]}}`, version)
+	}
+
+	setConfig(t, &Config{})
+	findings, err := checkDeprecatedFunctions(delegatecallTarget(t, artifact("0.8.19")))
+	require.NoError(t, err)
+	require.Empty(t, findings)
+
+	findings, err = checkDeprecatedFunctions(delegatecallTarget(t, artifact("0.8.15")))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, "Test.run references deprecated Base.legacy", findings[0].Message)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+)
+
+// ABI diff contexts for --diff-context, which control how a changed interface function, event or
+// error is shown when only its parameters differ.
+const (
+	// diffContextFull shows the item as a REMOVE of the interface signature and an ADD of the
+	// contract signature.
+	diffContextFull = "full"
+	// diffContextSignature shows the interface signature once, followed by the changed fields.
+	diffContextSignature = "signature"
+	// diffContextMinimal shows the kind and name of the item and the changed fields.
+	diffContextMinimal = "minimal"
+)
+
+// diffContext is the --diff-context setting.
+var diffContext = diffContextSignature
+
+func parseDiffContext(value string) (string, error) {
+	switch value {
+	case diffContextFull, diffContextSignature, diffContextMinimal:
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid --diff-context %q: expected %s, %s or %s",
+		value, diffContextFull, diffContextSignature, diffContextMinimal)
+}
+
+// fieldMismatch describes an interface item and a contract item that differ only in their
+// parameters, down to the fields of struct parameters, as one CHANGE line naming each changed
+// field by its path, e.g. _config.limits.gasLimit. It reports false with diffContextFull, and when
+// the items differ in any other way or in the number of parameters.
+func fieldMismatch(interfaceItem, contractItem map[string]interface{}) (string, bool) {
+	if diffContext == diffContextFull || !reflect.DeepEqual(withoutParams(interfaceItem), withoutParams(contractItem)) {
+		return "", false
+	}
+
+	var changes []string
+	for _, key := range []string{"inputs", "outputs"} {
+		interfaceParams, _ := interfaceItem[key].([]interface{})
+		contractParams, _ := contractItem[key].([]interface{})
+		if len(interfaceParams) != len(contractParams) {
+			return "", false
+		}
+		prefix := ""
+		if key == "outputs" {
+			prefix = "returns"
+		}
+		changes = append(changes, paramChanges(interfaceParams, contractParams, prefix)...)
+	}
+	if len(changes) == 0 {
+		return "", false
+	}
+
+	label := formatABIItem(interfaceItem)
+	if diffContext == diffContextMinimal {
+		label = getString(interfaceItem, "type") + " " + getString(interfaceItem, "name")
+	}
+	return fmt.Sprintf("CHANGE %s: %s", label, strings.Join(changes, "; ")), true
+}
+
+// paramChanges compares two parameter lists of the same length position by position, descending
+// into the components of struct parameters whose other attributes match.
+func paramChanges(interfaceParams, contractParams []interface{}, prefix string) []string {
+	var changes []string
+	for i := range interfaceParams {
+		interfaceParam, _ := interfaceParams[i].(map[string]interface{})
+		contractParam, _ := contractParams[i].(map[string]interface{})
+		path := getString(interfaceParam, "name")
+		if path == "" {
+			path = fmt.Sprintf("#%d", i+1)
+		}
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		interfaceComponents, _ := interfaceParam["components"].([]interface{})
+		contractComponents, _ := contractParam["components"].([]interface{})
+		switch {
+		case reflect.DeepEqual(interfaceParam, contractParam):
+		case len(interfaceComponents) > 0 && reflect.DeepEqual(withoutComponents(interfaceParam), withoutComponents(contractParam)):
+			if len(interfaceComponents) != len(contractComponents) {
+				changes = append(changes, fmt.Sprintf("%s has %d fields in the interface, %d in the contract",
+					path, len(interfaceComponents), len(contractComponents)))
+				continue
+			}
+			changes = append(changes, paramChanges(interfaceComponents, contractComponents, path)...)
+		default:
+			changes = append(changes, fmt.Sprintf("%s is %s in the interface, %s in the contract",
+				path, describeParam(interfaceParam), describeParam(contractParam)))
+		}
+	}
+	return changes
+}
+
+// describeParam renders a parameter like formatABIParams, falling back to the ABI type when the
+// parameter has no internalType and marking indexed event parameters.
+func describeParam(param map[string]interface{}) string {
+	paramType := getString(param, "internalType")
+	if parts := strings.Fields(paramType); len(parts) == 2 {
+		paramType = parts[1]
+	}
+	if paramType == "" {
+		paramType = getString(param, "type")
+	}
+	if param["indexed"] == true {
+		paramType += " indexed"
+	}
+	if name := getString(param, "name"); name != "" {
+		return paramType + " " + name
+	}
+	return paramType
+}
+
+// withoutParams returns a copy of an ABI item without its inputs and outputs.
+func withoutParams(item map[string]interface{}) map[string]interface{} {
+	rest := maps.Clone(item)
+	delete(rest, "inputs")
+	delete(rest, "outputs")
+	return rest
+}
+
+// withoutComponents returns a copy of an ABI parameter without its struct components.
+func withoutComponents(param map[string]interface{}) map[string]interface{} {
+	rest := maps.Clone(param)
+	delete(rest, "components")
+	return rest
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatABIDiffsContext(t *testing.T) {
+	abi := func(gasLimit string) []map[string]interface{} {
+		items, err := normalizeABI(json.RawMessage(`[{"type":"function","name":"setConfig","stateMutability":"nonpayable","inputs":[
+			{"name":"_owner","type":"address","internalType":"address"},
+			{"name":"_config","type":"tuple","internalType":"struct ITest.Config","components":[
+				{"name":"fee","type":"uint256","internalType":"uint256"},
+				{"name":"limits","type":"tuple","internalType":"struct ITest.Limits","components":[
+					{"name":"gasLimit","type":"` + gasLimit + `","internalType":"` + gasLimit + `"}
+				]}
+			]}
+		],"outputs":[]}]`))
+		require.NoError(t, err)
+		return items
+	}
+	interfaceABI, contractABI := abi("uint64"), abi("uint32")
+	diffs := diffABIs(interfaceABI, contractABI)
+	require.Len(t, diffs, 2)
+
+	for context, want := range map[string][]string{
+		diffContextFull: {
+			"REMOVE function from interface: function setConfig(address _owner, ITest.Config _config)",
+			"ADD function to interface: function setConfig(address _owner, ITest.Config _config)",
+		},
+		diffContextSignature: {
+			"CHANGE function setConfig(address _owner, ITest.Config _config): _config.limits.gasLimit is uint64 gasLimit in the interface, uint32 gasLimit in the contract",
+		},
+		diffContextMinimal: {
+			"CHANGE function setConfig: _config.limits.gasLimit is uint64 gasLimit in the interface, uint32 gasLimit in the contract",
+		},
+	} {
+		t.Run(context, func(t *testing.T) {
+			prev := diffContext
+			diffContext = context
+			t.Cleanup(func() { diffContext = prev })
+			require.Equal(t, want, formatABIDiffs(diffs, interfaceABI, contractABI))
+		})
+	}
+}
+
+func TestParseDiffContext(t *testing.T) {
+	value, err := parseDiffContext("minimal")
+	require.NoError(t, err)
+	require.Equal(t, diffContextMinimal, value)
+
+	_, err = parseDiffContext("short")
+	require.EqualError(t, err, `invalid --diff-context "short": expected full, signature or minimal`)
+}
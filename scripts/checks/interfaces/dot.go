@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+)
+
+// dotColors maps a contract's interface status to its node color in the coverage graph.
+var dotColors = map[string]string{
+	"interface": "palegreen",
+	"missing":   "lightcoral",
+	"excluded":  "lightgray",
+}
+
+// sourceContractBases reads the artifact of each scanned contract and returns its direct bases
+// from the AST. Contracts without an artifact, e.g. when the build is stale, have no edges.
+func sourceContractBases(contracts []sourceContract) (map[string][]string, error) {
+	bases := make(map[string][]string)
+	for _, contract := range contracts {
+		path, ok, err := artifactPathForContract(contract.Name)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		artifact, err := readArtifact(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read artifact of %s: %w", contract.Name, err)
+		}
+		if node := artifact.contractNode(contract.Name); node != nil {
+			bases[contract.Name] = baseContractNames(node)
+		}
+	}
+	return bases, nil
+}
+
+// writeDOT writes a graphviz digraph of the scanned contracts, colored by interface status, with
+// an edge from each contract to the scanned contracts it inherits from.
+func writeDOT(w io.Writer, contracts []sourceContract, bases map[string][]string) error {
+	names := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		names = append(names, contract.Name)
+	}
+
+	lines := []string{
+		"digraph interfaces {",
+		"  rankdir=BT;",
+		`  node [shape=box, style=filled, fontname="Helvetica"];`,
+	}
+	for _, contract := range contracts {
+		status := "missing"
+		switch {
+		case contract.Excluded:
+			status = "excluded"
+		case contract.HasInterface:
+			status = "interface"
+		}
+		lines = append(lines, fmt.Sprintf("  %q [fillcolor=%s, tooltip=%q];", contract.Name, dotColors[status], contract.SourcePath))
+	}
+	for _, contract := range contracts {
+		for _, base := range bases[contract.Name] {
+			if slices.Contains(names, base) {
+				lines = append(lines, fmt.Sprintf("  %q -> %q;", contract.Name, base))
+			}
+		}
+	}
+	lines = append(lines, "}")
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
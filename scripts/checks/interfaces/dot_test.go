@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDOT(t *testing.T) {
+	contracts := []sourceContract{
+		{Name: "Portal", SourcePath: "src/L1/Portal.sol", HasInterface: true},
+		{Name: "Base", SourcePath: "src/L1/Base.sol"},
+		{Name: "Spacer", SourcePath: "src/L1/Spacer.sol", Excluded: true},
+	}
+	bases := map[string][]string{"Portal": {"Base", "Initializable"}}
+
+	var out bytes.Buffer
+	require.NoError(t, writeDOT(&out, contracts, bases))
+	require.Equal(t, `digraph interfaces {
+  rankdir=BT;
+  node [shape=box, style=filled, fontname="Helvetica"];
+  "Portal" [fillcolor=palegreen, tooltip="src/L1/Portal.sol"];
+  "Base" [fillcolor=lightcoral, tooltip="src/L1/Base.sol"];
+  "Spacer" [fillcolor=lightgray, tooltip="src/L1/Spacer.sol"];
+  "Portal" -> "Base";
+}
+`, out.String())
+}
+
+func TestSourceContractBases(t *testing.T) {
+	dir := t.TempDir()
+	prev := artifactsDir
+	artifactsDir = dir
+	t.Cleanup(func() { artifactsDir = prev })
+
+	artifact := `{"abi":[],"ast":{"absolutePath":"src/Portal.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Portal","contractKind":"contract","baseContracts":[
+			{"nodeType":"InheritanceSpecifier","baseName":{"nodeType":"IdentifierPath","name":"Base"}}
+		]}
+	]}}`
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "Portal.sol"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Portal.sol", "Portal.json"), []byte(artifact), 0644))
+	// Artifacts are found the way every other check finds them, including versioned ones.
+	versioned := strings.ReplaceAll(strings.ReplaceAll(artifact, "Portal", "Vault"), `"Base"`, `"Portal"`)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "Vault.sol"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Vault.sol", "Vault.0.8.15.json"), []byte(versioned), 0644))
+
+	bases, err := sourceContractBases([]sourceContract{
+		{Name: "Portal", SourcePath: "src/Portal.sol"},
+		{Name: "Vault", SourcePath: "src/Vault.sol"},
+		{Name: "Unbuilt", SourcePath: "src/Unbuilt.sol"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"Portal": {"Base"}, "Vault": {"Portal"}}, bases)
+}
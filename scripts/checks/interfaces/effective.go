@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// effectiveConfig is the configuration a run actually uses, printed by --print-config. Every
+// value and exclusion records where it came from, so that it is clear why a contract is or is
+// not checked.
+type effectiveConfig struct {
+	ConfigFile    string                    `json:"configFile"`
+	Settings      map[string]effectiveValue `json:"settings"`
+	Exclusions    []effectiveExclusion      `json:"exclusions"`
+	InlineIgnores []inlineIgnore            `json:"inlineIgnores"`
+}
+
+// effectiveValue is a setting and its source: the config file, or "default" when the config
+// leaves it unset.
+type effectiveValue struct {
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+// effectiveExclusion is a contract that a check skips.
+type effectiveExclusion struct {
+	Check    string `json:"check"`
+	Contract string `json:"contract"`
+	Source   string `json:"source"`
+}
+
+// inlineIgnore is an opt-out written in a Solidity source, either ignoreTag on a definition or
+// an @checks:abi-ignore comment in an interface.
+type inlineIgnore struct {
+	Check string `json:"check"`
+	File  string `json:"file"`
+	Line  int    `json:"line"`
+	Text  string `json:"text"`
+}
+
+// resolveEffectiveConfig merges the defaults in this package, the config read from configFile,
+// the .checkignore files and the inline ignores under the source and interface roots.
+func resolveEffectiveConfig(configFile string) (*effectiveConfig, error) {
+	effective := &effectiveConfig{
+		ConfigFile:    configFile,
+		Settings:      make(map[string]effectiveValue),
+		InlineIgnores: []inlineIgnore{},
+	}
+	setting := func(key string, value any, isSet bool, fallback any) {
+		if isSet {
+			effective.Settings[key] = effectiveValue{Value: value, Source: configFile}
+		} else {
+			effective.Settings[key] = effectiveValue{Value: fallback, Source: "default"}
+		}
+	}
+	setting("sourceRoots", config.SourceRoots, len(config.SourceRoots) > 0, defaultSourceRoots)
+	setting("delegatecall.allow", config.Delegatecall.Allow, len(config.Delegatecall.Allow) > 0, []string{})
+	setting("structReturn.maxFields", config.StructReturn.MaxFields, config.StructReturn.MaxFields > 0, defaultMaxStructReturnFields)
+	setting("externalFunctions.max", config.ExternalFunctions.Max, config.ExternalFunctions.Max > 0, defaultMaxExternalFunctions)
+	setting("parameters.max", config.Parameters.Max, config.Parameters.Max > 0, defaultMaxParameters)
+	setting("returnValues.max", config.ReturnValues.Max, config.ReturnValues.Max > 0, defaultMaxReturnFields)
+	setting("selectorClash.reserved", config.SelectorClash.Reserved, len(config.SelectorClash.Reserved) > 0, defaultReservedSelectors)
+	setting("accessControl.patterns", config.AccessControl.Patterns, len(config.AccessControl.Patterns) > 0, defaultPrivilegedPatterns)
+	setting("proxyAdmin.proxies", config.ProxyAdmin.Proxies, len(config.ProxyAdmin.Proxies) > 0, defaultProxyContracts)
+	setting("proxyAdmin.patterns", config.ProxyAdmin.Patterns, len(config.ProxyAdmin.Patterns) > 0, defaultProxyAdminPatterns)
+	setting("addressRegistry", config.AddressRegistry, config.AddressRegistry.Registry != "", AddressRegistryConfig{})
+	setting("gasBudget", config.GasBudget, config.GasBudget.Budget != "", GasBudgetConfig{})
+	setting("upgradeable.contracts", config.Upgradeable.Contracts, len(config.Upgradeable.Contracts) > 0, []string{})
+	setting("magicNumbers.max", config.MagicNumbers.Max, config.MagicNumbers.Max > 0, defaultMaxMagicNumber)
+	setting("magicNumbers.allow", config.MagicNumbers.Allow, len(config.MagicNumbers.Allow) > 0, []string{})
+	setting("forbiddenOpcodes", config.ForbiddenOpcodes, len(config.ForbiddenOpcodes.Opcodes) > 0, ForbiddenOpcodesConfig{})
+	setting("functionOrder.policy", config.FunctionOrder.Policy, config.FunctionOrder.Policy != "", "")
+	setting("predeploys.library", config.Predeploys.Library, config.Predeploys.Library != "", defaultPredeploysLibrary)
+	setting("predeploys.addresses", config.Predeploys.Addresses, len(config.Predeploys.Addresses) > 0, map[string]string{})
+	setting("payableCoverage.report", config.PayableCoverage.Report, config.PayableCoverage.Report != "", "")
+	setting("standaloneInterfaces.allow", config.StandaloneInterfaces.Allow, len(config.StandaloneInterfaces.Allow) > 0, []string{})
+	setting("eventRules", config.EventRules, len(config.EventRules) > 0, []EventRule{})
+	setting("modifierRules", config.ModifierRules, len(config.ModifierRules) > 0, []ModifierRule{})
+	setting("requiredEvents", config.RequiredEvents, len(config.RequiredEvents) > 0, []RequiredEventsRule{})
+	setting("eventNaming", config.EventNaming, len(config.EventNaming) > 0, []EventNamingRule{})
+	setting("pragmaExempt", config.PragmaExempt, len(config.PragmaExempt) > 0, []PragmaExemption{})
+
+	for _, dir := range common.ExcludedDirs {
+		source := "--exclude-dir"
+		if slices.Contains(common.DefaultExcludedDirs, dir) {
+			source = "default: excluded directory"
+		}
+		effective.Exclusions = append(effective.Exclusions, effectiveExclusion{Check: "*", Contract: strings.TrimSuffix(dir, "/") + "/**", Source: source})
+	}
+	if !includeTests {
+		effective.Exclusions = append(effective.Exclusions, effectiveExclusion{Check: "interfaces", Contract: "*.t.sol, test/**, tests/**", Source: "default: test contracts (--include-tests to check them)"})
+	}
+	for _, contract := range excludeSourceContracts {
+		effective.Exclusions = append(effective.Exclusions, effectiveExclusion{Check: "interfaces", Contract: contract, Source: "main.go: excludeSourceContracts"})
+	}
+	for _, contract := range excludeContracts {
+		effective.Exclusions = append(effective.Exclusions, effectiveExclusion{Check: "interfaces", Contract: contract, Source: "main.go: excludeContracts"})
+	}
+	for check, contracts := range config.Exclude {
+		for _, contract := range contracts {
+			effective.Exclusions = append(effective.Exclusions, effectiveExclusion{Check: check, Contract: contract, Source: configFile + ": exclude." + check})
+		}
+	}
+
+	var sourceGlobs, sourceExcludes []string
+	checkIgnoreGlobs := []string{checkIgnoreFile}
+	for _, root := range sourceRoots() {
+		sourceGlobs = append(sourceGlobs, filepath.ToSlash(filepath.Join(root.Root, "**/*.sol")),
+			filepath.ToSlash(filepath.Join(root.ExpectedInterfaceRoot, "**/*.sol")))
+		sourceExcludes = append(sourceExcludes, root.Exclude...)
+		checkIgnoreGlobs = append(checkIgnoreGlobs, filepath.ToSlash(filepath.Join(root.Root, "**", checkIgnoreFile)))
+	}
+
+	checkIgnores, err := common.FindFiles(checkIgnoreGlobs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range checkIgnores {
+		names, _ := readCheckIgnore(filepath.Dir(path))
+		for _, contract := range names {
+			effective.Exclusions = append(effective.Exclusions, effectiveExclusion{Check: "interfaces", Contract: contract, Source: path})
+		}
+	}
+
+	sources, err := common.FindFiles(sourceGlobs, sourceExcludes)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range sources {
+		ignores, err := readInlineIgnores(path)
+		if err != nil {
+			return nil, err
+		}
+		effective.InlineIgnores = append(effective.InlineIgnores, ignores...)
+	}
+
+	slices.SortFunc(effective.Exclusions, func(a, b effectiveExclusion) int {
+		return strings.Compare(a.Check+"\x00"+a.Contract+"\x00"+a.Source, b.Check+"\x00"+b.Contract+"\x00"+b.Source)
+	})
+	slices.SortFunc(effective.InlineIgnores, func(a, b inlineIgnore) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		return a.Line - b.Line
+	})
+	return effective, nil
+}
+
+// readInlineIgnores returns the ignoreTag and @checks:abi-ignore opt-outs in the source at path.
+func readInlineIgnores(path string) ([]inlineIgnore, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var ignores []inlineIgnore
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if match := abiIgnoreRegex.FindStringSubmatch(text); match != nil {
+			ignores = append(ignores, inlineIgnore{Check: "interfaces", File: path, Line: line, Text: strings.Join(strings.Fields(match[1]), " ")})
+			continue
+		}
+		_, rest, ok := strings.Cut(text, ignoreTag)
+		if fields := strings.Fields(rest); ok && len(fields) > 0 {
+			ignores = append(ignores, inlineIgnore{Check: fields[0], File: path, Line: line, Text: strings.TrimSpace(text)})
+		}
+	}
+	return ignores, scanner.Err()
+}
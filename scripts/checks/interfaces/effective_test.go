@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveEffectiveConfig(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"src/L1/.checkignore": "Legacy\n",
+		"src/L1/Legacy.sol":   "contract Legacy {}\n",
+		"src/L1/Portal.sol": "contract Portal {\n" +
+			"    /// @custom:interfaces-ignore raw-bytes-param\n" +
+			"    function relay(bytes calldata data) external {}\n}\n",
+		"src/vendor/Vendored.sol": "/// @custom:interfaces-ignore raw-bytes-param\ncontract Vendored {}\n",
+		"interfaces/L1/IPortal.sol": "interface IPortal {\n" +
+			"    // @checks:abi-ignore function deprecatedThing()\n}\n",
+	})
+	checkIgnoreCache.Clear()
+	t.Cleanup(checkIgnoreCache.Clear)
+	setConfig(t, &Config{
+		SourceRoots:       []SourceRoot{{Root: "src", ExpectedInterfaceRoot: "interfaces", Exclude: []string{"src/vendor/**"}}},
+		Exclude:           map[string][]string{"selector-clash": {"Proxy"}},
+		ExternalFunctions: ExternalFunctionsConfig{Max: 10},
+	})
+
+	effective, err := resolveEffectiveConfig("config.json")
+	require.NoError(t, err)
+
+	require.Equal(t, effectiveValue{Value: 10, Source: "config.json"}, effective.Settings["externalFunctions.max"])
+	require.Equal(t, effectiveValue{Value: defaultMaxParameters, Source: "default"}, effective.Settings["parameters.max"])
+
+	require.Contains(t, effective.Exclusions, effectiveExclusion{Check: "interfaces", Contract: "Legacy", Source: "src/L1/.checkignore"})
+	require.Contains(t, effective.Exclusions, effectiveExclusion{Check: "interfaces", Contract: "WETH", Source: "main.go: excludeSourceContracts"})
+	require.Contains(t, effective.Exclusions, effectiveExclusion{Check: "selector-clash", Contract: "Proxy", Source: "config.json: exclude.selector-clash"})
+
+	require.Equal(t, []inlineIgnore{
+		{Check: "interfaces", File: "interfaces/L1/IPortal.sol", Line: 2, Text: "function deprecatedThing()"},
+		{Check: "raw-bytes-param", File: "src/L1/Portal.sol", Line: 2, Text: "/// @custom:interfaces-ignore raw-bytes-param"},
+	}, effective.InlineIgnores)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkEmitArgumentOrder flags emit statements of source contracts whose arguments look swapped:
+// an argument that is a plain identifier named like another parameter of the event than the one
+// it is passed as, e.g. `emit Transfer(to, from, amount)` for `event Transfer(from, to, amount)`.
+// Leading and trailing underscores are ignored. The heuristic misses renamed variables and can
+// be fooled by deliberate reuse of names, so the finding is informational unless --strict is
+// set, and a function can opt out with ignoreTag.
+func checkEmitArgumentOrder(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("emit-argument-order", t.name) {
+		return nil, nil
+	}
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	// Events declared in the file are resolved by id, others by name and arity in the ABI.
+	eventsByID := make(map[float64][]string)
+	walkAST(t.artifact.tree(), func(n astNode, _ []astNode) bool {
+		if id, ok := n["id"].(float64); ok && n.nodeType() == "EventDefinition" {
+			var names []string
+			for _, param := range n.child("parameters").children("parameters") {
+				names = append(names, param.name())
+			}
+			eventsByID[id] = names
+		}
+		return true
+	})
+	eventsByName := make(map[string][][]string)
+	for _, item := range items {
+		if getString(item, "type") != "event" {
+			continue
+		}
+		inputs, _ := item["inputs"].([]interface{})
+		names := make([]string, 0, len(inputs))
+		for _, input := range inputs {
+			if param, ok := input.(map[string]interface{}); ok {
+				names = append(names, getString(param, "name"))
+			}
+		}
+		eventsByName[getString(item, "name")] = append(eventsByName[getString(item, "name")], names)
+	}
+
+	severity := common.SeverityInfo
+	if strict {
+		severity = common.SeverityWarning
+	}
+	var source []byte
+	var findings []common.Finding
+	var readErr error
+	walkAST(node, func(n astNode, parents []astNode) bool {
+		if n.nodeType() != "EmitStatement" || readErr != nil {
+			return true
+		}
+		fn := enclosingFunction(parents)
+		if fn != nil && hasIgnoreTag(fn, "emit-argument-order") {
+			return false
+		}
+		call := n.child("eventCall")
+		args := call.children("arguments")
+		event := call.child("expression")
+		name := event.name() + getString(event, "memberName") // Foo, or the member name of IFoo.Foo
+		id, _ := event["referencedDeclaration"].(float64)
+		params, ok := eventsByID[id]
+		if !ok {
+			var candidates [][]string
+			for _, names := range eventsByName[name] {
+				if len(names) == len(args) {
+					candidates = append(candidates, names)
+				}
+			}
+			if len(candidates) != 1 {
+				return false
+			}
+			params = candidates[0]
+		}
+		if len(params) != len(args) || !swappedArguments(args, params) {
+			return false
+		}
+
+		location := t.sourcePath()
+		if offset, ok := srcOffset(n); ok {
+			if source == nil {
+				source, readErr = readSource(t.sourcePath())
+				if readErr != nil {
+					return false
+				}
+			}
+			if offset <= len(source) {
+				location = fmt.Sprintf("%s:%d", location, bytes.Count(source[:offset], []byte("\n"))+1)
+			}
+		}
+		passed := make([]string, len(args))
+		for i, arg := range args {
+			passed[i] = arg.name()
+			if arg.nodeType() != "Identifier" {
+				passed[i] = "..."
+			}
+		}
+		findings = append(findings, newFinding("emit-argument-order", severity, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s emits %s(%s) at %s but %s declares (%s); check the argument order",
+				t.name, functionLabel(fn), name, strings.Join(passed, ", "), location, name, strings.Join(params, ", "))))
+		return false
+	})
+	if readErr != nil {
+		return nil, readErr
+	}
+	return findings, nil
+}
+
+// swappedArguments reports whether an identifier argument is named like a different parameter
+// than the one at its position.
+func swappedArguments(args []astNode, params []string) bool {
+	positions := make(map[string]int, len(params))
+	for i, param := range params {
+		if name := strings.Trim(param, "_"); name != "" {
+			positions[name] = i
+		}
+	}
+	for i, arg := range args {
+		if arg.nodeType() != "Identifier" {
+			continue
+		}
+		if j, ok := positions[strings.Trim(arg.name(), "_")]; ok && j != i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEmitArgumentOrder(t *testing.T) {
+	source := `contract Test {
+    event Transfer(address indexed from, address indexed to, uint256 amount);
+
+    function send(address _from, address _to, uint256 _amount) external {
+        emit Transfer(_to, _from, _amount);
+        emit Transfer(_from, _to, _amount);
+        emit ITest.Paused(by, true);
+    }
+}
+`
+	setupSourceFixture(t, map[string]string{"src/Test.sol": source})
+
+	ident := func(name string) string { return `{"nodeType":"Identifier","name":"` + name + `"}` }
+	emit := func(text, event string, args ...string) string {
+		return fmt.Sprintf(`{"nodeType":"EmitStatement","src":"%d:%d:0","eventCall":{"nodeType":"FunctionCall","expression":%s,"arguments":[%s]}}`,
+			strings.Index(source, text), len(text), event, strings.Join(args, ","))
+	}
+	transfer := `{"nodeType":"Identifier","name":"Transfer","referencedDeclaration":5}`
+	paused := `{"nodeType":"MemberAccess","memberName":"Paused","expression":{"nodeType":"Identifier","name":"ITest"}}`
+	artifact := `{"abi":[
+		{"type":"event","name":"Paused","inputs":[{"name":"paused","type":"bool"},{"name":"by","type":"address"}]}
+	],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+			{"nodeType":"EventDefinition","id":5,"name":"Transfer","parameters":{"parameters":[
+				{"name":"from"},{"name":"to"},{"name":"amount"}
+			]}},
+			{"nodeType":"FunctionDefinition","name":"send","body":{"nodeType":"Block","statements":[
+				` + emit("emit Transfer(_to", transfer, ident("_to"), ident("_from"), ident("_amount")) + `,
+				` + emit("emit Transfer(_from", transfer, ident("_from"), ident("_to"), ident("_amount")) + `,
+				` + emit("emit ITest.Paused", paused, ident("by"), `{"nodeType":"Literal","value":"true"}`) + `
+			]}}
+		]}
+	]}}`
+
+	setConfig(t, &Config{})
+	findings, err := checkEmitArgumentOrder(delegatecallTarget(t, artifact))
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	require.Equal(t, common.SeverityInfo, findings[0].Severity)
+	require.Equal(t, "Test.send emits Transfer(_to, _from, _amount) at src/Test.sol:5 but Transfer declares (from, to, amount); check the argument order",
+		findings[0].Message)
+	require.Equal(t, "Test.send emits Paused(by, ...) at src/Test.sol:7 but Paused declares (paused, by); check the argument order",
+		findings[1].Message)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkErrorDefinitions fails when the corresponding contract declares an error, in the contract
+// or at the top level of its file, that the interface does not. Solidity leaves file-level
+// errors out of the contract's ABI unless the contract uses them, so the ABI comparison misses
+// them; errors that are in the contract's ABI are left to that comparison.
+func checkErrorDefinitions(t *checkTarget) ([]common.Finding, error) {
+	if t.definition.ContractKind != "interface" || !strings.HasPrefix(t.name, "I") ||
+		config.isExcluded("error-definition", t.name) {
+		return nil, nil
+	}
+
+	contractName := t.name[1:]
+	contractPath, err := contractArtifactPath(contractName)
+	if err != nil {
+		return nil, err
+	}
+	contractArtifact, err := readArtifact(contractPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corresponding contract artifact: %w", err)
+	}
+	contractNode := contractArtifact.contractNode(contractName)
+	if contractNode == nil {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	declared := abiErrorNames(items)
+	for name := range declaredErrors(t.artifact, t.artifact.contractNode(t.name)) {
+		declared[name] = true
+	}
+	contractItems, err := normalizeABI(contractArtifact.ABI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse contract ABI: %w", err)
+	}
+	inContractABI := abiErrorNames(contractItems)
+
+	var findings []common.Finding
+	contractErrors := declaredErrors(contractArtifact, contractNode)
+	for _, name := range sortedErrorNames(contractErrors) {
+		if declared[name] || inContractABI[name] {
+			continue
+		}
+		findings = append(findings, newFinding("error-definition", common.SeverityError, t.sourcePath(), t.name,
+			fmt.Sprintf("%s does not declare error %s, which %s declares in %s", t.name, contractErrors[name], contractName, contractArtifact.AST.AbsolutePath)))
+	}
+	return findings, nil
+}
+
+// declaredErrors returns the errors declared in contract and at the top level of its file,
+// keyed by name, as name(types).
+func declaredErrors(artifact *Artifact, contract astNode) map[string]string {
+	errs := make(map[string]string)
+	for _, scope := range [][]astNode{artifact.tree().children("nodes"), contract.children("nodes")} {
+		for _, node := range scope {
+			if node.nodeType() == "ErrorDefinition" {
+				errs[node.name()] = fmt.Sprintf("%s(%s)", node.name(), strings.Join(astParamTypes(node.child("parameters")), ","))
+			}
+		}
+	}
+	return errs
+}
+
+func abiErrorNames(items []map[string]interface{}) map[string]bool {
+	names := make(map[string]bool)
+	for _, item := range items {
+		if getString(item, "type") == "error" {
+			names[getString(item, "name")] = true
+		}
+	}
+	return names
+}
+
+func sortedErrorNames(errs map[string]string) []string {
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckErrorDefinitions(t *testing.T) {
+	errorDef := func(name string, types ...string) string {
+		params := ""
+		for i, typeString := range types {
+			if i > 0 {
+				params += ","
+			}
+			params += `{"nodeType":"VariableDeclaration","typeDescriptions":{"typeString":"` + typeString + `"}}`
+		}
+		return `{"nodeType":"ErrorDefinition","name":"` + name + `","parameters":{"parameters":[` + params + `]}}`
+	}
+
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Test.sol/Test.json": `{
+			"abi":[{"type":"error","name":"Used","inputs":[]}],
+			"ast":{"absolutePath":"src/Test.sol","nodes":[
+				` + errorDef("Unauthorized", "address") + `,
+				` + errorDef("Used") + `,
+				{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+					` + errorDef("Declared") + `,
+					` + errorDef("TooLarge", "uint256", "bytes memory") + `
+				]}
+			]}
+		}`,
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+	setConfig(t, &Config{})
+
+	target := interfaceTarget(t, `[{"type":"error","name":"Declared","inputs":[]}]`)
+	require.NoError(t, target.artifact.setSections(json.RawMessage(`{"absolutePath":"interfaces/ITest.sol","nodes":[
+		`+errorDef("TooLarge", "uint256", "bytes")+`,
+		{"nodeType":"ContractDefinition","name":"ITest","contractKind":"interface","nodes":[]}
+	]}`), target.artifact.ABI, nil, nil))
+
+	findings, err := checkErrorDefinitions(target)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityError, findings[0].Severity)
+	require.Equal(t, "ITest does not declare error Unauthorized(address), which Test declares in src/Test.sol", findings[0].Message)
+
+	t.Run("no contract artifact", func(t *testing.T) {
+		artifactsDir = t.TempDir()
+		findings, err := checkErrorDefinitions(interfaceTarget(t, `[]`))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkStringReverts warns about `revert("...")` and `require(cond, "...")` in source
+// contracts, which cost more gas than custom errors and are harder to decode consistently.
+func checkStringReverts(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("custom-error", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	walkAST(node, func(n astNode, parents []astNode) bool {
+		if n.nodeType() != "FunctionCall" {
+			return true
+		}
+		reason, ok := stringRevertReason(n)
+		if !ok {
+			return true
+		}
+		findings = append(findings, newFinding("custom-error", common.SeverityWarning, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s reverts with string %q; use a custom error instead",
+				t.name, functionLabel(enclosingFunction(parents)), reason)))
+		return true
+	})
+	return findings, nil
+}
+
+// stringRevertReason returns the string literal reason of a revert or require call.
+func stringRevertReason(call astNode) (string, bool) {
+	callee := call.child("expression")
+	if callee.nodeType() != "Identifier" {
+		return "", false
+	}
+	args := call.children("arguments")
+	switch {
+	case callee.name() == "revert" && len(args) == 1:
+		return stringLiteral(args[0])
+	case callee.name() == "require" && len(args) == 2:
+		return stringLiteral(args[1])
+	}
+	return "", false
+}
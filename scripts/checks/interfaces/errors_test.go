@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// stringRevertArtifact reverts with a string, requires with a string, requires with a custom
+// error and reverts with a custom error.
+const stringRevertArtifact = `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"withdraw","body":{"nodeType":"Block","statements":[
+			{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall",
+				"expression":{"nodeType":"Identifier","name":"revert"},
+				"arguments":[{"nodeType":"Literal","kind":"string","value":"Test: not allowed"}]}},
+			{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall",
+				"expression":{"nodeType":"Identifier","name":"require"},
+				"arguments":[{"nodeType":"Identifier","name":"ok"},{"nodeType":"Literal","kind":"string","value":"Test: failed"}]}},
+			{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall",
+				"expression":{"nodeType":"Identifier","name":"require"},
+				"arguments":[{"nodeType":"Identifier","name":"ok"},{"nodeType":"FunctionCall","expression":{"nodeType":"Identifier","name":"Failed"},"arguments":[]}]}},
+			{"nodeType":"RevertStatement","errorCall":{"nodeType":"FunctionCall",
+				"expression":{"nodeType":"Identifier","name":"Failed"},"arguments":[]}}
+		]}}
+	]}
+]}}`
+
+func TestCheckStringReverts(t *testing.T) {
+	t.Run("flags string reasons", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkStringReverts(delegatecallTarget(t, stringRevertArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "src/Test.sol", findings[0].File)
+		require.Equal(t, `Test.withdraw reverts with string "Test: not allowed"; use a custom error instead`, findings[0].Message)
+		require.Equal(t, `Test.withdraw reverts with string "Test: failed"; use a custom error instead`, findings[1].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"custom-error": {"Test"}}})
+		findings, err := checkStringReverts(delegatecallTarget(t, stringRevertArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
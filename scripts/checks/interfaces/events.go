@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// EventRule requires events whose names match Events to declare each of the Indexed parameters.
+type EventRule struct {
+	// Events is a glob pattern over event names, e.g. "Dispute*".
+	Events  string             `json:"events"`
+	Indexed []IndexedParamRule `json:"indexed"`
+}
+
+// IndexedParamRule describes a required indexed parameter. An empty Name or Type matches any.
+type IndexedParamRule struct {
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+func (r IndexedParamRule) String() string {
+	switch {
+	case r.Name != "" && r.Type != "":
+		return r.Type + " indexed " + r.Name
+	case r.Type != "":
+		return r.Type + " indexed"
+	default:
+		return "indexed " + r.Name
+	}
+}
+
+func (r IndexedParamRule) matches(param map[string]interface{}) bool {
+	indexed, _ := param["indexed"].(bool)
+	return indexed &&
+		(r.Name == "" || getString(param, "name") == r.Name) &&
+		(r.Type == "" || getString(param, "type") == r.Type)
+}
+
+// checkEventRules validates the events of source contracts against the configured event rules.
+func checkEventRules(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || len(config.EventRules) == 0 || config.isExcluded("event-indexed-param", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []common.Finding
+	for _, item := range items {
+		if getString(item, "type") != "event" {
+			continue
+		}
+		name := getString(item, "name")
+		inputs, _ := item["inputs"].([]interface{})
+
+		for _, rule := range config.EventRules {
+			if ok, err := path.Match(rule.Events, name); err != nil {
+				return nil, fmt.Errorf("invalid event pattern %q: %w", rule.Events, err)
+			} else if !ok {
+				continue
+			}
+
+			var missing []string
+			for _, required := range rule.Indexed {
+				if !hasMatchingParam(inputs, required) {
+					missing = append(missing, required.String())
+				}
+			}
+			if len(missing) == 0 {
+				continue
+			}
+			findings = append(findings, newFinding("event-indexed-param", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s: %s must have %s (rule %q)", t.name, formatABIItem(item), strings.Join(missing, ", "), rule.Events)))
+		}
+	}
+	return findings, nil
+}
+
+func hasMatchingParam(inputs []interface{}, rule IndexedParamRule) bool {
+	for _, input := range inputs {
+		if param, ok := input.(map[string]interface{}); ok && rule.matches(param) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEventRules(t *testing.T) {
+	abi := `[
+		{"type":"event","name":"DisputeCreated","inputs":[
+			{"name":"gameId","type":"uint256","internalType":"uint256","indexed":true},
+			{"name":"creator","type":"address","internalType":"address","indexed":true}
+		]},
+		{"type":"event","name":"DisputeResolved","inputs":[
+			{"name":"gameId","type":"uint256","internalType":"uint256","indexed":false}
+		]},
+		{"type":"event","name":"Paused","inputs":[]}
+	]`
+	rules := []EventRule{{Events: "Dispute*", Indexed: []IndexedParamRule{{Name: "gameId", Type: "uint256"}}}}
+
+	t.Run("flags non-compliant events", func(t *testing.T) {
+		setConfig(t, &Config{EventRules: rules})
+		findings, err := checkEventRules(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, `Test: event DisputeResolved(uint256 gameId) must have uint256 indexed gameId (rule "Dispute*")`, findings[0].Message)
+	})
+
+	t.Run("type only", func(t *testing.T) {
+		setConfig(t, &Config{EventRules: []EventRule{{Events: "*", Indexed: []IndexedParamRule{{Type: "address"}}}}})
+		findings, err := checkEventRules(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Contains(t, findings[0].Message, "DisputeResolved")
+		require.Contains(t, findings[1].Message, "event Paused() must have address indexed")
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		setConfig(t, &Config{EventRules: []EventRule{{Events: "[", Indexed: []IndexedParamRule{{Name: "id"}}}}})
+		_, err := checkEventRules(abiTarget(t, abi))
+		require.Error(t, err)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{EventRules: rules, Exclude: map[string][]string{"event-indexed-param": {"Test"}}})
+		findings, err := checkEventRules(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// eventTopicIndex records, for every event topic in the artifacts, the parameter shapes declared
+// under it and a contract declaring each shape.
+type eventTopicIndex struct {
+	mtx    sync.Mutex
+	topics map[string]map[string]eventDeclaration // topic -> shape -> first declaration
+}
+
+// eventDeclaration is the contract, and its source file, that declares an event shape.
+type eventDeclaration struct {
+	contract string
+	file     string
+}
+
+func newEventTopicIndex() *eventTopicIndex {
+	return &eventTopicIndex{topics: make(map[string]map[string]eventDeclaration)}
+}
+
+var eventTopics = newEventTopicIndex()
+
+func (idx *eventTopicIndex) run(t *checkTarget) ([]common.Finding, error) {
+	if config.isExcluded("event-topic-collision", t.name) {
+		return nil, nil
+	}
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+	for _, item := range items {
+		// Anonymous events have no topic 0, so there is nothing to collide on.
+		if getString(item, "type") != "event" || item["anonymous"] == true {
+			continue
+		}
+		topic := eventTopic(abiSignature(item))
+		shapes, ok := idx.topics[topic]
+		if !ok {
+			shapes = make(map[string]eventDeclaration)
+			idx.topics[topic] = shapes
+		}
+		shape := eventShape(item)
+		// Keep the alphabetically first contract so that the report does not depend on the order
+		// artifacts were processed in.
+		if existing, ok := shapes[shape]; !ok || t.name < existing.contract {
+			shapes[shape] = eventDeclaration{contract: t.name, file: t.sourcePath()}
+		}
+	}
+	return nil, nil
+}
+
+// finish reports every topic declared with more than one shape. Events with the same topic have
+// the same name and types, so the shapes differ in which parameters are indexed, and an indexer
+// keyed on the topic decodes one of them from the wrong topics and data.
+func (idx *eventTopicIndex) finish() []common.Finding {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	var findings []common.Finding
+	for _, topic := range slices.Sorted(maps.Keys(idx.topics)) {
+		shapes := idx.topics[topic]
+		if len(shapes) < 2 {
+			continue
+		}
+		sorted := slices.Sorted(maps.Keys(shapes))
+		declarations := make([]string, 0, len(sorted))
+		for _, shape := range sorted {
+			declarations = append(declarations, fmt.Sprintf("%s in %s", shape, shapes[shape].contract))
+		}
+		first := shapes[sorted[0]]
+		findings = append(findings, newFinding("event-topic-collision", common.SeverityError, first.file, first.contract,
+			fmt.Sprintf("event topic %s is declared with %d shapes: %s", topic, len(sorted), strings.Join(declarations, "; "))))
+	}
+	return findings
+}
+
+// eventTopic returns the 0x-prefixed topic 0 of a canonical event signature.
+func eventTopic(signature string) string {
+	return "0x" + hex.EncodeToString(crypto.Keccak256([]byte(signature)))
+}
+
+// eventShape renders an event's canonical types with their indexed flags, which together
+// determine how a log is decoded.
+func eventShape(item map[string]interface{}) string {
+	types := canonicalABITypes(item["inputs"])
+	params, _ := item["inputs"].([]interface{})
+	for i, p := range params {
+		if param, ok := p.(map[string]interface{}); ok && param["indexed"] == true && i < len(types) {
+			types[i] += " indexed"
+		}
+	}
+	return fmt.Sprintf("%s(%s)", getString(item, "name"), strings.Join(types, ","))
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventTopicIndex(t *testing.T) {
+	withABI := func(target *checkTarget, abi string) *checkTarget {
+		target.artifact.ABI = json.RawMessage(abi)
+		return target
+	}
+	transfer := func(toIndexed bool) string {
+		return `{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+			{"name":"from","type":"address","indexed":true},
+			{"name":"to","type":"address","indexed":` + map[bool]string{true: "true", false: "false"}[toIndexed] + `},
+			{"name":"amount","type":"uint256","indexed":false}]}`
+	}
+	targets := []*checkTarget{
+		withABI(inheritanceArtifact(t, "src/Token.sol", "Token", "contract"), `[`+transfer(true)+`]`),
+		withABI(inheritanceArtifact(t, "src/Vault.sol", "Vault", "contract"), `[`+transfer(false)+`]`),
+		withABI(inheritanceArtifact(t, "src/Wrapped.sol", "Wrapped", "contract"), `[`+transfer(true)+`]`),
+		withABI(inheritanceArtifact(t, "src/Anon.sol", "Anon", "contract"),
+			`[{"type":"event","name":"Transfer","anonymous":true,"inputs":[{"name":"from","type":"address","indexed":false},{"name":"to","type":"address","indexed":false},{"name":"amount","type":"uint256","indexed":false}]}]`),
+	}
+
+	setConfig(t, &Config{})
+	index := newEventTopicIndex()
+	for _, target := range targets {
+		_, err := index.run(target)
+		require.NoError(t, err)
+	}
+
+	findings := index.finish()
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityError, findings[0].Severity)
+	require.Equal(t, "Token", findings[0].Contract)
+	require.Equal(t, "event topic 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef is declared with 2 shapes: "+
+		"Transfer(address indexed,address indexed,uint256) in Token; Transfer(address indexed,address,uint256) in Vault", findings[0].Message)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// excludeListsFile is where the hardcoded exclude lists live, as reported in findings.
+const excludeListsFile = "scripts/checks/interfaces/main.go"
+
+// excludeList is one of the hardcoded lists of contracts the interfaces check skips.
+type excludeList struct {
+	name      string
+	contracts []string
+	// known lists the names an entry can refer to, and unknown says where those were looked for.
+	known   func() (map[string]bool, error)
+	unknown string
+}
+
+// hardcodedExcludeLists returns the lists checked by the stale-exclude and duplicate-exclude
+// checks.
+func hardcodedExcludeLists() []excludeList {
+	return []excludeList{
+		{name: "excludeContracts", contracts: excludeContracts, known: artifactNames,
+			unknown: "no contract or interface of that name has an artifact"},
+		{name: "excludeSourceContracts", contracts: excludeSourceContracts, known: sourceContractNames,
+			unknown: "no contract of that name is declared under the source roots"},
+	}
+}
+
+// artifactNames returns the contract names of every artifact.
+func artifactNames() (map[string]bool, error) {
+	files, err := findArtifacts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	names := make(map[string]bool, len(files))
+	for _, file := range files {
+		names[contractNameFromArtifactPath(file)] = true
+	}
+	return names, nil
+}
+
+// sourceContractNames returns the names of the contracts declared under the source roots, which
+// are the only ones excludeSourceContracts applies to.
+func sourceContractNames() (map[string]bool, error) {
+	contracts, err := scanSourceContracts(sourceRoots())
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(contracts))
+	for _, contract := range contracts {
+		names[contract.Name] = true
+	}
+	return names, nil
+}
+
+// skipArtifact is the run of checks that only have a finish step.
+func skipArtifact(*checkTarget) ([]common.Finding, error) {
+	return nil, nil
+}
+
+// finishStaleExcludes warns about the entries of the hardcoded exclude lists that name nothing
+// the list applies to. Such an entry is left over from a rename or removal, and would silently
+// exclude a new contract that reuses the name. A list whose names cannot be looked up is
+// reported as an error, since its entries went unchecked.
+func finishStaleExcludes() []common.Finding {
+	var findings []common.Finding
+	for _, list := range hardcodedExcludeLists() {
+		known, err := list.known()
+		if err != nil {
+			findings = append(findings, newFinding("stale-exclude", common.SeverityError, excludeListsFile, "",
+				fmt.Sprintf("cannot check %s for stale entries: %v", list.name, err)))
+			continue
+		}
+		reported := make(map[string]bool)
+		for _, name := range list.contracts {
+			if known[name] || reported[name] || config.isExcluded("stale-exclude", name) {
+				continue
+			}
+			reported[name] = true
+			findings = append(findings, newFinding("stale-exclude", common.SeverityWarning, excludeListsFile, name,
+				fmt.Sprintf("%s lists %s, but %s; remove the entry", list.name, name, list.unknown)))
+		}
+	}
+	return findings
+}
+
+// finishDuplicateExcludes warns about names listed more than once in a hardcoded exclude list.
+func finishDuplicateExcludes() []common.Finding {
+	var findings []common.Finding
+	for _, list := range hardcodedExcludeLists() {
+		counts := make(map[string]int)
+		for _, name := range list.contracts {
+			counts[name]++
+			if counts[name] == 2 && !config.isExcluded("duplicate-exclude", name) {
+				findings = append(findings, newFinding("duplicate-exclude", common.SeverityWarning, excludeListsFile, name,
+					fmt.Sprintf("%s lists %s more than once; remove the duplicates", list.name, name)))
+			}
+		}
+	}
+	return findings
+}
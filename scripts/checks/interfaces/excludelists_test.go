@@ -0,0 +1,60 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExcludeListChecks(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/IProxy.sol/IProxy.json": `{"abi":[]}`,
+		"forge-artifacts/WETH.sol/WETH.0.8.json": `{"abi":[]}`,
+		"src/WETH.sol":                           "contract WETH {}\n",
+		// A library contract has an artifact but is not a source contract.
+		"forge-artifacts/LibOnly.sol/LibOnly.json": `{"abi":[]}`,
+	})
+	prevDir := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	prevContracts, prevSources := excludeContracts, excludeSourceContracts
+	excludeContracts = []string{"IProxy", "IRenamed", "IRenamed"}
+	excludeSourceContracts = []string{"WETH", "Removed", "LibOnly"}
+	t.Cleanup(func() {
+		artifactsDir = prevDir
+		excludeContracts, excludeSourceContracts = prevContracts, prevSources
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings := finishStaleExcludes()
+		require.Len(t, findings, 3)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "scripts/checks/interfaces/main.go", findings[0].File)
+		require.Equal(t, "excludeContracts lists IRenamed, but no contract or interface of that name has an artifact; remove the entry", findings[0].Message)
+		require.Equal(t, "excludeSourceContracts lists Removed, but no contract of that name is declared under the source roots; remove the entry", findings[1].Message)
+		require.Equal(t, "excludeSourceContracts lists LibOnly, but no contract of that name is declared under the source roots; remove the entry", findings[2].Message)
+	})
+
+	t.Run("scan failure", func(t *testing.T) {
+		setConfig(t, &Config{SourceRoots: []SourceRoot{{Root: "src/["}}})
+		findings := finishStaleExcludes()
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityError, findings[1].Severity)
+		require.Contains(t, findings[1].Message, "cannot check excludeSourceContracts for stale entries: ")
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings := finishDuplicateExcludes()
+		require.Len(t, findings, 1)
+		require.Equal(t, "excludeContracts lists IRenamed more than once; remove the duplicates", findings[0].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"stale-exclude": {"IRenamed", "Removed", "LibOnly"}, "duplicate-exclude": {"IRenamed"}}})
+		require.Empty(t, finishStaleExcludes())
+		require.Empty(t, finishDuplicateExcludes())
+	})
+}
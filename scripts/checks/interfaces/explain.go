@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// explanations holds remediation guidance for each finding code, printed by --explain. Codes
+// are the names findings are reported under.
+var explanations = map[string]string{
+	"interfaces": `Every contract under src/ needs an interface under interfaces/ whose ABI matches the
+contract's exactly. Other contracts and scripts use the interface to call the contract, so a
+missing or stale function, event or error means callers compile against the wrong ABI.
+Update the interface by hand so each ABI difference the check logs goes away. Functions must
+use the same parameter and return types, and structs must match field for field.
+Interfaces of structs use the I-prefixed name, e.g. IFoo.Bar for Foo.Bar. The interface must
+use "pragma solidity ^0.8.0" exactly. Use "// @checks:abi-ignore <item>" only for intentional
+differences, and remove it once the difference is gone.`,
+	"duplicate-contract-name": `Contract names must be unique across src/. Forge names artifacts after the contract, so
+two contracts with the same name make it ambiguous which artifact the checks compare against.
+Rename one of the contracts, or exclude it under exclude.duplicate-contract-name if both are
+intentional.`,
+	"delegatecall": `delegatecall runs foreign code against this contract's storage and is only safe in
+contracts built for it, such as proxies. Remove the delegatecall, or add the contract to
+delegatecall.allow in the check configuration after review.`,
+	"encode-signature": `The signature passed to abi.encodeWithSignature or used to build a selector does not match
+any known function, so the call will hit the fallback or revert. Fix the spelling and
+parameter types, or use abi.encodeCall so that the compiler checks the call.`,
+	"function-event-name": `A contract declares a function and an event with the same name, which makes logs and
+traces ambiguous. Rename the event, usually to a past-tense form such as Paused for pause().`,
+	"override-signature": `An override must keep the parameter and return types of the function it overrides.
+Change the override to match its base, or change the base and every override together.`,
+	"interface-inheritance": `When a contract inherits a base that has its own interface, the contract's interface should
+inherit the base's interface, so that the interface exposes the inherited functions.
+Add the base interface to the interface's inheritance list.`,
+	"struct-return": `A public function returns a large struct by value, which is expensive and widens the ABI.
+Return only the fields callers need, or raise structReturn.maxFields if it is intended.`,
+	"external-function-count": `The contract exposes more external functions than the configured limit, which usually means
+it should be split. Move related functions into a separate contract, or raise
+externalFunctions.max.`,
+	"raw-bytes-param": `A public function takes a generically named bytes parameter such as "data". Decode it into a
+typed parameter or struct, or give it a name that says what it holds.`,
+	"assembly-mutability": `A view or pure function's inline assembly reads or writes storage in a way its mutability
+rules out. Fix the mutability or the assembly. Add "@custom:interfaces-ignore
+assembly-mutability" to the function's NatSpec if it has been reviewed.`,
+	"custom-error": `Reverts should use custom errors rather than string reasons. Custom errors are cheaper and can
+be decoded from the ABI. Declare an error and use "revert MyError()".`,
+	"selector-clash": `The function's selector equals that of a reserved proxy function. Behind a transparent
+proxy, the admin can never reach it and everyone else may reach it unexpectedly. Rename the
+function.`,
+	"interface-concrete-type": `An interface references a concrete contract type. Interfaces should only depend on other
+interfaces, so replace the type with that contract's interface.`,
+	"event-indexed-param": `An event does not index the parameters the configured event rules require, so indexers
+cannot filter on them. Mark the listed parameters indexed.`,
+	"parameter-count": `The function takes more parameters than the configured limit. Group related parameters into
+a struct, or opt out with "@custom:interfaces-ignore parameter-count".`,
+	"access-control": `A state-changing function's name suggests a privileged action but it has no modifiers.
+Add an access-control modifier. If the function checks permissions inline, add
+"@custom:interfaces-ignore access-control".`,
+	"receives-eth": `The contract can receive ETH, which an interface cannot declare because interfaces have no
+receive function. Add "// @checks:receives-eth" to the interface so that callers know
+about it.`,
+	"forwards-calls": `The contract has a fallback(bytes) returns (bytes) that forwards calls, so it accepts
+calldata that its interface does not describe. Add "// @checks:forwards-calls" to the interface
+so that integrators know any call may succeed.`,
+	"address-registry": `An address constant differs from the canonical address registry. Fix the constant, or update
+the registry if the canonical address changed.`,
+	"assembly-comment": `Inline assembly should be directly preceded by a comment that explains why it is needed and
+what it relies on.`,
+	"proxy-admin-function": `Only the allowlisted proxies may declare proxy admin functions such as admin() or upgradeTo().
+Elsewhere they clash with the proxy's own functions. Rename the function, or add the contract
+to proxyAdmin.proxies.`,
+	"assembly-log": `Inline assembly emits a raw log0-log4. It is not described by the contract's ABI, so indexers
+decoding the ABI will not see it. Declare an event and emit it instead. If the log is
+intended, opt out with "@custom:interfaces-ignore assembly-log".`,
+	"gas-budget": `The function's gas estimate exceeds its budget in the committed budget file. Reduce the cost,
+or update the budget in the same change and explain why. New functions need a budget entry.`,
+	"return-size": `The function's outputs flatten to more fields than the limit, which can hit stack-depth
+limits in consumers. Split the return value, or opt out with
+"@custom:interfaces-ignore return-size".`,
+	"required-modifier": `The function matches a modifier rule in the check configuration but does not apply the
+required modifier. Add the modifier. If the rule does not fit this function, opt out with
+"@custom:interfaces-ignore required-modifier".`,
+	"struct-definition": `The interface and its contract both declare a struct with this name, and the fields differ.
+Fix whichever side is out of date. Better, declare the struct once in the interface or a
+types library and use it from the contract.`,
+	"inheritdoc": `An @inheritdoc tag names a contract that does not exist or does not declare the documented
+member, so the documentation is silently dropped. Point the tag at the contract or interface
+that declares the member.`,
+	"unreachable-contract": `A concrete contract has no external functions and nothing inherits from it, so a deployment
+would have nothing to call. Remove it, or make it abstract if it is meant to be a base.`,
+	"privileged-todo": `A function that applies a modifier or has a privileged-looking name contains a TODO, FIXME
+or XXX comment. Unfinished privileged logic should not be deployed. Finish the work, or move
+the note to an issue.`,
+	"interface-members": `A contract in a file under interfaces/ declares a constructor or a modifier, so it is an
+abstract contract rather than an interface. Declare it as an interface and move the
+constructor or modifier into the contract that implements it.`,
+	"upgradeable-storage": `An upgradeable contract has no room for later versions to add state. End its state
+variables with a uint256[N] __gap, sized so the contract's slots plus the gap add up to a round
+number, or move its state into an ERC-7201 struct tagged @custom:storage-location.`,
+	"setter-event": `A function matching an eventNaming rule has none of the events the rule expects, so
+off-chain consumers cannot observe the change. Emit the event from the function, or mark the
+function @custom:interfaces-ignore setter-event if it deliberately stays silent.`,
+	"generated-interface": `An interface marked "Code generated from <Contract>; DO NOT EDIT." no longer matches its
+contract, so it was edited by hand and the next regeneration would lose the edit. Revert the
+interface, make the change in the contract and regenerate the interface.`,
+	"event-topic-collision": `Two events with the same name and types, and so the same topic, index different
+parameters. An indexer keyed on the topic decodes one of them from the wrong topics and data.
+Make the indexed parameters agree, or rename one of the events.`,
+	"optimizer-settings": `The interface and its contract were compiled with different optimizer settings, so
+they probably come from different build profiles and ABI differences may reflect the build
+rather than the sources. Build both with the same profile.`,
+	"unnamed-param": `A function parameter has no name, so generated clients and docs fall back to positional
+names. Name the parameter, even if the function does not use it.`,
+	"bool-return": `An interface function returns a bare bool, which usually signals success the way ERC20's
+transfer does. Callers that forget to check it carry on after a failure. Prefer reverting on
+failure. If the bool is a result rather than a success flag, name the return value, or opt the
+function out with @custom:interfaces-ignore bool-return.`,
+	"standalone-interface": `An interface imports, directly or through other imports, a file that declares a contract
+or a library with external functions, so integrators cannot compile it without pulling in the
+implementation. Move the types it needs into a types-only file or another interface, or list
+the dependency under standaloneInterfaces.allow.`,
+	"magic-number": `A function uses a large numeric literal inline, which leaves the reader to work out what
+it means. Declare it as a named constant, or immutable if it is set at deployment. Add the value
+to magicNumbers.allow if it is self-explanatory, or put @custom:interfaces-ignore magic-number
+in a comment on the line.`,
+	"required-event": `A contract matching a requiredEvents rule does not have one of the rule's events, or has an
+event of that name with different parameters. Off-chain relayers and indexers decode these
+events by signature, so they stop seeing the contract's messages. Restore the event with the
+exact signature, or update the rule if the protocol changed.`,
+	"using-for-collision": `A contract function has the same name as a library or free function the contract attaches
+to a type with "using ... for", so x.f() and f(x) run different code. Rename one of them, or
+mark the function @custom:interfaces-ignore using-for-collision if the overlap is intended.`,
+	"error-definition": `The contract, or the file it is declared in, declares an error that its interface does not.
+Callers decoding reverts through the interface cannot recognize it. The ABI comparison only
+sees file-level errors that the contract actually uses. Declare the error in the interface.`,
+	"integer-width": `Functions of the contract take a parameter with the same name, such as amount, as different
+integer types. Passing the value from one to the other truncates or needs a cast, which hides
+accounting bugs. Use one type for the quantity, or mark a function
+@custom:interfaces-ignore integer-width if the names only coincide.`,
+	"forbidden-opcode": `The contract uses an opcode that forbiddenOpcodes rules out for its deployment target, in
+inline assembly or through a builtin such as selfdestruct. Remove the usage. If the opcode is
+acceptable for this contract, narrow forbiddenOpcodes.contracts or exclude the contract.`,
+	"function-order": `The interface declares a function out of the order set by functionOrder.policy:
+"alphabetical" orders functions by name, and "mutability" declares view and pure functions after
+the state-changing ones. Only the first out-of-order function is reported, so move it and rerun.
+The finding is advisory unless --strict is set.`,
+	"predeploy-address": `The contract spells out a predeploy address that disagrees with the Predeploys
+library: a constant named like a Predeploys constant holds another address, or an address in the
+predeploy namespace, in code or in a @custom:predeploy tag, is not a predeploy at all. Use the
+Predeploys constant instead of a literal. predeploys.addresses adds or overrides canonical
+addresses.`,
+	"deprecated-function": `The contract references a function tagged @custom:deprecated, or deprecates a public
+function whose interface counterpart lacks the tag. Call the replacement instead so the deprecated
+function can be retired, and tag the interface function too so callers see the deprecation.
+Deprecated functions may reference each other. Record existing call sites in the baseline to
+flag only new ones.`,
+	"storage-layout": `The storageLayout in the contract's artifact is inconsistent: a state variable has no
+label or the label of another one, two variables share bytes, or slots are skipped between
+consecutive variables. Packed variables at different offsets of one slot are fine. solc does not
+produce such layouts, so look for a broken build or tooling that rewrote the artifact, and
+rebuild.`,
+	"emit-argument-order": `An emit passes a variable named like one event parameter in the position of another,
+e.g. emit Transfer(to, from, amount) for event Transfer(from, to, amount). When the types match
+this compiles and logs wrong values. Reorder the arguments. If the order is intended, rename the
+variables or opt the function out with @custom:interfaces-ignore emit-argument-order.`,
+	"interface-calldata": `An external function of an interface takes an array, bytes, string or struct parameter
+in memory. External callers pass arguments in calldata, and copying them to memory costs gas for
+nothing, so interfaces declare them as calldata. Change the parameter's location, or opt the
+function out with @custom:interfaces-ignore interface-calldata.`,
+	"approve-race": `A contract exposes approve(address,uint256) with no increaseAllowance or decreaseAllowance,
+and its approve does not require the old or new allowance to be zero. A spender who sees an
+allowance being changed from one nonzero value to another can spend the old allowance first and
+the new one after. Add increaseAllowance/decreaseAllowance, or require the allowance to be reset
+to zero first. If the contract is not a token, opt it out with @custom:interfaces-ignore
+approve-race.`,
+	"stale-exclude": `An entry of excludeContracts in main.go names no contract or interface with an artifact,
+or an entry of excludeSourceContracts names no contract declared under the source roots, usually
+because the contract was renamed or removed. The entry excludes nothing today but would silently
+exclude a new contract that reuses the name. Remove it, or update it to the new name.`,
+	"duplicate-exclude": `excludeContracts or excludeSourceContracts in main.go lists the same name more than once.
+Remove the duplicates.`,
+	"payable-coverage": `A payable function has no hits in the coverage report, so no test sends it ETH or calls it
+at all. ETH-handling paths without tests are where funds get stuck or lost. Add a test that
+calls the function, with and without value, and regenerate the report. If the path is covered
+in a way the report cannot see, opt the function out with @custom:interfaces-ignore
+payable-coverage.`,
+	"stale-artifact": `The artifacts are missing or older than the source, so the result reflects the previous
+build. Run forge build and check again.`,
+	"baseline": `A baseline entry no longer matches any finding. Run with --baseline-update to remove it.`,
+}
+
+// explainFlag is --explain, which either turns on guidance after the findings or, given a
+// code, prints the guidance for that code.
+type explainFlag struct {
+	enabled bool
+	code    string
+}
+
+func (f *explainFlag) String() string {
+	return f.code
+}
+
+func (f *explainFlag) Set(value string) error {
+	f.enabled = true
+	if value != "true" {
+		f.code = value
+	}
+	return nil
+}
+
+func (f *explainFlag) IsBoolFlag() bool {
+	return true
+}
+
+// explain returns the guidance for code.
+func explain(code string) (string, error) {
+	text, ok := explanations[code]
+	if !ok {
+		codes := make([]string, 0, len(explanations))
+		for code := range explanations {
+			codes = append(codes, code)
+		}
+		slices.Sort(codes)
+		return "", fmt.Errorf("no explanation for %q; known codes: %s", code, strings.Join(codes, ", "))
+	}
+	return text, nil
+}
+
+// writeExplanations writes the guidance for every code with a warning or error in findings.
+func writeExplanations(w io.Writer, findings []common.Finding) error {
+	var codes []string
+	for _, finding := range findings {
+		if finding.Severity != common.SeverityInfo && !slices.Contains(codes, finding.Check) {
+			codes = append(codes, finding.Check)
+		}
+	}
+	slices.Sort(codes)
+
+	for _, code := range codes {
+		text, ok := explanations[code]
+		if !ok {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "\n%s:\n%s\n", code, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplanationsCoverChecks(t *testing.T) {
+	for _, check := range artifactChecks {
+		require.Contains(t, explanations, check.name)
+	}
+	for _, rule := range (&interfacesCheck{}).Info().Rules {
+		require.Contains(t, explanations, rule.Name)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	text, err := explain("selector-clash")
+	require.NoError(t, err)
+	require.Contains(t, text, "reserved proxy function")
+
+	_, err = explain("nope")
+	require.ErrorContains(t, err, `no explanation for "nope"; known codes: access-control, address-registry`)
+}
+
+func TestExplainFlag(t *testing.T) {
+	parse := func(args ...string) explainFlag {
+		var f explainFlag
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(&f, "explain", "")
+		require.NoError(t, fs.Parse(args))
+		return f
+	}
+	require.Equal(t, explainFlag{}, parse())
+	require.Equal(t, explainFlag{enabled: true}, parse("--explain"))
+	require.Equal(t, explainFlag{enabled: true, code: "interfaces"}, parse("--explain=interfaces"))
+}
+
+func TestWriteExplanations(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, writeExplanations(&out, []common.Finding{
+		{Check: "selector-clash", Severity: common.SeverityError},
+		{Check: "delegatecall", Severity: common.SeverityError},
+		{Check: "delegatecall", Severity: common.SeverityError},
+		{Check: "interfaces", Severity: common.SeverityInfo},
+		{Check: "unknown-code", Severity: common.SeverityWarning},
+	}))
+	require.Equal(t, "\ndelegatecall:\n"+explanations["delegatecall"]+"\n\nselector-clash:\n"+explanations["selector-clash"]+"\n", out.String())
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunChecksFailFast(t *testing.T) {
+	files := make(map[string]string)
+	total := 4*runtime.NumCPU() + 8
+	for i := range total {
+		name := fmt.Sprintf("C%d", i)
+		files[filepath.Join("forge-artifacts", name+".sol", name+".json")] = fmt.Sprintf(`{
+			"abi":[{"type":"function","name":"f","inputs":[{"name":"","type":"uint256","internalType":"uint256"}],"outputs":[],"stateMutability":"nonpayable"}],
+			"ast":{"absolutePath":"src/%[1]s.sol","nodes":[{"nodeType":"ContractDefinition","name":"%[1]s","contractKind":"contract","nodes":[
+				{"nodeType":"FunctionDefinition","name":"f","kind":"function","visibility":"external","parameters":{"parameters":[
+					{"nodeType":"VariableDeclaration","name":"","typeDescriptions":{"typeString":"uint256"}}
+				]}}
+			]}]}
+		}`, name)
+	}
+	setupSourceFixture(t, files)
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+	// The fixture has none of the hardcoded excluded contracts, which would each be reported.
+	setConfig(t, &Config{
+		SourceRoots: []SourceRoot{{Root: "missing", ExpectedInterfaceRoot: "interfaces"}},
+		Exclude:     map[string][]string{"stale-exclude": slices.Concat(excludeContracts, excludeSourceContracts)},
+	})
+
+	contracts := func(failFast bool) int {
+		findings, err := runChecks(context.Background(), &runTimer{}, failFast)
+		require.NoError(t, err)
+		require.True(t, common.HasProblems(findings))
+		reported := make(map[string]bool)
+		for _, finding := range findings {
+			reported[finding.Contract] = true
+		}
+		return len(reported)
+	}
+	require.Equal(t, total, contracts(false))
+	require.Less(t, contracts(true), total, "fail-fast should skip artifacts after the first warning")
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// forwardsCallsMarkerRegex matches the `// @checks:forwards-calls` comment that an interface
+// must carry when its contract forwards arbitrary calldata through its fallback, which an
+// interface cannot declare.
+var forwardsCallsMarkerRegex = regexp.MustCompile(`(?m)^\s*//+\s*@checks:forwards-calls\b`)
+
+// checkForwardsCallsMarker fails when a source contract declares a `fallback(bytes) returns
+// (bytes)` that does not simply revert but its interface lacks the forwards-calls marker.
+func checkForwardsCallsMarker(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || t.definition.ContractKind != "contract" || config.isExcluded("forwards-calls", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	hasFallback := false
+	for _, item := range items {
+		hasFallback = hasFallback || getString(item, "type") == "fallback"
+	}
+	if !hasFallback || !hasForwardingFallback(t.artifact.contractNode(t.name)) {
+		return nil, nil
+	}
+
+	// A missing interface is reported by verifyAllContractsHaveInterfaces.
+	interfacePath, ok := interfaceForSource(t.sourcePath(), t.name)
+	if !ok {
+		return nil, nil
+	}
+	content, err := os.ReadFile(interfacePath)
+	if err != nil {
+		return nil, err
+	}
+	if forwardsCallsMarkerRegex.Match(content) {
+		return nil, nil
+	}
+	return []common.Finding{newFinding("forwards-calls", common.SeverityError, t.sourcePath(), t.name,
+		fmt.Sprintf("%s has a forwarding fallback(bytes) returns (bytes) but %s lacks a `// @checks:forwards-calls` comment", t.name, repoRelative(interfacePath)))}, nil
+}
+
+// hasForwardingFallback reports whether a contract declares a fallback that takes and returns
+// bytes and does not start by reverting.
+func hasForwardingFallback(contract astNode) bool {
+	for _, fn := range contract.children("nodes") {
+		if fn.nodeType() != "FunctionDefinition" || getString(fn, "kind") != "fallback" {
+			continue
+		}
+		if len(fn.child("parameters").children("parameters")) != 1 || len(fn.child("returnParameters").children("parameters")) != 1 {
+			continue
+		}
+		statements := fn.child("body").children("statements")
+		return len(statements) == 0 || !isRevert(statements[0])
+	}
+	return false
+}
+
+// isRevert reports whether a statement is a revert, either `revert Error()` or `revert("...")`.
+func isRevert(statement astNode) bool {
+	switch statement.nodeType() {
+	case "RevertStatement":
+		return true
+	case "ExpressionStatement":
+		call := statement.child("expression")
+		return call.nodeType() == "FunctionCall" && call.child("expression").name() == "revert"
+	}
+	return false
+}
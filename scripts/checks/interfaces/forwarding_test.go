@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckForwardsCallsMarker(t *testing.T) {
+	fallback := func(params, returns int, statement string) string {
+		list := func(n int) string {
+			if n == 0 {
+				return `{"parameters":[]}`
+			}
+			return `{"parameters":[{"nodeType":"VariableDeclaration","typeDescriptions":{"typeString":"bytes"}}]}`
+		}
+		return fmt.Sprintf(`{"abi":[{"type":"fallback","stateMutability":"nonpayable"}],"ast":{"absolutePath":"src/Test.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+				{"nodeType":"FunctionDefinition","kind":"fallback","parameters":%s,"returnParameters":%s,
+					"body":{"nodeType":"Block","statements":[%s]}}
+			]}
+		]}}`, list(params), list(returns), statement)
+	}
+	forward := `{"nodeType":"Return"}`
+	revert := `{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall","expression":{"nodeType":"Identifier","name":"revert"}}}`
+
+	setupSourceFixture(t, map[string]string{"interfaces/ITest.sol": "interface ITest {}\n"})
+	setConfig(t, &Config{})
+
+	findings, err := checkForwardsCallsMarker(delegatecallTarget(t, fallback(1, 1, forward)))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityError, findings[0].Severity)
+	require.Equal(t, "Test has a forwarding fallback(bytes) returns (bytes) but interfaces/ITest.sol lacks a `// @checks:forwards-calls` comment", findings[0].Message)
+
+	for name, artifact := range map[string]string{
+		"plain fallback":     fallback(0, 0, forward),
+		"reverting fallback": fallback(1, 1, revert),
+		"revert statement":   fallback(1, 1, `{"nodeType":"RevertStatement"}`),
+	} {
+		findings, err := checkForwardsCallsMarker(delegatecallTarget(t, artifact))
+		require.NoError(t, err, name)
+		require.Empty(t, findings, name)
+	}
+
+	require.NoError(t, os.WriteFile("interfaces/ITest.sol", []byte("// @checks:forwards-calls\ninterface ITest {}\n"), 0644))
+	findings, err = checkForwardsCallsMarker(delegatecallTarget(t, fallback(1, 1, forward)))
+	require.NoError(t, err)
+	require.Empty(t, findings)
+}
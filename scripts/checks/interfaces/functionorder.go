@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// Function order policies for the function-order check.
+const (
+	// functionOrderAlphabetical orders functions by name.
+	functionOrderAlphabetical = "alphabetical"
+	// functionOrderMutability declares state-changing functions before view and pure ones.
+	functionOrderMutability = "mutability"
+)
+
+// checkFunctionOrder reports the first function of an interface under interfaces/ that is
+// declared out of the order chosen by functionOrder.policy, using the source positions of the
+// declarations. The check is disabled without a policy. Ordering is a matter of style, so the
+// finding is informational unless --strict is set.
+func checkFunctionOrder(t *checkTarget) ([]common.Finding, error) {
+	policy := config.FunctionOrder.Policy
+	if policy == "" || t.definition.ContractKind != "interface" || !strings.HasPrefix(t.sourcePath(), "interfaces/") ||
+		config.isExcluded("function-order", t.name) {
+		return nil, nil
+	}
+	if policy != functionOrderAlphabetical && policy != functionOrderMutability {
+		return nil, fmt.Errorf("invalid functionOrder.policy %q: expected %q or %q",
+			policy, functionOrderAlphabetical, functionOrderMutability)
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+	var functions []astNode
+	for _, fn := range node.children("nodes") {
+		if _, ok := srcOffset(fn); ok && fn.nodeType() == "FunctionDefinition" && fn.name() != "" {
+			functions = append(functions, fn)
+		}
+	}
+	slices.SortStableFunc(functions, func(a, b astNode) int {
+		offsetA, _ := srcOffset(a)
+		offsetB, _ := srcOffset(b)
+		return offsetA - offsetB
+	})
+
+	severity := common.SeverityInfo
+	if strict {
+		severity = common.SeverityWarning
+	}
+	for i := 1; i < len(functions); i++ {
+		prev, fn := functions[i-1], functions[i]
+		var msg string
+		switch policy {
+		case functionOrderAlphabetical:
+			if strings.ToLower(fn.name()) < strings.ToLower(prev.name()) {
+				msg = fmt.Sprintf("%s.%s is declared after %s; the alphabetical policy orders it first",
+					t.name, fn.name(), prev.name())
+			}
+		case functionOrderMutability:
+			if isReadOnly(prev) && !isReadOnly(fn) {
+				msg = fmt.Sprintf("%s.%s is declared after %s function %s; the mutability policy declares view and pure functions last",
+					t.name, fn.name(), getString(prev, "stateMutability"), prev.name())
+			}
+		}
+		if msg != "" {
+			return []common.Finding{newFinding("function-order", severity, t.sourcePath(), t.name, msg)}, nil
+		}
+	}
+	return nil, nil
+}
+
+func isReadOnly(fn astNode) bool {
+	mutability := getString(fn, "stateMutability")
+	return mutability == "view" || mutability == "pure"
+}
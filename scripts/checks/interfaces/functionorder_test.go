@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFunctionOrder(t *testing.T) {
+	target := interfaceTarget(t, `[]`)
+	// Declared in source order version, deposit, balanceOf, withdraw; the AST lists them shuffled.
+	require.NoError(t, target.artifact.setSections(json.RawMessage(`{"absolutePath":"interfaces/ITest.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"ITest","contractKind":"interface","nodes":[
+			{"nodeType":"FunctionDefinition","name":"withdraw","stateMutability":"nonpayable","src":"300:10:0"},
+			{"nodeType":"FunctionDefinition","name":"version","stateMutability":"pure","src":"0:10:0"},
+			{"nodeType":"FunctionDefinition","name":"deposit","stateMutability":"payable","src":"100:10:0"},
+			{"nodeType":"FunctionDefinition","name":"balanceOf","stateMutability":"view","src":"200:10:0"}
+		]}
+	]}`), target.artifact.ABI, nil, nil))
+
+	t.Run("alphabetical", func(t *testing.T) {
+		setConfig(t, &Config{FunctionOrder: FunctionOrderConfig{Policy: functionOrderAlphabetical}})
+		findings, err := checkFunctionOrder(target)
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityInfo, findings[0].Severity)
+		require.Equal(t, "ITest.deposit is declared after version; the alphabetical policy orders it first", findings[0].Message)
+	})
+
+	t.Run("mutability", func(t *testing.T) {
+		setConfig(t, &Config{FunctionOrder: FunctionOrderConfig{Policy: functionOrderMutability}})
+		prev := strict
+		strict = true
+		t.Cleanup(func() { strict = prev })
+		findings, err := checkFunctionOrder(target)
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "ITest.deposit is declared after pure function version; the mutability policy declares view and pure functions last",
+			findings[0].Message)
+	})
+
+	t.Run("disabled without a policy", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkFunctionOrder(target)
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		setConfig(t, &Config{FunctionOrder: FunctionOrderConfig{Policy: "random"}})
+		_, err := checkFunctionOrder(target)
+		require.ErrorContains(t, err, `invalid functionOrder.policy "random"`)
+	})
+}
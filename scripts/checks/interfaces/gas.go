@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// GasEstimates is the subset of solc's gasEstimates output the gas budget check reads. Estimates
+// are decimal strings, or "infinite" when solc cannot bound them.
+type GasEstimates struct {
+	External map[string]string `json:"external"`
+}
+
+// gasBudgetCache holds the budgets loaded from GasBudgetConfig.Budget.
+type gasBudgetCache struct {
+	once    sync.Once
+	budgets map[string]map[string]uint64
+	err     error
+}
+
+var gasBudget = &gasBudgetCache{}
+
+// loadGasBudget reads the budget file once per run.
+func loadGasBudget() (map[string]map[string]uint64, error) {
+	gasBudget.once.Do(func() {
+		path := config.GasBudget.Budget
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cwd, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			gasBudget.err = fmt.Errorf("failed to read gas budget: %w", err)
+			return
+		}
+		if err := json.Unmarshal(data, &gasBudget.budgets); err != nil {
+			gasBudget.err = fmt.Errorf("failed to parse gas budget %s: %w", path, err)
+		}
+	})
+	return gasBudget.budgets, gasBudget.err
+}
+
+// checkGasBudget fails when the artifact's gas estimate for an external function exceeds its
+// budget by more than the configured margin. Only contracts listed in the budget file are
+// checked; their functions without a budget entry, and unbounded estimates, are warnings.
+func checkGasBudget(t *checkTarget) ([]common.Finding, error) {
+	if config.GasBudget.Budget == "" || !t.isSource() || t.artifact.GasEstimates == nil {
+		return nil, nil
+	}
+
+	budgets, err := loadGasBudget()
+	if err != nil {
+		return nil, err
+	}
+	contractBudget, ok := budgets[t.name]
+	if !ok {
+		return nil, nil
+	}
+
+	signatures := make([]string, 0, len(t.artifact.GasEstimates.External))
+	for signature := range t.artifact.GasEstimates.External {
+		signatures = append(signatures, signature)
+	}
+	slices.Sort(signatures)
+
+	var findings []common.Finding
+	for _, signature := range signatures {
+		estimate := t.artifact.GasEstimates.External[signature]
+		budget, ok := contractBudget[signature]
+		if !ok {
+			findings = append(findings, newFinding("gas-budget", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s has no gas budget entry (estimate %s)", t.name, signature, estimate)))
+			continue
+		}
+
+		actual, err := strconv.ParseUint(estimate, 10, 64)
+		if err != nil {
+			findings = append(findings, newFinding("gas-budget", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s has an unbounded gas estimate (%s) against a budget of %d", t.name, signature, estimate, budget)))
+			continue
+		}
+		if float64(actual) > float64(budget)*(1+config.GasBudget.Margin/100) {
+			findings = append(findings, newFinding("gas-budget", common.SeverityError, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s gas estimate %d exceeds its budget of %d", t.name, signature, actual, budget)))
+		}
+	}
+	return findings, nil
+}
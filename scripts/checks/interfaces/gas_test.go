@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+const gasArtifact = `{"abi":[],"gasEstimates":{"external":{
+	"deposit()":"21500","withdraw(uint256)":"30000","loop()":"infinite","added()":"1000"}},
+	"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[]}
+	]}}`
+
+func TestCheckGasBudget(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"gas-budget.json": `{"Test": {"deposit()": 21000, "withdraw(uint256)": 25000, "loop()": 50000}}`,
+	})
+	reset := func() {
+		gasBudget = &gasBudgetCache{}
+	}
+	reset()
+	t.Cleanup(reset)
+
+	t.Run("flags overruns", func(t *testing.T) {
+		setConfig(t, &Config{GasBudget: GasBudgetConfig{Budget: "gas-budget.json", Margin: 5}})
+		findings, err := checkGasBudget(delegatecallTarget(t, gasArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 3)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.added() has no gas budget entry (estimate 1000)", findings[0].Message)
+		require.Equal(t, common.SeverityWarning, findings[1].Severity)
+		require.Equal(t, "Test.loop() has an unbounded gas estimate (infinite) against a budget of 50000", findings[1].Message)
+		require.Equal(t, common.SeverityError, findings[2].Severity)
+		require.Equal(t, "Test.withdraw(uint256) gas estimate 30000 exceeds its budget of 25000", findings[2].Message)
+	})
+
+	t.Run("margin", func(t *testing.T) {
+		setConfig(t, &Config{GasBudget: GasBudgetConfig{Budget: "gas-budget.json"}})
+		findings, err := checkGasBudget(delegatecallTarget(t, gasArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 4)
+		require.Equal(t, "Test.deposit() gas estimate 21500 exceeds its budget of 21000", findings[1].Message)
+	})
+
+	t.Run("missing budget", func(t *testing.T) {
+		reset()
+		setConfig(t, &Config{GasBudget: GasBudgetConfig{Budget: "missing.json"}})
+		_, err := checkGasBudget(delegatecallTarget(t, gasArtifact))
+		require.ErrorContains(t, err, "failed to read gas budget")
+	})
+}
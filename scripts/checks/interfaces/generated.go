@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// generatedMarkerRegex matches the header that marks an interface as generated from its
+// contract, following the Go convention: `// Code generated from <Contract>; DO NOT EDIT.`
+var generatedMarkerRegex = regexp.MustCompile(`(?m)^// Code generated from (\w+); DO NOT EDIT\.$`)
+
+// generatedHeader is the marker line written at the top of an interface generated from contract.
+func generatedHeader(contract string) string {
+	return fmt.Sprintf("// Code generated from %s; DO NOT EDIT.", contract)
+}
+
+// checkGeneratedInterface fails when an interface whose source carries the generated marker no
+// longer matches what would be generated from its contract, which means it was edited by hand.
+// Generation copies the contract's ABI, so the interface is regenerated in memory by comparing
+// its ABI to the contract's exactly: the @checks:abi-ignore comments that hand-maintained
+// interfaces may use are themselves an edit.
+func checkGeneratedInterface(t *checkTarget) ([]common.Finding, error) {
+	if t.definition.ContractKind != "interface" || !strings.HasPrefix(t.name, "I") ||
+		config.isExcluded("generated-interface", t.name) {
+		return nil, nil
+	}
+
+	source, err := readSource(t.sourcePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	marker := generatedMarkerRegex.FindSubmatch(source)
+	if marker == nil {
+		return nil, nil
+	}
+	contractName := string(marker[1])
+
+	var details []string
+	fail := func(format string, args ...any) ([]common.Finding, error) {
+		finding := newFinding("generated-interface", common.SeverityError, t.sourcePath(), t.name,
+			fmt.Sprintf("%s is generated from %s but %s; edit %s and regenerate the interface instead",
+				t.name, contractName, fmt.Sprintf(format, args...), contractName))
+		finding.Details = details
+		return []common.Finding{finding}, nil
+	}
+
+	if abiIgnoreRegex.Match(source) {
+		return fail("has @checks:abi-ignore comments")
+	}
+
+	contractPath, err := contractArtifactPath(contractName)
+	if err != nil {
+		return nil, err
+	}
+	contractArtifact, err := readArtifact(contractPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fail("no artifact for %s was found", contractName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s artifact: %w", contractName, err)
+	}
+	interfaceABI, err := normalizeABI(t.artifact.ABI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize interface ABI: %w", err)
+	}
+	contractABI, err := normalizeABI(contractArtifact.ABI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize contract ABI: %w", err)
+	}
+
+	diffs := diffABIs(interfaceABI, contractABI)
+	if len(diffs) == 0 {
+		return nil, nil
+	}
+	details = formatABIDiffs(diffs, interfaceABI, contractABI)
+	return fail("its ABI differs from the contract")
+}
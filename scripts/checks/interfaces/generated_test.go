@@ -0,0 +1,55 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckGeneratedInterface(t *testing.T) {
+	const abi = `[{"type":"function","name":"pause","inputs":[],"outputs":[],"stateMutability":"nonpayable"}]`
+	setup := func(t *testing.T, source string) {
+		setupSourceFixture(t, map[string]string{
+			"forge-artifacts/Test.sol/Test.json": `{"abi":` + abi + `}`,
+			"interfaces/ITest.sol":               source,
+		})
+		prev := artifactsDir
+		artifactsDir = filepath.Join(cwd, "forge-artifacts")
+		t.Cleanup(func() { artifactsDir = prev })
+		setConfig(t, &Config{})
+	}
+	generated := generatedHeader("Test") + "\ninterface ITest {\n    function pause() external;\n}\n"
+
+	t.Run("matches contract", func(t *testing.T) {
+		setup(t, generated)
+		findings, err := checkGeneratedInterface(interfaceTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("hand edited", func(t *testing.T) {
+		setup(t, generated)
+		findings, err := checkGeneratedInterface(interfaceTarget(t, `[]`))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, "ITest is generated from Test but its ABI differs from the contract; edit Test and regenerate the interface instead", findings[0].Message)
+	})
+
+	t.Run("abi ignore", func(t *testing.T) {
+		setup(t, generated+"// @checks:abi-ignore function unpause()\n")
+		findings, err := checkGeneratedInterface(interfaceTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Contains(t, findings[0].Message, "has @checks:abi-ignore comments")
+	})
+
+	t.Run("hand maintained", func(t *testing.T) {
+		setup(t, "interface ITest {}\n")
+		findings, err := checkGeneratedInterface(interfaceTarget(t, `[]`))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// importRegex matches the path of an import directive, for source units without artifacts.
+var importRegex = regexp.MustCompile(`(?m)^\s*import\s+(?:[^"';]*\s)?["']([^"']+)["']`)
+
+// sourceUnit is what the standalone-interface check needs to know about an imported file.
+type sourceUnit struct {
+	imports []string
+	// implementations lists the contracts in the file that are not interfaces or types-only
+	// libraries.
+	implementations []string
+}
+
+// sourceUnits caches sourceUnit lookups by artifacts directory and source path.
+var sourceUnits sync.Map
+
+// checkStandaloneInterface fails when an interface under interfaces/ transitively imports a file
+// that declares an implementation, since the interface then cannot be compiled without it.
+// Imports of interfaces, types-only files and the standaloneInterfaces.allow globs are fine.
+func checkStandaloneInterface(t *checkTarget) ([]common.Finding, error) {
+	if t.definition.ContractKind != "interface" || !strings.HasPrefix(t.sourcePath(), "interfaces/") ||
+		config.isExcluded("standalone-interface", t.name) {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	chain := map[string]string{t.sourcePath(): ""}
+	queue := importPaths(t.artifact.tree())
+	for _, imported := range queue {
+		chain[imported] = t.sourcePath()
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if isAllowedImport(current) {
+			continue
+		}
+		unit, err := loadSourceUnit(current)
+		if err != nil {
+			return nil, err
+		}
+		if unit == nil {
+			continue
+		}
+		if len(unit.implementations) > 0 {
+			findings = append(findings, newFinding("standalone-interface", common.SeverityError, t.sourcePath(), t.name,
+				fmt.Sprintf("%s imports %s (%s) via %s, which is not an interface or types-only file",
+					t.name, current, strings.Join(unit.implementations, ", "), importChain(chain, current))))
+			continue
+		}
+		for _, imported := range unit.imports {
+			if _, seen := chain[imported]; !seen {
+				chain[imported] = current
+				queue = append(queue, imported)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// isAllowedImport reports whether path is another interface file or is allowlisted in config.
+func isAllowedImport(path string) bool {
+	if strings.HasPrefix(path, "interfaces/") {
+		return true
+	}
+	for _, pattern := range config.StandaloneInterfaces.Allow {
+		if ok, _ := doublestar.Match(pattern, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// importChain renders the imports leading from the interface to path, e.g.
+// "interfaces/IFoo.sol -> src/Bar.sol -> src/Baz.sol".
+func importChain(chain map[string]string, path string) string {
+	links := []string{path}
+	for parent := chain[path]; parent != ""; parent = chain[parent] {
+		links = append(links, parent)
+	}
+	slices.Reverse(links)
+	return strings.Join(links, " -> ")
+}
+
+// importPaths returns the resolved paths of a source unit's import directives.
+func importPaths(tree astNode) []string {
+	var paths []string
+	for _, node := range tree.children("nodes") {
+		if node.nodeType() == "ImportDirective" {
+			if path := getString(node, "absolutePath"); path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+// loadSourceUnit describes the source at path from one of its artifacts, falling back to the
+// source text for files forge emits no artifacts for, such as files of free-standing types. It
+// returns nil when neither exists.
+func loadSourceUnit(path string) (*sourceUnit, error) {
+	key := artifactsDir + "\x00" + path
+	if cached, ok := sourceUnits.Load(key); ok {
+		return cached.(*sourceUnit), nil
+	}
+
+	unit, err := sourceUnitFromArtifacts(path)
+	if err != nil {
+		return nil, err
+	}
+	if unit == nil {
+		unit, err = sourceUnitFromSource(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sourceUnits.Store(key, unit)
+	return unit, nil
+}
+
+// sourceUnitFromArtifacts finds an artifact compiled from path. Forge nests the <File>.sol
+// directory deeper when two sources share a file name, so every matching directory is tried.
+func sourceUnitFromArtifacts(path string) (*sourceUnit, error) {
+	matches, err := doublestar.Glob(artifactsFS(), "**/"+filepath.Base(path)+"/*.json")
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		artifact, err := readArtifact(filepath.Join(artifactsDir, filepath.FromSlash(match)))
+		if err != nil {
+			return nil, err
+		}
+		if artifact.AST.AbsolutePath != path {
+			continue
+		}
+		tree := artifact.tree()
+		unit := &sourceUnit{imports: importPaths(tree)}
+		for _, node := range tree.children("nodes") {
+			if node.nodeType() == "ContractDefinition" && !isTypesOnlyContract(node) {
+				unit.implementations = append(unit.implementations, node.name())
+			}
+		}
+		return unit, nil
+	}
+	return nil, nil
+}
+
+// isTypesOnlyContract reports whether a ContractDefinition is an interface, or a library whose
+// functions are all internal and so add no code or linking to an interface that imports it.
+func isTypesOnlyContract(contract astNode) bool {
+	switch getString(contract, "contractKind") {
+	case "interface":
+		return true
+	case "library":
+		return !slices.ContainsFunc(contract.children("nodes"), func(n astNode) bool {
+			visibility := getString(n, "visibility")
+			return n.nodeType() == "FunctionDefinition" && (visibility == "public" || visibility == "external")
+		})
+	}
+	return false
+}
+
+// sourceUnitFromSource reads the imports and contract declarations of path from its text.
+// Imports relative to the file are resolved; remapped imports are taken as written.
+func sourceUnitFromSource(path string) (*sourceUnit, error) {
+	data, err := os.ReadFile(filepath.Join(cwd, path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	data = stripComments(data)
+	unit := &sourceUnit{implementations: declaredContractNames(data)}
+	for _, match := range importRegex.FindAllStringSubmatch(string(data), -1) {
+		imported := match[1]
+		if strings.HasPrefix(imported, ".") {
+			imported = filepath.ToSlash(filepath.Join(filepath.Dir(path), imported))
+		}
+		unit.imports = append(unit.imports, imported)
+	}
+	return unit, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// sourceUnitAST returns the AST of a source at path with the given imports and top-level nodes.
+func sourceUnitAST(path string, imports []string, nodes ...string) string {
+	for _, imported := range imports {
+		nodes = append(nodes, fmt.Sprintf(`{"nodeType":"ImportDirective","absolutePath":%q}`, imported))
+	}
+	return fmt.Sprintf(`{"absolutePath":%q,"nodes":[%s]}`, path, strings.Join(nodes, ","))
+}
+
+func sourceUnitArtifact(path string, imports []string, nodes ...string) string {
+	return `{"abi":[],"ast":` + sourceUnitAST(path, imports, nodes...) + `}`
+}
+
+func TestCheckStandaloneInterface(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Types.sol/Types.json": sourceUnitArtifact("src/libraries/Types.sol", nil,
+			`{"nodeType":"ContractDefinition","name":"Types","contractKind":"library","nodes":[
+				{"nodeType":"FunctionDefinition","name":"pack","visibility":"internal"}
+			]}`),
+		"forge-artifacts/Helpers.sol/Helpers.json": sourceUnitArtifact("src/libraries/Helpers.sol", []string{"src/Impl.sol"},
+			`{"nodeType":"ContractDefinition","name":"Helpers","contractKind":"library","nodes":[]}`),
+		"forge-artifacts/Impl.sol/Impl.json": sourceUnitArtifact("src/Impl.sol", nil,
+			`{"nodeType":"ContractDefinition","name":"Impl","contractKind":"contract","nodes":[]}`),
+		"src/libraries/Errors.sol": "// SPDX-License-Identifier: MIT\nimport { Impl } from \"../Impl.sol\";\nerror Unauthorized();\n",
+		// Commented-out code is neither an implementation nor an import.
+		"src/libraries/Events.sol": "// SPDX-License-Identifier: MIT\n/*\nimport { Impl } from \"../Impl.sol\";\ncontract Legacy {}\n*/\n// contract Old {}\nevent Paused();\n",
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	target := func(imports ...string) *checkTarget {
+		target := interfaceTarget(t, `[]`)
+		ast := json.RawMessage(sourceUnitAST("interfaces/ITest.sol", imports))
+		require.NoError(t, target.artifact.setSections(ast, target.artifact.ABI, nil, nil))
+		return target
+	}
+
+	t.Run("interfaces and types-only files", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkStandaloneInterface(target("interfaces/IOther.sol", "src/libraries/Types.sol"))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("transitive implementation", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkStandaloneInterface(target("src/libraries/Helpers.sol"))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, "ITest imports src/Impl.sol (Impl) via interfaces/ITest.sol -> src/libraries/Helpers.sol -> src/Impl.sol, which is not an interface or types-only file", findings[0].Message)
+	})
+
+	t.Run("source without artifacts", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkStandaloneInterface(target("src/libraries/Errors.sol"))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Contains(t, findings[0].Message, "via interfaces/ITest.sol -> src/libraries/Errors.sol -> src/Impl.sol")
+	})
+
+	t.Run("commented-out source without artifacts", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkStandaloneInterface(target("src/libraries/Events.sol"))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("allowlisted", func(t *testing.T) {
+		setConfig(t, &Config{StandaloneInterfaces: StandaloneInterfacesConfig{Allow: []string{"src/*.sol"}}})
+		findings, err := checkStandaloneInterface(target("src/libraries/Helpers.sol"))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// changedFiles restricts a run to the artifacts and sources related to these .sol paths,
+// relative to the working directory. A nil map means every file is checked.
+var changedFiles map[string]struct{}
+
+// readChangedFiles reads a newline-separated list of paths, such as the output of
+// `git diff --name-only`, keeping only Solidity files.
+func readChangedFiles(r io.Reader) (map[string]struct{}, error) {
+	files := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		path := strings.TrimSpace(scanner.Text())
+		if strings.HasSuffix(path, ".sol") {
+			files[filepath.Clean(path)] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read changed files: %w", err)
+	}
+	return files, nil
+}
+
+// changedFilesSince asks git for the .sol files that differ between the merge base of ref and
+// HEAD and the working tree, including untracked files. Paths are made relative to dir, which
+// may be a subdirectory of the repository.
+func changedFilesSince(dir, ref string) (map[string]struct{}, error) {
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root = strings.TrimSpace(root)
+
+	base, err := git(root, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("unknown ref %q: %w", ref, err)
+	}
+
+	diff, err := git(root, "diff", "--name-only", "--ignore-submodules=all", strings.TrimSpace(base))
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := git(root, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := readChangedFiles(strings.NewReader(diff + "\n" + untracked))
+	if err != nil {
+		return nil, err
+	}
+	return relativeToDir(dir, root, files)
+}
+
+// stagedFiles asks git for the .sol files added, copied, modified or renamed in the index,
+// relative to dir. Deleted files are left out since there is nothing left to check.
+func stagedFiles(dir string) (map[string]struct{}, error) {
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root = strings.TrimSpace(root)
+
+	staged, err := git(root, "diff", "--cached", "--name-only", "--diff-filter=ACMR", "--ignore-submodules=all")
+	if err != nil {
+		return nil, err
+	}
+	files, err := readChangedFiles(strings.NewReader(staged))
+	if err != nil {
+		return nil, err
+	}
+	return relativeToDir(dir, root, files)
+}
+
+// relativeToDir rewrites repository-relative paths to be relative to dir, dropping the ones
+// outside it.
+func relativeToDir(dir, root string, files map[string]struct{}) (map[string]struct{}, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	relative := make(map[string]struct{}, len(files))
+	for path := range files {
+		rel, err := filepath.Rel(absDir, filepath.Join(root, path))
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		relative[rel] = struct{}{}
+	}
+	return relative, nil
+}
+
+// staleArtifactFindings warns about changed files whose artifacts are missing or older than the
+// source, since checking them would compare against the previous build. Artifacts are found by
+// forge's <File>.sol/<Contract>.json layout, so no build is needed to tell.
+func staleArtifactFindings(files map[string]struct{}) ([]common.Finding, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	var findings []common.Finding
+	for _, path := range paths {
+		source, err := os.Stat(filepath.Join(cwd, path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		artifacts, err := fs.Glob(artifactsFS(), filepath.Base(path)+"/*.json")
+		if err != nil {
+			return nil, err
+		}
+		if len(artifacts) == 0 {
+			findings = append(findings, newFinding("stale-artifact", common.SeverityWarning, path, "",
+				"no artifacts found; run forge build before relying on this check"))
+			continue
+		}
+		for _, artifact := range artifacts {
+			info, err := fs.Stat(artifactsFS(), artifact)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat %s: %w", filepath.Join(artifactsDir, artifact), err)
+			}
+			if info.ModTime().Before(source.ModTime()) {
+				findings = append(findings, newFinding("stale-artifact", common.SeverityWarning, path, "",
+					"artifacts are older than the source; run forge build before relying on this check"))
+				break
+			}
+		}
+	}
+	return findings, nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// isChanged reports whether path is part of the restricted file set.
+func isChanged(path string) bool {
+	if changedFiles == nil {
+		return true
+	}
+	_, ok := changedFiles[filepath.Clean(path)]
+	return ok
+}
+
+// isRelevantArtifactPath reports whether an artifact belongs to a changed source file or to
+// the interface or contract counterpart of one. Forge lays artifacts out as
+// <File>.sol/<Contract>.json, so this works without reading the artifact.
+func isRelevantArtifactPath(artifactPath string) bool {
+	if changedFiles == nil {
+		return true
+	}
+	artifactFile := strings.TrimSuffix(filepath.Base(filepath.Dir(artifactPath)), ".sol")
+	for path := range changedFiles {
+		changed := strings.TrimSuffix(filepath.Base(path), ".sol")
+		if changed == artifactFile || "I"+changed == artifactFile || changed == "I"+artifactFile {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setChangedFiles(t *testing.T, files ...string) {
+	t.Helper()
+	prev := changedFiles
+	changedFiles = make(map[string]struct{}, len(files))
+	for _, file := range files {
+		changedFiles[file] = struct{}{}
+	}
+	t.Cleanup(func() { changedFiles = prev })
+}
+
+func TestReadChangedFiles(t *testing.T) {
+	files, err := readChangedFiles(strings.NewReader("src/L1/Portal.sol\nREADME.md\n\n ./interfaces/L1/IPortal.sol \n"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{
+		"src/L1/Portal.sol":         {},
+		"interfaces/L1/IPortal.sol": {},
+	}, files)
+}
+
+func TestIsRelevantArtifactPath(t *testing.T) {
+	require.True(t, isRelevantArtifactPath("forge-artifacts/Anything.sol/Anything.json"), "full runs check everything")
+
+	setChangedFiles(t, "src/L1/Portal.sol")
+	require.True(t, isRelevantArtifactPath("forge-artifacts/Portal.sol/Portal.json"))
+	require.True(t, isRelevantArtifactPath("forge-artifacts/IPortal.sol/IPortal.json"))
+	require.False(t, isRelevantArtifactPath("forge-artifacts/Bridge.sol/Bridge.json"))
+}
+
+func TestChangedFilesSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, path), []byte(content), 0644))
+	}
+
+	run("init", "-q", "-b", "main")
+	write("pkg/src/A.sol", "contract A {}")
+	write("pkg/src/B.sol", "contract B {}")
+	run("add", "-A")
+	run("commit", "-q", "-m", "init")
+	run("checkout", "-q", "--detach")
+
+	write("pkg/src/A.sol", "contract A { }")
+	write("pkg/src/C.sol", "contract C {}")
+	write("pkg/notes.md", "ignored")
+	write("other/D.sol", "contract D {}")
+
+	files, err := changedFilesSince(filepath.Join(repo, "pkg"), "main")
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"src/A.sol": {}, "src/C.sol": {}}, files)
+
+	_, err = changedFilesSince(filepath.Join(repo, "pkg"), "does-not-exist")
+	require.Error(t, err)
+}
+
+func TestStagedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, path), []byte(content), 0644))
+	}
+
+	run("init", "-q", "-b", "main")
+	write("pkg/src/A.sol", "contract A {}")
+	write("pkg/src/B.sol", "contract B {}")
+	run("add", "-A")
+	run("commit", "-q", "-m", "init")
+
+	write("pkg/src/A.sol", "contract A { }")
+	write("pkg/src/C.sol", "contract C {}")
+	write("pkg/src/Unstaged.sol", "contract Unstaged {}")
+	run("add", "pkg/src/A.sol", "pkg/src/C.sol")
+	run("rm", "-q", "pkg/src/B.sol")
+
+	files, err := stagedFiles(filepath.Join(repo, "pkg"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"src/A.sol": {}, "src/C.sol": {}}, files)
+}
+
+func TestStaleArtifactFindings(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"src/Fresh.sol":                          "contract Fresh {}\n",
+		"src/Stale.sol":                          "contract Stale {}\n",
+		"src/Unbuilt.sol":                        "contract Unbuilt {}\n",
+		"forge-artifacts/Fresh.sol/Fresh.json":   "{}",
+		"forge-artifacts/Stale.sol/Stale.json":   "{}",
+		"forge-artifacts/Stale.sol/IStale.json":  "{}",
+		"forge-artifacts/Other.sol/Unbuilt.json": "{}",
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	// Sources were edited an hour ago; one of Stale.sol's artifacts predates that edit.
+	edited, built := time.Now().Add(-time.Hour), time.Now().Add(-2*time.Hour)
+	require.NoError(t, os.Chtimes("src/Fresh.sol", edited, edited))
+	require.NoError(t, os.Chtimes("src/Stale.sol", edited, edited))
+	require.NoError(t, os.Chtimes("forge-artifacts/Stale.sol/Stale.json", built, built))
+
+	findings, err := staleArtifactFindings(map[string]struct{}{"src/Fresh.sol": {}, "src/Stale.sol": {}, "src/Unbuilt.sol": {}})
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	require.Equal(t, "src/Stale.sol", findings[0].File)
+	require.Equal(t, "artifacts are older than the source; run forge build before relying on this check", findings[0].Message)
+	require.Equal(t, "src/Unbuilt.sol", findings[1].File)
+	require.Equal(t, "no artifacts found; run forge build before relying on this check", findings[1].Message)
+}
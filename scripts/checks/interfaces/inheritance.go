@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// inheritanceEntry is a contract or interface and the names of its direct bases.
+type inheritanceEntry struct {
+	kind  string
+	file  string
+	bases []string
+}
+
+// inheritanceIndex records the direct bases of every source contract and interface so that
+// interface inheritance can be compared against contract inheritance.
+type inheritanceIndex struct {
+	mtx     sync.Mutex
+	entries map[string]inheritanceEntry
+}
+
+func newInheritanceIndex() *inheritanceIndex {
+	return &inheritanceIndex{entries: make(map[string]inheritanceEntry)}
+}
+
+var inheritance = newInheritanceIndex()
+
+func (idx *inheritanceIndex) run(t *checkTarget) ([]common.Finding, error) {
+	path := t.sourcePath()
+	if !strings.HasPrefix(path, "src/") && !strings.HasPrefix(path, "interfaces/") {
+		return nil, nil
+	}
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	entry := inheritanceEntry{
+		kind:  t.definition.ContractKind,
+		file:  path,
+		bases: baseContractNames(node),
+	}
+
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+	idx.entries[t.name] = entry
+	return nil, nil
+}
+
+func (idx *inheritanceIndex) finish() []common.Finding {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	var findings []common.Finding
+	for name, entry := range idx.entries {
+		if entry.kind != "contract" || !strings.HasPrefix(entry.file, "src/") {
+			continue
+		}
+		iface, ok := idx.entries["I"+name]
+		if !ok || iface.kind != "interface" {
+			continue
+		}
+
+		inherited := idx.ancestors("I" + name)
+		for _, base := range entry.bases {
+			if b, ok := idx.entries[base]; !ok || b.kind == "interface" {
+				continue
+			}
+			expected := "I" + base
+			if e, ok := idx.entries[expected]; !ok || e.kind != "interface" {
+				continue
+			}
+			if slices.Contains(inherited, expected) {
+				continue
+			}
+			findings = append(findings, newFinding("interface-inheritance", common.SeverityWarning, iface.file, "I"+name,
+				fmt.Sprintf("I%s should inherit %s because %s inherits %s", name, expected, name, base)))
+		}
+	}
+	return findings
+}
+
+// ancestors returns every base reachable from name, following the recorded direct bases.
+func (idx *inheritanceIndex) ancestors(name string) []string {
+	var out []string
+	queue := slices.Clone(idx.entries[name].bases)
+	for len(queue) > 0 {
+		base := queue[0]
+		queue = queue[1:]
+		if slices.Contains(out, base) {
+			continue
+		}
+		out = append(out, base)
+		queue = append(queue, idx.entries[base].bases...)
+	}
+	return out
+}
+
+// baseContractNames returns the names of a ContractDefinition's direct bases.
+func baseContractNames(contract astNode) []string {
+	var names []string
+	for _, base := range contract.children("baseContracts") {
+		name := base.child("baseName").name()
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// inheritanceArtifact returns an artifact declaring a single contract with the given bases.
+func inheritanceArtifact(t *testing.T, path, name, kind string, bases ...string) *checkTarget {
+	t.Helper()
+	baseNodes := make([]string, 0, len(bases))
+	for _, base := range bases {
+		baseNodes = append(baseNodes, fmt.Sprintf(`{"nodeType":"InheritanceSpecifier","baseName":{"nodeType":"IdentifierPath","name":%q}}`, base))
+	}
+	src := fmt.Sprintf(`{"abi":[],"ast":{"absolutePath":%q,"nodes":[{"nodeType":"ContractDefinition","name":%q,"contractKind":%q,"baseContracts":[%s]}]}}`,
+		path, name, kind, strings.Join(baseNodes, ","))
+
+	var artifact Artifact
+	require.NoError(t, json.Unmarshal([]byte(src), &artifact))
+	return &checkTarget{name: name, artifact: &artifact, definition: getContractDefinition(&artifact, name)}
+}
+
+func TestInheritanceIndex(t *testing.T) {
+	index := newInheritanceIndex()
+	targets := []*checkTarget{
+		inheritanceArtifact(t, "src/A.sol", "A", "contract"),
+		inheritanceArtifact(t, "src/B.sol", "B", "contract", "A", "ISemver"),
+		inheritanceArtifact(t, "src/C.sol", "C", "contract", "A"),
+		inheritanceArtifact(t, "src/D.sol", "D", "contract", "A"),
+		inheritanceArtifact(t, "interfaces/IA.sol", "IA", "interface"),
+		inheritanceArtifact(t, "interfaces/IB.sol", "IB", "interface"),
+		inheritanceArtifact(t, "interfaces/IC.sol", "IC", "interface", "IX"),
+		inheritanceArtifact(t, "interfaces/IX.sol", "IX", "interface", "IA"),
+		inheritanceArtifact(t, "interfaces/ISemver.sol", "ISemver", "interface"),
+	}
+	for _, target := range targets {
+		_, err := index.run(target)
+		require.NoError(t, err)
+	}
+
+	// IB is missing IA; IC inherits it through IX; D has no interface.
+	findings := index.finish()
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	require.Equal(t, "interfaces/IB.sol", findings[0].File)
+	require.Equal(t, "IB should inherit IA because B inherits A", findings[0].Message)
+}
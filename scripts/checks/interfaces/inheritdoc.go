@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+var inheritdocRegex = regexp.MustCompile(`@inheritdoc\s+(\w+)`)
+
+// artifactIndexCache maps contract names to their artifact paths, built on first use by
+// walking an artifacts directory. Artifacts are not always named after the file that declares
+// them, so looking a contract up by name needs the whole tree.
+type artifactIndexCache struct {
+	once  sync.Once
+	paths map[string]string
+	err   error
+}
+
+// artifactIndexes holds an artifactIndexCache per artifacts directory.
+var artifactIndexes sync.Map
+
+// artifactPathForContract returns the artifact of the named contract under artifactsDir,
+// preferring the unversioned artifact when several exist.
+func artifactPathForContract(name string) (string, bool, error) {
+	cached, _ := artifactIndexes.LoadOrStore(artifactsDir, &artifactIndexCache{})
+	index := cached.(*artifactIndexCache)
+	index.once.Do(func() {
+		var paths []string
+		index.err = fs.WalkDir(artifactsFS(), ".", func(entry string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(entry, ".json") {
+				paths = append(paths, filepath.Join(artifactsDir, filepath.FromSlash(entry)))
+			}
+			return nil
+		})
+		slices.Sort(paths)
+		index.paths = make(map[string]string, len(paths))
+		for _, path := range paths {
+			contract := contractNameFromArtifactPath(path)
+			unversioned := contract + ".json"
+			if existing, ok := index.paths[contract]; !ok || (filepath.Base(path) == unversioned && filepath.Base(existing) != unversioned) {
+				index.paths[contract] = path
+			}
+		}
+	})
+	path, ok := index.paths[name]
+	return path, ok, index.err
+}
+
+// checkInheritdoc warns about @inheritdoc tags in source contracts whose target does not exist
+// or does not declare a member with the documented member's name. Solidity then drops the
+// documentation without an error.
+func checkInheritdoc(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("inheritdoc", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	for _, member := range node.children("nodes") {
+		if member.nodeType() != "FunctionDefinition" && member.nodeType() != "VariableDeclaration" {
+			continue
+		}
+		match := inheritdocRegex.FindStringSubmatch(getString(member.child("documentation"), "text"))
+		if match == nil {
+			continue
+		}
+		target := match[1]
+
+		members, ok, err := contractMembers(target)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !ok:
+			findings = append(findings, newFinding("inheritdoc", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s has @inheritdoc %s, but no contract named %s was found", t.name, functionLabel(member), target, target)))
+		case !members[member.name()]:
+			findings = append(findings, newFinding("inheritdoc", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s has @inheritdoc %s, but %s does not declare %s",
+					t.name, functionLabel(member), target, target, functionLabel(member))))
+		}
+	}
+	return findings, nil
+}
+
+// declaredMembers caches the member names of the contracts looked up by contractMembers.
+var declaredMembers sync.Map
+
+// contractMembers returns the names of the functions and state variables the named contract
+// declares, and whether the contract was found.
+func contractMembers(contract string) (map[string]bool, bool, error) {
+	if cached, ok := declaredMembers.Load(contract); ok {
+		return cached.(map[string]bool), true, nil
+	}
+
+	path, ok, err := artifactPathForContract(contract)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	artifact, err := readArtifact(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read artifact of %s: %w", contract, err)
+	}
+	members := make(map[string]bool)
+	for _, node := range artifact.contractNode(contract).children("nodes") {
+		switch node.nodeType() {
+		case "FunctionDefinition", "VariableDeclaration":
+			members[node.name()] = true
+		}
+	}
+	declaredMembers.Store(contract, members)
+	return members, true, nil
+}
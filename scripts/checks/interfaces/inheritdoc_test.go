@@ -0,0 +1,63 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInheritdoc(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/IBase.sol/IBase.json": `{"abi":[],"ast":{"absolutePath":"interfaces/IBase.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"IBase","contractKind":"interface","nodes":[
+				{"nodeType":"FunctionDefinition","name":"version"},
+				{"nodeType":"FunctionDefinition","name":"owner"}]}]}}`,
+		"forge-artifacts/Helpers.sol/Helper.json": `{"abi":[],"ast":{"absolutePath":"src/Helpers.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"Helper","contractKind":"contract","nodes":[
+				{"nodeType":"FunctionDefinition","name":"_help"}]}]}}`,
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	reset := func() {
+		artifactIndexes.Clear()
+		declaredMembers.Clear()
+	}
+	reset()
+	t.Cleanup(func() {
+		artifactsDir = prev
+		reset()
+	})
+
+	doc := func(text string) string {
+		return `"documentation":{"nodeType":"StructuredDocumentation","text":"` + text + `"}`
+	}
+	artifact := `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+			{"nodeType":"FunctionDefinition","name":"version",` + doc(" @inheritdoc IBase") + `},
+			{"nodeType":"VariableDeclaration","name":"owner",` + doc(" @inheritdoc IBase") + `},
+			{"nodeType":"FunctionDefinition","name":"_help",` + doc(" @inheritdoc Helper") + `},
+			{"nodeType":"FunctionDefinition","name":"renamed",` + doc(" @inheritdoc IBase") + `},
+			{"nodeType":"FunctionDefinition","name":"typo",` + doc(" @inheritdoc IBsae") + `},
+			{"nodeType":"FunctionDefinition","name":"plain",` + doc(" @notice Does a thing.") + `}
+		]}
+	]}}`
+
+	t.Run("flags dangling targets", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkInheritdoc(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.renamed has @inheritdoc IBase, but IBase does not declare renamed", findings[0].Message)
+		require.Equal(t, "Test.typo has @inheritdoc IBsae, but no contract named IBsae was found", findings[1].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"inheritdoc": {"Test"}}})
+		findings, err := checkInheritdoc(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkInterfaceMembers fails when a contract declared in a file under interfaces/ has a
+// constructor or a modifier. A real interface cannot declare either, so these are abstract
+// contracts masquerading as interfaces.
+func checkInterfaceMembers(t *checkTarget) ([]common.Finding, error) {
+	if !strings.HasPrefix(t.sourcePath(), "interfaces/") || config.isExcluded("interface-members", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	for _, member := range node.children("nodes") {
+		var declared string
+		switch {
+		case member.nodeType() == "ModifierDefinition":
+			declared = "modifier " + member.name()
+		case member.nodeType() == "FunctionDefinition" && getString(member, "kind") == "constructor":
+			declared = "a constructor"
+		default:
+			continue
+		}
+		findings = append(findings, newFinding("interface-members", common.SeverityError, t.sourcePath(), t.name,
+			fmt.Sprintf("%s is in interfaces/ but declares %s; interfaces cannot declare constructors or modifiers", t.name, declared)))
+	}
+	return findings, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInterfaceMembers(t *testing.T) {
+	artifact, err := readArtifact(filepath.Join("testdata", "interface-members", "IMasquerade.sol", "IMasquerade.json"))
+	require.NoError(t, err)
+	target := &checkTarget{
+		path:       "forge-artifacts/IMasquerade.sol/IMasquerade.json",
+		name:       "IMasquerade",
+		artifact:   artifact,
+		definition: getContractDefinition(artifact, "IMasquerade"),
+	}
+
+	t.Run("flags constructors and modifiers", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkInterfaceMembers(target)
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, "interfaces/L1/IMasquerade.sol", findings[0].File)
+		require.Equal(t, "IMasquerade is in interfaces/ but declares a constructor; interfaces cannot declare constructors or modifiers", findings[0].Message)
+		require.Equal(t, "IMasquerade is in interfaces/ but declares modifier onlyOwner; interfaces cannot declare constructors or modifiers", findings[1].Message)
+	})
+
+	t.Run("source contracts are skipped", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkInterfaceMembers(delegatecallTarget(t, accessControlArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"interface-members": {"IMasquerade"}}})
+		findings, err := checkInterfaceMembers(target)
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
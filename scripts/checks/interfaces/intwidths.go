@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// integerTypeRegex matches the elementary integer types.
+var integerTypeRegex = regexp.MustCompile(`^u?int\d*$`)
+
+// checkIntegerWidths reports parameters of a source contract's functions that share a name but
+// not an integer type, e.g. an `amount` that is a uint256 in one function and a uint128 in
+// another. Converting between them usually means a truncation somewhere. Leading and trailing
+// underscores are ignored when comparing names, and a function can opt out with ignoreTag. The
+// finding is informational unless --strict is set.
+func checkIntegerWidths(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("integer-width", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	declared := declaredFunctions(t.artifact.contractNode(t.name))
+
+	// uses maps a parameter name to its integer types and the functions using each.
+	uses := make(map[string]map[string][]string)
+	for _, item := range items {
+		if getString(item, "type") != "function" {
+			continue
+		}
+		if fn, ok := declared[abiParamNamesKey(item)]; ok && hasIgnoreTag(fn, "integer-width") {
+			continue
+		}
+		inputs, _ := item["inputs"].([]interface{})
+		for _, input := range inputs {
+			param, _ := input.(map[string]interface{})
+			name := strings.Trim(getString(param, "name"), "_")
+			paramType := getString(param, "type")
+			if name == "" || !integerTypeRegex.MatchString(paramType) {
+				continue
+			}
+			if uses[name] == nil {
+				uses[name] = make(map[string][]string)
+			}
+			fn := getString(item, "name")
+			if !slices.Contains(uses[name][paramType], fn) {
+				uses[name][paramType] = append(uses[name][paramType], fn)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(uses))
+	for name, types := range uses {
+		if len(types) > 1 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	severity := common.SeverityInfo
+	if strict {
+		severity = common.SeverityWarning
+	}
+
+	var findings []common.Finding
+	for _, name := range names {
+		types := make([]string, 0, len(uses[name]))
+		for paramType := range uses[name] {
+			types = append(types, paramType)
+		}
+		slices.Sort(types)
+		widths := make([]string, len(types))
+		for i, paramType := range types {
+			widths[i] = fmt.Sprintf("%s in %s", paramType, strings.Join(uses[name][paramType], ", "))
+		}
+		findings = append(findings, newFinding("integer-width", severity, t.sourcePath(), t.name,
+			fmt.Sprintf("%s takes %s with different integer types: %s", t.name, name, strings.Join(widths, "; "))))
+	}
+	return findings, nil
+}
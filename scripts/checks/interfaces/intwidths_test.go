@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIntegerWidths(t *testing.T) {
+	abi := `[
+		{"type":"function","name":"deposit","inputs":[{"name":"_amount","type":"uint256"},{"name":"_to","type":"address"}],"outputs":[]},
+		{"type":"function","name":"withdraw","inputs":[{"name":"_amount","type":"uint256"}],"outputs":[]},
+		{"type":"function","name":"bridge","inputs":[{"name":"amount","type":"uint128"},{"name":"nonce","type":"uint64"}],"outputs":[]},
+		{"type":"function","name":"replay","inputs":[{"name":"nonce","type":"uint64"}],"outputs":[]},
+		{"type":"event","name":"Bridged","inputs":[{"name":"nonce","type":"uint256"}]}
+	]`
+
+	setConfig(t, &Config{})
+	findings, err := checkIntegerWidths(abiTarget(t, abi))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityInfo, findings[0].Severity)
+	require.Equal(t, "Test takes amount with different integer types: uint128 in bridge; uint256 in deposit, withdraw", findings[0].Message)
+
+	t.Run("strict escalates", func(t *testing.T) {
+		setConfig(t, &Config{})
+		prev := strict
+		strict = true
+		t.Cleanup(func() { strict = prev })
+
+		findings, err := checkIntegerWidths(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.NotEmpty(t, findings)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	})
+
+	t.Run("opt out", func(t *testing.T) {
+		target := delegatecallTarget(t, `{"abi":`+abi+`,"ast":{"absolutePath":"src/Test.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+				{"nodeType":"FunctionDefinition","name":"bridge","parameters":{"parameters":[{"name":"amount"},{"name":"nonce"}]},
+					"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore integer-width"}}
+			]}
+		]}}`)
+		findings, err := checkIntegerWidths(target)
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// logOpcodes are the Yul builtins that emit a log without going through a declared event.
+var logOpcodes = map[string]bool{"log0": true, "log1": true, "log2": true, "log3": true, "log4": true}
+
+// checkAssemblyLogs warns about raw log0-log4 calls in the inline assembly of source contracts.
+// Logs emitted this way bypass the contract's ABI, so indexers decoding it will not see them.
+// Vetted functions can opt out with ignoreTag.
+func checkAssemblyLogs(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("assembly-log", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var source []byte
+	var findings []common.Finding
+	var readErr error
+	walkAST(node, func(n astNode, parents []astNode) bool {
+		if n.nodeType() != "YulFunctionCall" || !logOpcodes[n.child("functionName").name()] || readErr != nil {
+			return true
+		}
+		fn := enclosingFunction(parents)
+		if fn != nil && hasIgnoreTag(fn, "assembly-log") {
+			return true
+		}
+
+		location := t.sourcePath()
+		if offset, ok := srcOffset(n); ok {
+			if source == nil {
+				source, readErr = readSource(t.sourcePath())
+				if readErr != nil {
+					return false
+				}
+			}
+			if offset <= len(source) {
+				location = fmt.Sprintf("%s:%d", location, bytes.Count(source[:offset], []byte("\n"))+1)
+			}
+		}
+		findings = append(findings, newFinding("assembly-log", common.SeverityWarning, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s emits a raw %s at %s that is not described by the contract's ABI",
+				t.name, functionLabel(fn), n.child("functionName").name(), location)))
+		return true
+	})
+	if readErr != nil {
+		return nil, readErr
+	}
+	return findings, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAssemblyLogs(t *testing.T) {
+	source := `contract Test {
+    function emitRaw() external {
+        assembly {
+            log1(0, 0, 0x1234)
+        }
+    }
+
+    /// @custom:interfaces-ignore assembly-log
+    function vetted() external {
+        assembly {
+            log0(0, 0)
+        }
+    }
+
+    function safe() external {
+        assembly {
+            pop(sload(0))
+        }
+    }
+}
+`
+	setupSourceFixture(t, map[string]string{"src/Test.sol": source})
+
+	yulCall := func(name string, offset int) string {
+		return fmt.Sprintf(`{"nodeType":"InlineAssembly","AST":{"nodeType":"YulBlock","statements":[
+			{"nodeType":"YulExpressionStatement","expression":{"nodeType":"YulFunctionCall","src":"%d:10:0",
+				"functionName":{"nodeType":"YulIdentifier","name":%q},"arguments":[]}}]}}`, offset, name)
+	}
+	artifact := fmt.Sprintf(`{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+			{"nodeType":"FunctionDefinition","name":"emitRaw","body":{"nodeType":"Block","statements":[%s]}},
+			{"nodeType":"FunctionDefinition","name":"vetted",
+				"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore assembly-log"},
+				"body":{"nodeType":"Block","statements":[%s]}},
+			{"nodeType":"FunctionDefinition","name":"safe","body":{"nodeType":"Block","statements":[%s]}}
+		]}
+	]}}`, yulCall("log1", strings.Index(source, "log1")), yulCall("log0", strings.Index(source, "log0")),
+		yulCall("sload", strings.Index(source, "sload")))
+
+	t.Run("flags raw logs", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkAssemblyLogs(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.emitRaw emits a raw log1 at src/Test.sol:4 that is not described by the contract's ABI", findings[0].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"assembly-log": {"Test"}}})
+		findings, err := checkAssemblyLogs(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
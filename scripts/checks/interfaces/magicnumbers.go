@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// defaultMaxMagicNumber is the largest literal a function may use without naming it, large
+// enough for loop bounds, bit widths and shifts.
+const defaultMaxMagicNumber = 255
+
+// checkMagicNumbers reports numeric literals above magicNumbers.max used in the functions and
+// modifiers of source contracts, which are easier to review as named constants. Literals with a
+// unit such as `1 days`, array lengths and the values in magicNumbers.allow are left alone, and
+// a line can opt out with ignoreTag in a comment on it or on the line above. The finding is
+// informational unless --strict is set.
+func checkMagicNumbers(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("magic-number", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	limit := big.NewFloat(defaultMaxMagicNumber)
+	if config.MagicNumbers.Max > 0 {
+		limit = new(big.Float).SetUint64(config.MagicNumbers.Max)
+	}
+	var allowed []*big.Float
+	for _, value := range config.MagicNumbers.Allow {
+		parsed, ok := parseNumberLiteral(value)
+		if !ok {
+			return nil, fmt.Errorf("invalid magicNumbers.allow value %q", value)
+		}
+		allowed = append(allowed, parsed)
+	}
+
+	severity := common.SeverityInfo
+	if strict {
+		severity = common.SeverityWarning
+	}
+
+	var source []byte
+	var findings []common.Finding
+	var readErr error
+	walkAST(node, func(n astNode, parents []astNode) bool {
+		switch n.nodeType() {
+		case "VariableDeclaration":
+			mutability := getString(n, "mutability")
+			return n["constant"] != true && mutability != "constant" && mutability != "immutable"
+		case "ArrayTypeName":
+			return false
+		case "Literal":
+		default:
+			return true
+		}
+		if getString(n, "kind") != "number" || getString(n, "subdenomination") != "" || readErr != nil {
+			return false
+		}
+		fn := enclosingFunction(parents)
+		if fn == nil {
+			return false
+		}
+		value, ok := parseNumberLiteral(getString(n, "value"))
+		if !ok || value.Cmp(limit) <= 0 || slices.ContainsFunc(allowed, func(a *big.Float) bool { return a.Cmp(value) == 0 }) {
+			return false
+		}
+
+		location := t.sourcePath()
+		if offset, ok := srcOffset(n); ok {
+			if source == nil {
+				source, readErr = readSource(t.sourcePath())
+				if readErr != nil {
+					return false
+				}
+			}
+			if offset <= len(source) {
+				line := bytes.Count(source[:offset], []byte("\n")) + 1
+				if hasLineIgnore(sourceLine(source, line), "magic-number") || hasLineIgnore(sourceLine(source, line-1), "magic-number") {
+					return false
+				}
+				location = fmt.Sprintf("%s:%d", location, line)
+			}
+		}
+		findings = append(findings, newFinding("magic-number", severity, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s uses the literal %s at %s; consider a named constant",
+				t.name, functionLabel(fn), getString(n, "value"), location)))
+		return false
+	})
+	if readErr != nil {
+		return nil, readErr
+	}
+	return findings, nil
+}
+
+// parseNumberLiteral parses a Solidity number literal such as 1_000, 0xff or 1e18.
+func parseNumberLiteral(value string) (*big.Float, bool) {
+	parsed, ok := new(big.Float).SetPrec(512).SetString(strings.ReplaceAll(value, "_", ""))
+	return parsed, ok
+}
+
+// hasLineIgnore reports whether a source line carries an ignoreTag comment for check.
+func hasLineIgnore(line, check string) bool {
+	_, comment, ok := strings.Cut(line, "//")
+	if !ok {
+		return false
+	}
+	fields := strings.Fields(comment)
+	i := slices.Index(fields, ignoreTag)
+	return i >= 0 && i+1 < len(fields) && fields[i+1] == check
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckMagicNumbers(t *testing.T) {
+	source := `contract Test {
+    uint256 internal constant MAX = 1000000;
+    uint256[500] internal slots;
+
+    function fee(uint256 _amount) external pure returns (uint256) {
+        return _amount * 30000 / 1_000_000;
+    }
+
+    function wait() external view returns (uint256) {
+        // @custom:interfaces-ignore magic-number
+        return block.timestamp + 86400 + 7 days + 3;
+    }
+}
+`
+	setupSourceFixture(t, map[string]string{"src/Test.sol": source})
+
+	literal := func(value, extra string) string {
+		return fmt.Sprintf(`{"nodeType":"Literal","kind":"number","value":%q,"src":"%d:%d:0"%s}`,
+			value, strings.Index(source, value), len(value), extra)
+	}
+	artifact := fmt.Sprintf(`{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+			{"nodeType":"VariableDeclaration","name":"MAX","constant":true,"mutability":"constant","value":%s},
+			{"nodeType":"VariableDeclaration","name":"slots","mutability":"mutable",
+				"typeName":{"nodeType":"ArrayTypeName","length":%s}},
+			{"nodeType":"FunctionDefinition","name":"fee","body":{"nodeType":"Block","statements":[
+				{"nodeType":"Return","expression":{"nodeType":"BinaryOperation","leftExpression":%s,"rightExpression":%s}}
+			]}},
+			{"nodeType":"FunctionDefinition","name":"wait","body":{"nodeType":"Block","statements":[
+				{"nodeType":"Return","expression":{"nodeType":"BinaryOperation","leftExpression":%s,"rightExpression":
+					{"nodeType":"BinaryOperation","leftExpression":%s,"rightExpression":%s}}}
+			]}}
+		]}
+	]}}`, literal("1000000", ""), literal("500", ""), literal("30000", ""), literal("1_000_000", ""),
+		literal("86400", ""), literal("7", `,"subdenomination":"days"`), literal("3", ""))
+
+	t.Run("flags large literals", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkMagicNumbers(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityInfo, findings[0].Severity)
+		require.Equal(t, "Test.fee uses the literal 30000 at src/Test.sol:6; consider a named constant", findings[0].Message)
+		require.Equal(t, "Test.fee uses the literal 1_000_000 at src/Test.sol:6; consider a named constant", findings[1].Message)
+	})
+
+	t.Run("strict escalates", func(t *testing.T) {
+		setConfig(t, &Config{})
+		prev := strict
+		strict = true
+		t.Cleanup(func() { strict = prev })
+
+		findings, err := checkMagicNumbers(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.NotEmpty(t, findings)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	})
+
+	t.Run("allowlist and max", func(t *testing.T) {
+		setConfig(t, &Config{MagicNumbers: MagicNumbersConfig{Max: 50000, Allow: []string{"1e6"}}})
+		findings, err := checkMagicNumbers(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("invalid allowlist value", func(t *testing.T) {
+		setConfig(t, &Config{MagicNumbers: MagicNumbersConfig{Allow: []string{"lots"}}})
+		_, err := checkMagicNumbers(delegatecallTarget(t, artifact))
+		require.ErrorContains(t, err, `invalid magicNumbers.allow value "lots"`)
+	})
+}
+
+func TestParseNumberLiteral(t *testing.T) {
+	for literal, want := range map[string]int64{"1_000": 1000, "0xff": 255, "1e18": 1e18, "2.5e3": 2500} {
+		value, ok := parseNumberLiteral(literal)
+		require.True(t, ok, literal)
+		got, _ := value.Int64()
+		require.Equal(t, want, got, literal)
+	}
+}
@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"log"
+	"maps"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/base/contracts/scripts/checks/common"
 )
@@ -55,119 +63,1358 @@ type ArtifactAST struct {
 type Artifact struct {
 	AST ArtifactAST     `json:"ast"`
 	ABI json.RawMessage `json:"abi"`
+	// GasEstimates holds solc's gasEstimates output when forge was asked to emit it.
+	GasEstimates *GasEstimates `json:"gasEstimates,omitempty"`
+	// Metadata holds the compiler settings recorded in the artifact's metadata.
+	Metadata *ArtifactMetadata `json:"metadata,omitempty"`
+	// StorageLayout holds solc's storageLayout output when forge was asked to emit it.
+	StorageLayout *StorageLayout `json:"storageLayout,omitempty"`
+
+	// RawAST holds the undecoded "ast" section for checks that walk the full tree.
+	RawAST  json.RawMessage `json:"-"`
+	astTree astNode
+}
+
+func (a *Artifact) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		AST           json.RawMessage   `json:"ast"`
+		ABI           json.RawMessage   `json:"abi"`
+		GasEstimates  *GasEstimates     `json:"gasEstimates"`
+		Metadata      *ArtifactMetadata `json:"metadata"`
+		StorageLayout *StorageLayout    `json:"storageLayout"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	a.StorageLayout = raw.StorageLayout
+	return a.setSections(raw.AST, raw.ABI, raw.GasEstimates, raw.Metadata)
+}
+
+// setSections fills the artifact from its sections, decoding the ast into AST.
+func (a *Artifact) setSections(ast, abi json.RawMessage, gasEstimates *GasEstimates, metadata *ArtifactMetadata) error {
+	a.ABI = abi
+	a.GasEstimates = gasEstimates
+	a.Metadata = metadata
+	a.RawAST = ast
+	if len(ast) > 0 && string(ast) != "null" {
+		if err := json.Unmarshal(ast, &a.AST); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTarget is the artifact currently being inspected by the artifact checks.
+type checkTarget struct {
+	path       string
+	name       string
+	artifact   *Artifact
+	definition *ContractDefinition
+
+	abi []map[string]interface{}
+}
+
+// abiItems returns the artifact's parsed ABI, decoding it on first use.
+func (t *checkTarget) abiItems() ([]map[string]interface{}, error) {
+	if t.abi == nil && len(t.artifact.ABI) > 0 {
+		if err := json.Unmarshal(t.artifact.ABI, &t.abi); err != nil {
+			return nil, fmt.Errorf("failed to parse ABI: %w", err)
+		}
+	}
+	return t.abi, nil
+}
+
+// sourcePath returns the path of the Solidity file the artifact was compiled from.
+func (t *checkTarget) sourcePath() string {
+	return t.artifact.AST.AbsolutePath
+}
+
+// isSource reports whether the artifact was compiled from a file under src/.
+func (t *checkTarget) isSource() bool {
+	return strings.HasPrefix(t.sourcePath(), "src/")
+}
+
+// Rules of the interfaces check, recorded on its findings so that summaries can count missing
+// interfaces and ABI mismatches separately.
+const (
+	ruleMissingInterface = "missing-interface"
+	ruleABIMismatch      = "abi-mismatch"
+)
+
+// artifactCheck is a check that is run against every artifact.
+type artifactCheck struct {
+	name        string
+	severity    common.Severity
+	description string
+	run         func(t *checkTarget) ([]common.Finding, error)
+	// readABI, if set, runs on every artifact before run, including the artifacts without an AST
+	// or contract definition that run never sees, for checks that collect what the ABI declares.
+	readABI func(t *checkTarget) error
+	// finish, if set, runs once every artifact has been processed and reports findings that
+	// depend on the whole artifact set.
+	finish func() []common.Finding
+}
+
+var artifactChecks = []artifactCheck{
+	{
+		name:        "interfaces",
+		severity:    common.SeverityError,
+		description: "Source contracts have interfaces named I<Name> that use pragma solidity ^0.8.0 and match their ABI",
+		run:         checkInterface,
+	},
+	{
+		name:        "delegatecall",
+		severity:    common.SeverityError,
+		description: "Source contracts only delegatecall when allowlisted",
+		run:         checkDelegatecall,
+	},
+	{
+		name:        "encode-signature",
+		severity:    common.SeverityWarning,
+		description: "Signatures passed to abi.encodeWithSignature/encodeWithSelector match a known function",
+		run:         encodedSignatures.run,
+		readABI:     encodedSignatures.readABI,
+		finish:      encodedSignatures.finish,
+	},
+	{
+		name:        "function-event-name",
+		severity:    common.SeverityWarning,
+		description: "A contract does not declare a function and an event with the same name",
+		run:         checkFunctionEventNames,
+	},
+	{
+		name:        "override-signature",
+		severity:    common.SeverityError,
+		description: "Overrides keep the parameter and return types of their base function",
+		run:         overriddenFunctions.run,
+		finish:      overriddenFunctions.finish,
+	},
+	{
+		name:        "interface-inheritance",
+		severity:    common.SeverityWarning,
+		description: "Interfaces inherit the interfaces of their contract's bases",
+		run:         inheritance.run,
+		finish:      inheritance.finish,
+	},
+	{
+		name:        "struct-return",
+		severity:    common.SeverityWarning,
+		description: "Public functions do not return large structs by value",
+		run:         checkStructReturns,
+	},
+	{
+		name:        "external-function-count",
+		severity:    common.SeverityWarning,
+		description: "Contracts stay under the external function limit",
+		run:         checkExternalFunctionCount,
+	},
+	{
+		name:        "raw-bytes-param",
+		severity:    common.SeverityInfo,
+		description: "Public functions do not take generically named bytes parameters",
+		run:         checkRawBytesParams,
+	},
+	{
+		name:        "assembly-mutability",
+		severity:    common.SeverityWarning,
+		description: "View and pure functions do not touch storage from inline assembly",
+		run:         checkAssemblyMutability,
+	},
+	{
+		name:        "custom-error",
+		severity:    common.SeverityWarning,
+		description: "Reverts use custom errors rather than string reasons",
+		run:         checkStringReverts,
+	},
+	{
+		name:        "selector-clash",
+		severity:    common.SeverityError,
+		description: "Implementation selectors do not clash with reserved proxy functions",
+		run:         checkSelectorClashes,
+	},
+	{
+		name:        "interface-concrete-type",
+		severity:    common.SeverityWarning,
+		description: "Interfaces reference other interfaces rather than concrete contracts",
+		run:         checkConcreteTypes,
+	},
+	{
+		name:        "event-indexed-param",
+		severity:    common.SeverityWarning,
+		description: "Events declare the indexed parameters required by the configured rules",
+		run:         checkEventRules,
+	},
+	{
+		name:        "parameter-count",
+		severity:    common.SeverityWarning,
+		description: "Functions stay under the parameter limit",
+		run:         checkParameterCount,
+	},
+	{
+		name:        "access-control",
+		severity:    common.SeverityWarning,
+		description: "Privileged-looking functions have at least one modifier",
+		run:         checkAccessControl,
+	},
+	{
+		name:        "receives-eth",
+		severity:    common.SeverityError,
+		description: "Interfaces of ETH-receiving contracts carry the receives-eth marker",
+		run:         checkReceivesETHMarker,
+	},
+	{
+		name:        "forwards-calls",
+		severity:    common.SeverityError,
+		description: "Interfaces of contracts with a forwarding fallback carry the forwards-calls marker",
+		run:         checkForwardsCallsMarker,
+	},
+	{
+		name:        "address-registry",
+		severity:    common.SeverityError,
+		description: "Address constants in the configured files match the canonical address registry",
+		run:         checkAddressConstants,
+	},
+	{
+		name:        "assembly-comment",
+		severity:    common.SeverityWarning,
+		description: "Inline assembly blocks are directly preceded by an explanatory comment",
+		run:         checkAssemblyComments,
+	},
+	{
+		name:        "proxy-admin-function",
+		severity:    common.SeverityError,
+		description: "Only allowlisted proxies declare proxy admin functions such as admin() and upgradeTo()",
+		run:         checkProxyAdminFunctions,
+	},
+	{
+		name:        "assembly-log",
+		severity:    common.SeverityWarning,
+		description: "Inline assembly does not emit raw log0-log4 logs that bypass the ABI",
+		run:         checkAssemblyLogs,
+	},
+	{
+		name:        "gas-budget",
+		severity:    common.SeverityError,
+		description: "External function gas estimates stay within the committed budget",
+		run:         checkGasBudget,
+	},
+	{
+		name:        "return-size",
+		severity:    common.SeverityWarning,
+		description: "Function outputs stay under the flattened return field limit",
+		run:         checkReturnSize,
+	},
+	{
+		name:        "required-modifier",
+		severity:    common.SeverityError,
+		description: "Functions apply the modifiers required by the configured modifier rules",
+		run:         checkModifierRules,
+	},
+	{
+		name:        "struct-definition",
+		severity:    common.SeverityError,
+		description: "Structs declared in both an interface and its contract have the same fields",
+		run:         checkStructDefinitions,
+	},
+	{
+		name:        "inheritdoc",
+		severity:    common.SeverityWarning,
+		description: "@inheritdoc tags reference a contract that declares the documented member",
+		run:         checkInheritdoc,
+	},
+	{
+		name:        "unreachable-contract",
+		severity:    common.SeverityWarning,
+		description: "Concrete source contracts expose at least one external entry point or are inherited",
+		run:         unreachable.run,
+		finish:      unreachable.finish,
+	},
+	{
+		name:        "privileged-todo",
+		severity:    common.SeverityWarning,
+		description: "Privileged functions do not contain TODO, FIXME or XXX comments",
+		run:         checkPrivilegedTodos,
+	},
+	{
+		name:        "interface-members",
+		severity:    common.SeverityError,
+		description: "Files under interfaces/ declare no constructors or modifiers",
+		run:         checkInterfaceMembers,
+	},
+	{
+		name:        "upgradeable-storage",
+		severity:    common.SeverityError,
+		description: "Configured upgradeable contracts end with a uint256[N] __gap or use ERC-7201 namespaced storage",
+		run:         checkUpgradeableStorage,
+	},
+	{
+		name:        "setter-event",
+		severity:    common.SeverityWarning,
+		description: "State-changing functions matching an event naming rule have a correspondingly named event",
+		run:         checkSetterEvents,
+	},
+	{
+		name:        "generated-interface",
+		severity:    common.SeverityError,
+		description: "Interfaces marked as generated match what would be generated from their contract",
+		run:         checkGeneratedInterface,
+	},
+	{
+		name:        "event-topic-collision",
+		severity:    common.SeverityError,
+		description: "Events that share a topic across the artifacts index the same parameters",
+		run:         eventTopics.run,
+		finish:      eventTopics.finish,
+	},
+	{
+		name:        "optimizer-settings",
+		severity:    common.SeverityWarning,
+		description: "Interfaces are compiled with the same optimizer settings as their contracts",
+		run:         checkOptimizerSettings,
+	},
+	{
+		name:        "unnamed-param",
+		severity:    common.SeverityWarning,
+		description: "Function parameters in contracts and interfaces have names",
+		run:         checkUnnamedParams,
+	},
+	{
+		name:        "bool-return",
+		severity:    common.SeverityInfo,
+		description: "Interface functions do not return an unnamed bool success flag",
+		run:         checkBoolReturns,
+	},
+	{
+		name:        "standalone-interface",
+		severity:    common.SeverityError,
+		description: "Interfaces transitively import only other interfaces and types-only files",
+		run:         checkStandaloneInterface,
+	},
+	{
+		name:        "magic-number",
+		severity:    common.SeverityInfo,
+		description: "Functions name large numeric literals as constants instead of using them inline",
+		run:         checkMagicNumbers,
+	},
+	{
+		name:        "required-event",
+		severity:    common.SeverityError,
+		description: "Contracts matching a required events rule declare each of the rule's event signatures",
+		run:         checkRequiredEvents,
+	},
+	{
+		name:        "using-for-collision",
+		severity:    common.SeverityWarning,
+		description: "Contract functions do not share a name with functions attached by using for",
+		run:         checkUsingForCollisions,
+	},
+	{
+		name:        "error-definition",
+		severity:    common.SeverityError,
+		description: "Interfaces declare every error their contract declares, including file-level ones",
+		run:         checkErrorDefinitions,
+	},
+	{
+		name:        "integer-width",
+		severity:    common.SeverityInfo,
+		description: "Parameters with the same name use the same integer type across a contract's functions",
+		run:         checkIntegerWidths,
+	},
+	{
+		name:        "forbidden-opcode",
+		severity:    common.SeverityError,
+		description: "Contracts do not use the opcodes forbidden by the forbiddenOpcodes config",
+		run:         checkForbiddenOpcodes,
+	},
+	{
+		name:        "function-order",
+		severity:    common.SeverityInfo,
+		description: "Interface functions are declared in the order chosen by the functionOrder config",
+		run:         checkFunctionOrder,
+	},
+	{
+		name:        "predeploy-address",
+		severity:    common.SeverityError,
+		description: "Predeploy addresses outside the Predeploys library match the canonical addresses",
+		run:         checkPredeployAddresses,
+	},
+	{
+		name:        "deprecated-function",
+		severity:    common.SeverityWarning,
+		description: "Functions tagged @custom:deprecated are not referenced and are tagged in their interface",
+		run:         checkDeprecatedFunctions,
+	},
+	{
+		name:        "storage-layout",
+		severity:    common.SeverityError,
+		description: "State variables in the storage layout have unique labels and neither overlap nor skip slots",
+		run:         checkStorageLayout,
+	},
+	{
+		name:        "emit-argument-order",
+		severity:    common.SeverityInfo,
+		description: "Emitted event arguments named like event parameters are passed in declaration order",
+		run:         checkEmitArgumentOrder,
+	},
+	{
+		name:        "interface-calldata",
+		severity:    common.SeverityWarning,
+		description: "External interface functions take reference-type parameters as calldata",
+		run:         checkInterfaceCalldata,
+	},
+	{
+		name:        "approve-race",
+		severity:    common.SeverityWarning,
+		description: "Contracts with approve also offer increaseAllowance/decreaseAllowance or require a reset to zero",
+		run:         checkApproveRace,
+	},
+	{
+		name:        "stale-exclude",
+		severity:    common.SeverityWarning,
+		description: "Every entry of the hardcoded exclude lists names a contract or interface it can apply to",
+		run:         skipArtifact,
+		finish:      finishStaleExcludes,
+	},
+	{
+		name:        "duplicate-exclude",
+		severity:    common.SeverityWarning,
+		description: "The hardcoded exclude lists name each contract once",
+		run:         skipArtifact,
+		finish:      finishDuplicateExcludes,
+	},
+	{
+		name:        "payable-coverage",
+		severity:    common.SeverityWarning,
+		description: "Payable functions are reached by tests according to the configured lcov report",
+		run:         checkPayableCoverage,
+	},
 }
 
 var (
 	cwd          string
 	artifactsDir string
+	config       = &Config{}
+	verbose      bool
+	strict       bool
+	showProgress bool
+	// shard restricts a run to a slice of the contracts; the zero value checks all of them.
+	shard common.Shard
 )
 
+// progressEvery is how many artifacts pass between progress lines when stderr is not a terminal.
+const progressEvery = 500
+
+// stringList is a flag.Value that collects every occurrence of a repeatable flag.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
 func main() {
-	var err error
+	configPath := flag.String("config", "", "path to the check configuration file (.json, or .yaml/.yml)")
+	checksConfigPath := flag.String("checks-config", "", "path to a checks.json or checks.yaml with one section per registered check, used instead of --config")
+	flag.BoolVar(&selectorsOnly, "selectors-only", false, "compare only the function selectors of interfaces and contracts; other ABI differences are reported as info")
+	compareTypes := flag.String("compare-types", "", "comma-separated ABI item types to compare (function,event,error,...); defaults to all")
+	changedOnly := flag.Bool("changed-only", false, "only check files related to the .sol paths read from stdin; cross-artifact checks are skipped")
+	since := flag.String("since", "", "only check files related to .sol files changed since this git ref; cross-artifact checks are skipped")
+	staged := flag.Bool("staged", false, "pre-commit mode: only check files related to the staged .sol files, warn about stale artifacts and report only warnings and errors")
+	contractsListPath := flag.String("contracts-list", "", "path to a JSON list of {contract, source, interface} entries to check for interfaces instead of scanning the source roots")
+	bundlePath := flag.String("artifacts-bundle", "", "read the artifacts from this .zip, .tar, .tar.gz or .tgz archive instead of forge-artifacts/")
+	debugArtifactPath := flag.String("artifact", "", "run the artifact checks on this artifact alone with verbose logging and print the normalized ABIs of it and its interface or contract counterpart as JSON")
+	compareBranches := flag.String("compare-branches", "", "print the interface ABI changes between two pre-built artifact directories, given as <base dir>,<head dir>, as JSON instead of running the checks")
+	requireABIApproval := flag.Bool("require-abi-approval", false, "with --compare-branches, fail when any interface changed unless --abi-change-approved is set")
+	abiChangeApproved := flag.Bool("abi-change-approved", false, "with --require-abi-approval, accept the interface changes, e.g. when the PR carries the @checks:abi-change-approved label")
+	coverageMode := flag.Bool("coverage", false, "print interface coverage metrics as JSON instead of running the checks")
+	format := flag.String("format", "text", "output format: text, junit to also write the findings to stdout as JUnit XML, summary-json to write a compact JSON summary to --out, or to stdout without it, or dot for a graphviz interface coverage map instead of running the checks")
+	diffContextValue := flag.String("diff-context", diffContextSignature, "how an interface item whose parameters changed is shown: full for a REMOVE and an ADD line, signature for the interface signature and the changed fields, or minimal for the item name and the changed fields")
+	colorMode := flag.String("color", string(common.ColorAuto), "color the grouped findings and text diff output on stderr: auto to color terminals unless NO_COLOR is set, always, or never; JSON, JUnit and DOT output are never colored")
+	noColor := flag.Bool("no-color", false, "shorthand for --color=never")
+	flag.BoolVar(&includeTests, "include-tests", false, "require interfaces for test contracts in .t.sol files and test/ or tests/ directories too")
+	flat := flag.Bool("flat", false, "print findings as one flat list instead of grouped by contract")
+	flag.BoolVar(&strict, "strict", false, "treat warnings as errors and escalate advisory findings to warnings")
+	release := flag.Bool("release", false, "release profile: --strict on a full scan with every check and no baseline; flags that would narrow the run are refused")
+	failFast := flag.Bool("fail-fast", false, "stop at the first warning or error and exit 1, skipping the remaining artifacts and checks")
+	warningExit := flag.Bool("warning-exit", false, "exit with code 3 when the run reports warnings but no errors")
+	selectChecks := flag.String("select", "", "comma-separated registered checks or rules to run; defaults to all")
+	deselectChecks := flag.String("deselect", "", "comma-separated registered checks or rules to skip")
+	flag.BoolVar(&showProgress, "progress", false, "print progress to stderr even when it is not a terminal")
+	timing := flag.Bool("timing", false, "print per-phase timings to stderr")
+	shardValue := flag.String("shard", "", "only check the contracts in shard i/n of a run split across n runners; cross-artifact checks are skipped")
+	outPath := flag.String("out", "", "also write the findings to this path as a JSON report")
+	manifestPath := flag.String("emit-manifest", "", "also write a JSON catalog of every source contract's interface, interface status and function selectors to this path")
+	merge := flag.Bool("merge", false, "merge the JSON reports given as arguments, from every shard of a run, and exit like that run would")
+	baselinePath := flag.String("baseline", "", "path to a baseline of accepted findings that do not fail the run")
+	baselineUpdate := flag.Bool("baseline-update", false, "rewrite the --baseline file to accept the current findings")
+	var explainMode explainFlag
+	flag.Var(&explainMode, "explain", "print remediation guidance after the findings, or with =<code> print the guidance for that finding code and exit")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration, with where each setting and exclusion comes from, as JSON and exit")
+	printConfigSchema := flag.Bool("config-schema", false, "print the JSON Schema of the check configuration and exit")
+	printChecksConfigSchema := flag.Bool("checks-config-schema", false, "print the JSON Schema of a --checks-config file, with each check's section, and exit")
+	listChecks := flag.Bool("list-checks", false, "list the registered checks and exit")
+	helpCheck := flag.String("help-check", "", "print detailed usage for the named check and exit")
+	flag.BoolVar(&verbose, "verbose", false, "log additional detail about how contracts were checked")
+	flag.Var((*stringList)(&common.ExcludedDirs), "exclude-dir", "directory whose files no scan reads, in addition to lib, node_modules and src/vendor (repeatable)")
+	flag.Var((*stringList)(&interfaceSearchPaths), "interface-search-path", "additional root to search for interfaces, laid out like the expected interface root (repeatable)")
+	flag.Parse()
+
+	switch {
+	case *printConfigSchema:
+		if err := printJSON(configSchema()); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	case *printChecksConfigSchema:
+		if err := printJSON(common.ConfigSchema(common.RegisteredChecks())); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	case explainMode.code != "":
+		text, err := explain(explainMode.code)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(text)
+		return
+	case *listChecks:
+		if err := common.WriteCheckList(os.Stdout, common.RegisteredChecks()); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	case *helpCheck != "":
+		if err := common.WriteCheckHelp(os.Stdout, common.RegisteredChecks(), *helpCheck, flag.CommandLine); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	color, err := common.ParseColorMode(*colorMode)
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if *noColor {
+		color = common.ColorNever
+	}
+
+	if *release {
+		if err := releaseProfileError(flag.CommandLine); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		strict = true
+	}
+
+	if *merge {
+		findings, err := mergeReports(flag.Args())
+		if err == nil {
+			err = writeReportOutput(*outPath, *format, common.Shard{}, findings)
+		}
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		reportFindings(findings, *flat, common.UseColor(color, os.Stderr))
+		if *format == "summary-json" && *outPath == "" {
+			if err := common.WriteSummary(os.Stdout, findings); err != nil {
+				fmt.Printf("error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		os.Exit(common.ExitCode(findings, *warningExit))
+	}
+
+	if *shardValue != "" {
+		shard, err = common.ParseShard(*shardValue)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	cwd, err = os.Getwd()
 	if err != nil {
 		fmt.Printf("error: %v\n", err)
 		os.Exit(1)
 	}
 	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	if *bundlePath != "" {
+		if artifactBundle, err = common.OpenBundle(*bundlePath); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	if _, err := common.ProcessFilesGlob(
-		[]string{"forge-artifacts/**/*.json"},
-		[]string{},
-		processFile,
-	); err != nil {
+	diffContext, err = parseDiffContext(*diffContextValue)
+	if err != nil {
 		fmt.Printf("error: %v\n", err)
 		os.Exit(1)
 	}
+
+	comparedTypes, err = parseCompareTypes(*compareTypes)
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *checksConfigPath != "" {
+		err = configureChecks(*checksConfigPath, *configPath)
+	} else {
+		config, err = loadConfig(*configPath)
+	}
+	if err != nil {
+		fmt.Printf("error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *debugArtifactPath != "" {
+		verbose = true
+		debug, err := debugArtifact(*debugArtifactPath)
+		if err == nil {
+			err = printJSON(debug)
+		}
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *contractsListPath != "" {
+		contractsList, err = readContractsList(*contractsListPath)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *printConfig {
+		source := resolveConfigPath(*configPath)
+		if *checksConfigPath != "" {
+			source = *checksConfigPath + "#interfaces"
+		}
+		effective, err := resolveEffectiveConfig(source)
+		if err == nil {
+			err = printJSON(effective)
+		}
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	switch {
+	case *changedOnly:
+		changedFiles, err = readChangedFiles(os.Stdin)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	case *staged:
+		changedFiles, err = stagedFiles(cwd)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(changedFiles) == 0 {
+			return
+		}
+	case *since != "":
+		changedFiles, err = changedFilesSince(cwd, *since)
+		if err != nil {
+			log.Printf("could not determine files changed since %s, running a full check: %v", *since, err)
+			changedFiles = nil
+		}
+	}
+
+	if *format != "text" && *format != "junit" && *format != "summary-json" && *format != "dot" {
+		fmt.Printf("error: unknown format %q\n", *format)
+		os.Exit(1)
+	}
+
+	if *format == "dot" {
+		contracts, err := scanSourceContracts(sourceRoots())
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		bases, err := sourceContractBases(contracts)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeDOT(os.Stdout, contracts, bases); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *compareBranches != "" {
+		baseDir, headDir, err := parseCompareBranches(*compareBranches)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		changes, err := printInterfaceSurfaceDiff(baseDir, headDir)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeContractFunctionRemovals(os.Stderr, baseDir, headDir); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		if *requireABIApproval && !gateInterfaceSurfaceChanges(os.Stderr, changes, *abiChangeApproved, common.UseColor(color, os.Stderr)) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *coverageMode {
+		contracts, err := scanSourceContracts(sourceRoots())
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := printJSON(computeCoverage(contracts)); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	checks, err := common.SelectChecks(common.RegisteredChecks(), splitList(*selectChecks), splitList(*deselectChecks))
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	started := time.Now()
+	findings, err := common.RunChecks(ctx, checks, common.Options{Dir: cwd, Strict: strict, FailFast: *failFast})
+	elapsed := time.Since(started)
+	if *timing {
+		phaseTimer.print(os.Stderr)
+	}
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *staged {
+		stale, err := staleArtifactFindings(changedFiles)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		findings = append(dropInfo(findings), stale...)
+	}
+
+	if strict {
+		promoteWarnings(findings)
+	}
+	sortFindings(findings)
+
+	if *baselineUpdate {
+		// A restricted run would drop the entries of everything it did not check.
+		if *baselinePath == "" || changedFiles != nil || shard.Count > 1 || *selectChecks != "" || *deselectChecks != "" {
+			fmt.Println("error: --baseline-update needs --baseline and a full run")
+			os.Exit(1)
+		}
+		baseline := common.NewBaseline(findings)
+		if err := common.WriteBaseline(*baselinePath, baseline); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		log.Printf("wrote %d baseline entries to %s", len(baseline.Findings), *baselinePath)
+	}
+
+	if *baselinePath != "" {
+		findings, err = applyBaseline(*baselinePath, findings)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := writeReportOutput(*outPath, *format, shard, findings); err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if *manifestPath != "" {
+		if err := writeManifest(*manifestPath); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	reportFindings(findings, *flat, common.UseColor(color, os.Stderr))
+	if *format == "junit" {
+		if err := common.WriteJUnit(os.Stdout, checkNames(checks), findings, elapsed); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *format == "summary-json" && *outPath == "" {
+		if err := common.WriteSummary(os.Stdout, findings); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if explainMode.enabled {
+		if err := writeExplanations(os.Stdout, findings); err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	code := common.ExitCode(findings, *warningExit)
+	if *failFast && code == common.ExitClean && common.HasProblems(findings) {
+		code = common.ExitFailure
+	}
+	if code != common.ExitClean {
+		os.Exit(code)
+	}
+}
+
+// writeReportOutput writes the findings to the --out path, if any: as a summary with
+// --format=summary-json, and as the full JSON report otherwise.
+func writeReportOutput(path, format string, shard common.Shard, findings []common.Finding) error {
+	switch {
+	case path == "":
+		return nil
+	case format == "summary-json":
+		return common.WriteSummaryFile(path, findings)
+	default:
+		return common.WriteReport(path, shard, findings)
+	}
+}
+
+// checkNames lists the names findings of these checks are reported under: the rules of checks
+// that describe them, and the check's own name otherwise.
+func checkNames(checks []common.Check) []string {
+	var names []string
+	for _, check := range checks {
+		rules := common.DescribeCheck(check).Rules
+		if len(rules) == 0 {
+			names = append(names, check.Name())
+		}
+		for _, rule := range rules {
+			names = append(names, rule.Name)
+		}
+	}
+	return names
+}
+
+// mergeReports reads and merges the shard reports at paths.
+func mergeReports(paths []string) ([]common.Finding, error) {
+	reports := make([]*common.Report, 0, len(paths))
+	for _, path := range paths {
+		report, err := common.ReadReport(path)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	findings, err := common.MergeReports(reports)
+	if err != nil {
+		return nil, err
+	}
+	sortFindings(findings)
+	return findings, nil
+}
+
+// phaseTimer records the phases of the interfaces check for --timing.
+var phaseTimer = &runTimer{}
+
+func init() {
+	common.Register(&interfacesCheck{timer: phaseTimer})
+}
+
+// interfacesCheck runs the artifact checks and source scans in this package as one registered
+// check.
+type interfacesCheck struct {
+	timer *runTimer
+}
+
+func (c *interfacesCheck) Name() string {
+	return "interfaces"
+}
+
+// ruleEnabled, when set by SelectRules, restricts a run to the rules it reports true for.
+var ruleEnabled func(rule string) bool
+
+// ruleSelected reports whether the current run checks rule.
+func ruleSelected(rule string) bool {
+	return ruleEnabled == nil || ruleEnabled(rule)
+}
+
+// SelectRules restricts the following runs to the enabled rules, so that --select=<rule> only
+// does the work of that rule.
+func (c *interfacesCheck) SelectRules(enabled func(rule string) bool) {
+	ruleEnabled = enabled
+}
+
+func (c *interfacesCheck) Info() common.CheckInfo {
+	rules := make([]common.Rule, 0, len(artifactChecks)+1)
+	for _, check := range artifactChecks {
+		rules = append(rules, common.Rule{Name: check.name, Severity: check.severity, Description: check.description})
+	}
+	rules = append(rules, common.Rule{
+		Name:        "duplicate-contract-name",
+		Severity:    common.SeverityError,
+		Description: "Contract names are unique across src",
+	})
+	return common.CheckInfo{
+		Description: "Interface, ABI and AST design checks over forge artifacts and sources",
+		Severity:    common.SeverityError,
+		Flags:       []string{"config", "checks-config", "compare-types", "diff-context", "selectors-only", "changed-only", "since", "staged", "shard", "interface-search-path", "verbose"},
+		Rules:       rules,
+	}
+}
+
+func (c *interfacesCheck) Run(ctx context.Context, opts common.Options) ([]common.Finding, error) {
+	return runChecks(ctx, c.timer, opts.FailFast)
+}
+
+// runChecks runs the artifact checks followed by the source scans and returns every finding.
+// With failFast, the first artifact with a warning or error cancels the artifacts not yet
+// started, and the findings so far are returned without running the later phases.
+func runChecks(ctx context.Context, timer *runTimer, failFast bool) ([]common.Finding, error) {
+	endPhase := timer.phase("discover artifacts")
+	files, err := findArtifacts()
+	endPhase()
+	if err != nil {
+		return nil, err
+	}
+
+	endPhase = timer.phase("compare interfaces")
+	var progress *common.Progress
+	if tty := common.IsTerminal(os.Stderr); tty || showProgress {
+		progress = common.NewProgress(os.Stderr, "artifacts", len(files), tty, progressEvery)
+	}
+	artifactsCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	processor := processFile
+	if failFast {
+		processor = func(path string) ([]common.Finding, []error) {
+			findings, errs := processFile(path)
+			if common.HasProblems(findings) {
+				cancel()
+			}
+			return findings, errs
+		}
+	}
+	results, err := common.ProcessFilesContext(artifactsCtx, files, common.TrackProgress(progress, timer.wrap(processor)))
+	progress.Finish()
+	endPhase()
+	if err != nil && (ctx.Err() != nil || !errors.Is(err, context.Canceled)) {
+		return nil, err
+	}
+	findings := collectFindings(results)
+	if failFast && common.HasProblems(findings) {
+		sortFindings(findings)
+		return findings, nil
+	}
+
+	endPhase = timer.phase("cross-artifact checks")
+	for _, check := range artifactChecks {
+		// Cross-artifact checks need the full artifact set, which an incremental run does not read.
+		// A sharded run reads it on the first shard only; see processFile.
+		if check.finish != nil && changedFiles == nil && shard.Index <= 1 && ruleSelected(check.name) {
+			findings = append(findings, check.finish()...)
+		}
+	}
+	endPhase()
+
+	if ruleSelected("interfaces") {
+		endPhase = timer.phase("scan missing interfaces")
+		var missing []common.Finding
+		if contractsList != nil {
+			missing, err = verifyListedContractsHaveInterfaces(contractsList)
+		} else {
+			missing, err = verifyAllContractsHaveInterfaces(sourceRoots())
+		}
+		endPhase()
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, missing...)
+	}
+
+	// The duplicate name scan is cheap and covers every contract, so only the first shard runs it.
+	if shard.Index <= 1 && ruleSelected("duplicate-contract-name") {
+		endPhase = timer.phase("scan duplicate names")
+		duplicates, err := checkDuplicateContractNames("src")
+		endPhase()
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, duplicates...)
+	}
+
+	sortFindings(findings)
+	return findings, nil
 }
 
-func processFile(artifactPath string) (*common.Void, []error) {
+func processFile(artifactPath string) ([]common.Finding, []error) {
+	if !isRelevantArtifactPath(artifactPath) {
+		return nil, nil
+	}
 	contractName := contractNameFromArtifactPath(artifactPath)
-	if slices.Contains(excludeContracts, contractName) {
+	// The first shard also reads the artifacts of the other shards, for the cross-artifact checks
+	// only, so that a merged sharded run reports what an unsharded run would. Their other findings
+	// and errors are left to the shard they belong to.
+	inShard := shard.Contains(contractName)
+	if !inShard && shard.Index != 1 {
 		return nil, nil
 	}
 
 	artifact, err := readArtifact(artifactPath)
 	if err != nil {
+		if !inShard {
+			return nil, nil
+		}
 		return nil, []error{fmt.Errorf("failed to read artifact: %w", err)}
 	}
 
-	contractDef := getContractDefinition(artifact, contractName)
-	if contractDef == nil {
-		return nil, nil
+	target := &checkTarget{
+		path:     artifactPath,
+		name:     contractName,
+		artifact: artifact,
 	}
 
-	if contractDef.ContractKind != "interface" {
-		if contractDef.ContractKind != "contract" {
-			return nil, nil
+	for _, check := range artifactChecks {
+		if check.readABI == nil || !ruleSelected(check.name) {
+			continue
+		}
+		if err := check.readABI(target); err != nil && inShard {
+			return nil, []error{err}
 		}
+	}
 
-		absPath := artifact.AST.AbsolutePath
-		if !strings.HasPrefix(absPath, "src/") {
+	if len(artifact.AST.Nodes) == 0 {
+		if !inShard || !ruleSelected("interfaces") {
 			return nil, nil
 		}
-
-		for _, folder := range []string{"src/libraries", "src/vendor"} {
-			if strings.HasPrefix(absPath, folder) {
-				return nil, nil
-			}
+		findings, err := checkInterfaceWithoutAST(target)
+		if err != nil {
+			return nil, []error{err}
 		}
+		return findings, nil
+	}
 
-		if slices.Contains(excludeSourceContracts, contractName) {
-			return nil, nil
-		}
+	target.definition = getContractDefinition(artifact, contractName)
+	if target.definition == nil {
+		return nil, nil
+	}
 
-		dirPath := filepath.Dir(strings.TrimPrefix(absPath, "src/"))
-		interfacePath := filepath.Join(cwd, "interfaces", dirPath, "I"+contractName+".sol")
-		if _, err := os.Stat(interfacePath); errors.Is(err, os.ErrNotExist) {
-			return nil, []error{fmt.Errorf("%s: contract in %s has no corresponding interface at %s",
-				contractName, absPath, interfacePath)}
+	var findings []common.Finding
+	var errs []error
+	for _, check := range artifactChecks {
+		if (!inShard && check.finish == nil) || !ruleSelected(check.name) {
+			continue
+		}
+		checkFindings, err := check.run(target)
+		if !inShard {
+			continue
 		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		findings = append(findings, checkFindings...)
+	}
+	return findings, errs
+}
+
+// checkInterface verifies that interfaces match their corresponding contracts. Source
+// contracts without interfaces are found by verifyAllContractsHaveInterfaces.
+func checkInterface(t *checkTarget) ([]common.Finding, error) {
+	contractName := t.name
+	if slices.Contains(excludeContracts, contractName) {
+		return nil, nil
+	}
+
+	fail := func(format string, args ...any) ([]common.Finding, error) {
+		return []common.Finding{newFinding("interfaces", common.SeverityError, t.path, contractName, fmt.Sprintf(format, args...))}, nil
+	}
+
+	artifact := t.artifact
+	if t.definition.ContractKind != "interface" {
 		return nil, nil
 	}
 
 	if !strings.HasPrefix(contractName, "I") {
-		return nil, []error{fmt.Errorf("%s: interface does not start with 'I'", contractName)}
+		return fail("%s: interface does not start with 'I'", contractName)
 	}
 
 	semver, err := getContractSemver(artifact)
 	if err != nil {
-		return nil, []error{fmt.Errorf("failed to get contract semver: %w", err)}
+		return []common.Finding{newFinding("interfaces", common.SeverityInfo, t.path, contractName,
+			fmt.Sprintf("%s: no pragma found, skipping compiler version check", contractName))}, nil
+	}
+
+	if msg, err := checkInterfacePragma(contractName, semver); err != nil {
+		return nil, err
+	} else if msg != "" {
+		return fail("%s", msg)
+	}
+
+	return compareInterfaceABI(t)
+}
+
+// checkInterfaceWithoutAST handles artifacts that have an ABI but no AST, such as those built
+// from precompiled sources. The contract kind and pragma cannot be checked, but an I-prefixed
+// artifact is still compared against its corresponding contract.
+func checkInterfaceWithoutAST(t *checkTarget) ([]common.Finding, error) {
+	if slices.Contains(excludeContracts, t.name) || !strings.HasPrefix(t.name, "I") {
+		return nil, nil
 	}
 
-	if semver != "solidity^0.8.0" {
-		return nil, []error{fmt.Errorf("%s: interface does not have correct compiler version (MUST be exactly solidity ^0.8.0)", contractName)}
+	findings := []common.Finding{newFinding("interfaces", common.SeverityInfo, t.path, t.name,
+		fmt.Sprintf("%s: artifact has no AST, skipping contract kind and pragma checks", t.name))}
+	if len(t.artifact.ABI) == 0 {
+		return findings, nil
 	}
 
-	contractBasename := contractName[1:]
-	correspondingContractFile := filepath.Join(artifactsDir, contractBasename+".sol", contractBasename+".json")
+	abiFindings, err := compareInterfaceABI(t)
+	return append(findings, abiFindings...), err
+}
+
+// contractArtifactPath returns the artifact path of the named contract. It prefers looking the
+// contract up by name across all artifacts, since forge names the artifact directory after the
+// source file and a file may declare contracts with other names, and falls back to the
+// <Contract>.sol/<Contract>.json layout, which may not exist.
+func contractArtifactPath(name string) (string, error) {
+	path, ok, err := artifactPathForContract(name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to index artifacts: %w", err)
+	}
+	if ok {
+		return path, nil
+	}
+	return filepath.Join(artifactsDir, name+".sol", name+".json"), nil
+}
+
+// compareInterfaceABI compares an interface's ABI against the ABI of its corresponding contract.
+func compareInterfaceABI(t *checkTarget) ([]common.Finding, error) {
+	contractName := t.name
+	correspondingContractFile, err := contractArtifactPath(contractName[1:])
+	if err != nil {
+		return nil, err
+	}
 
 	contractArtifact, err := readArtifact(correspondingContractFile)
 	if errors.Is(err, os.ErrNotExist) {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, []error{fmt.Errorf("failed to read corresponding contract artifact: %w", err)}
+		return nil, fmt.Errorf("failed to read corresponding contract artifact: %w", err)
+	}
+	if len(contractArtifact.ABI) == 0 {
+		return nil, nil
 	}
 
-	interfaceABI := artifact.ABI
+	interfaceABI := t.artifact.ABI
 	contractABI := contractArtifact.ABI
 
 	normalizedInterfaceABI, err := normalizeABI(interfaceABI)
 	if err != nil {
-		return nil, []error{fmt.Errorf("failed to normalize interface ABI: %w", err)}
+		return nil, fmt.Errorf("failed to normalize interface ABI: %w", err)
 	}
 
 	normalizedContractABI, err := normalizeABI(contractABI)
 	if err != nil {
-		return nil, []error{fmt.Errorf("failed to normalize contract ABI: %w", err)}
+		return nil, fmt.Errorf("failed to normalize contract ABI: %w", err)
+	}
+
+	ignores, err := readABIIgnores(t.sourcePath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ABI ignores: %w", err)
 	}
 
-	if !compareABIs(normalizedInterfaceABI, normalizedContractABI) {
-		return nil, []error{fmt.Errorf("%s: ABI differs from contract", contractName)}
+	diffs, stale := applyABIIgnores(diffABIs(normalizedInterfaceABI, normalizedContractABI), ignores)
+
+	var findings []common.Finding
+	for _, ignore := range stale {
+		findings = append(findings, newFinding("interfaces", common.SeverityWarning, t.sourcePath(), contractName,
+			fmt.Sprintf("%s: @checks:abi-ignore %q matches no ABI difference and can be removed", contractName, ignore)))
 	}
 
-	return nil, nil
+	if selectorsOnly {
+		selectorDiffs := diffSelectors(normalizedInterfaceABI, normalizedContractABI)
+		switch {
+		case len(selectorDiffs) > 0:
+			finding := newFinding("interfaces", common.SeverityError, t.path, contractName,
+				fmt.Sprintf("%s: function selectors differ from contract", contractName))
+			finding.Details = selectorDiffs
+			finding.Rule = ruleABIMismatch
+			findings = append(findings, finding)
+		case len(diffs) > 0:
+			findings = append(findings, newFinding("interfaces", common.SeverityInfo, t.path, contractName,
+				fmt.Sprintf("%s: function selectors match the contract; the %d remaining ABI differences are cosmetic", contractName, len(diffs))))
+		}
+		return findings, nil
+	}
+
+	// Internal and private functions are not in the contract's ABI, so an interface declaring one
+	// would otherwise only be told to remove it.
+	hidden := nonExternalFunctions(contractArtifact.contractNode(contractName[1:]))
+	diffs = slices.DeleteFunc(diffs, func(diff abiDiff) bool {
+		visibility, ok := hidden[getString(diff.item, "name")]
+		if diff.add || getString(diff.item, "type") != "function" || !ok {
+			return false
+		}
+		finding := newFinding("interfaces", common.SeverityError, t.path, contractName,
+			fmt.Sprintf("%s: function %s is %s on the contract; make it external or remove from interface",
+				contractName, abiSignature(diff.item), visibility))
+		finding.Rule = ruleABIMismatch
+		findings = append(findings, finding)
+		return true
+	})
+
+	if len(diffs) > 0 {
+		finding := newFinding("interfaces", common.SeverityError, t.path, contractName,
+			fmt.Sprintf("%s: ABI differs from contract", contractName))
+		finding.Details = formatABIDiffs(diffs, normalizedInterfaceABI, normalizedContractABI)
+		finding.Rule = ruleABIMismatch
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// nonExternalFunctions maps the names of the internal and private functions a contract declares
+// to their visibility. A name that is also used by a public or external function is left out.
+func nonExternalFunctions(contract astNode) map[string]string {
+	functions := make(map[string]string)
+	var exposed []string
+	for _, fn := range contract.children("nodes") {
+		if fn.nodeType() != "FunctionDefinition" || fn.name() == "" {
+			continue
+		}
+		switch visibility := getString(fn, "visibility"); visibility {
+		case "internal", "private":
+			functions[fn.name()] = visibility
+		default:
+			exposed = append(exposed, fn.name())
+		}
+	}
+	for _, name := range exposed {
+		delete(functions, name)
+	}
+	return functions
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func newFinding(check string, severity common.Severity, file, contract, message string) common.Finding {
+	return common.Finding{
+		Check:    check,
+		Severity: severity,
+		File:     file,
+		Contract: contract,
+		Message:  message,
+	}
+}
+
+// collectFindings flattens per-artifact results into a sorted list. Findings that are
+// reported identically by several artifacts of the same source are only kept once.
+func collectFindings(results map[string][]common.Finding) []common.Finding {
+	seen := make(map[string]struct{})
+	var findings []common.Finding
+	for _, fileFindings := range results {
+		for _, finding := range fileFindings {
+			key := strings.Join(append([]string{finding.Check, finding.Severity.String(), finding.File, finding.Contract, finding.Message},
+				finding.Details...), "\x00")
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			findings = append(findings, finding)
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+func sortFindings(findings []common.Finding) {
+	slices.SortFunc(findings, func(a, b common.Finding) int {
+		return strings.Compare(a.File+"\x00"+a.Message, b.File+"\x00"+b.Message)
+	})
+}
+
+// applyBaseline drops the findings accepted by the baseline at path and adds an info finding
+// for each baseline entry that no longer matches anything.
+func applyBaseline(path string, findings []common.Finding) ([]common.Finding, error) {
+	baseline, err := common.ReadBaseline(path)
+	if err != nil {
+		return nil, err
+	}
+	remaining, stale := baseline.Filter(findings)
+	for _, entry := range stale {
+		remaining = append(remaining, newFinding("baseline", common.SeverityInfo, entry.File, entry.Contract,
+			fmt.Sprintf("baseline entry for %s no longer matches a finding; run with --baseline-update to remove it: %s", entry.Check, entry.Message)))
+	}
+	sortFindings(remaining)
+	return remaining, nil
+}
+
+// dropInfo removes info findings to keep the output short.
+func dropInfo(findings []common.Finding) []common.Finding {
+	return slices.DeleteFunc(findings, func(f common.Finding) bool {
+		return f.Severity == common.SeverityInfo
+	})
+}
+
+// promoteWarnings turns warnings into errors for --strict runs.
+func promoteWarnings(findings []common.Finding) {
+	for i := range findings {
+		if findings[i].Severity == common.SeverityWarning {
+			findings[i].Severity = common.SeverityError
+		}
+	}
+}
+
+// reportFindings prints findings to stderr, grouped by contract unless flat is set. With color
+// set, the grouped findings and their ABI differences are colored.
+func reportFindings(findings []common.Finding, flat, color bool) {
+	if !flat {
+		if os.Getenv(common.EnvSuppressErrorReporter) == "" {
+			_ = common.WriteGroupedFindings(os.Stderr, findings, color)
+		}
+		return
+	}
+	reporter := common.NewErrorReporter()
+	for _, finding := range findings {
+		message := finding.Message
+		for _, detail := range finding.Details {
+			message += "\n    " + detail
+		}
+		if finding.Severity == common.SeverityError {
+			reporter.Fail("%s: %s", finding.File, message)
+		} else {
+			reporter.Warn("%s: %s", finding.File, message)
+		}
+	}
 }
 
 func contractNameFromArtifactPath(artifactPath string) string {
@@ -177,18 +1424,17 @@ func contractNameFromArtifactPath(artifactPath string) string {
 }
 
 func readArtifact(path string) (*Artifact, error) {
-	file, err := os.Open(path)
+	file, err := openArtifactFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open artifact file: %w", err)
 	}
 	defer file.Close()
 
-	var artifact Artifact
-	if err := json.NewDecoder(file).Decode(&artifact); err != nil {
+	artifact, err := decodeArtifact(file)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse artifact file: %w", err)
 	}
-
-	return &artifact, nil
+	return artifact, nil
 }
 
 func getContractDefinition(artifact *Artifact, contractName string) *ContractDefinition {
@@ -258,7 +1504,22 @@ func normalizeInternalTypes(item map[string]interface{}) {
 	}
 }
 
+// compareABIs reports whether two normalized ABIs have the same items.
 func compareABIs(interfaceABI, contractABI []map[string]interface{}) bool {
+	return len(diffABIs(interfaceABI, contractABI)) == 0
+}
+
+// abiDiff is an ABI item present on only one side of an interface comparison.
+type abiDiff struct {
+	// add is true when the item is missing from the interface, false when the interface has an
+	// item the contract does not.
+	add  bool
+	item map[string]interface{}
+}
+
+// diffABIs returns the items that differ between the two ABIs, sorted by type, name and
+// signature so that overloads of the same name are adjacent.
+func diffABIs(interfaceABI, contractABI []map[string]interface{}) []abiDiff {
 	makeKey := func(item map[string]interface{}) string {
 		inputs, _ := json.Marshal(item["inputs"])
 		outputs, _ := json.Marshal(item["outputs"])
@@ -268,7 +1529,9 @@ func compareABIs(interfaceABI, contractABI []map[string]interface{}) bool {
 	indexBy := func(items []map[string]interface{}) map[string]map[string]interface{} {
 		out := make(map[string]map[string]interface{}, len(items))
 		for _, item := range items {
-			out[makeKey(item)] = item
+			if isComparedType(getString(item, "type")) {
+				out[makeKey(item)] = item
+			}
 		}
 		return out
 	}
@@ -276,21 +1539,232 @@ func compareABIs(interfaceABI, contractABI []map[string]interface{}) bool {
 	interfaceItems := indexBy(interfaceABI)
 	contractItems := indexBy(contractABI)
 
-	isMatch := true
+	var diffs []abiDiff
 	for key, item := range interfaceItems {
 		if _, exists := contractItems[key]; !exists {
-			log.Printf("REMOVE %s from interface: %s", getString(item, "type"), formatABIItem(item))
-			isMatch = false
+			diffs = append(diffs, abiDiff{add: false, item: item})
 		}
 	}
 	for key, item := range contractItems {
 		if _, exists := interfaceItems[key]; !exists {
-			log.Printf("ADD %s to interface: %s", getString(item, "type"), formatABIItem(item))
-			isMatch = false
+			diffs = append(diffs, abiDiff{add: true, item: item})
+		}
+	}
+
+	slices.SortFunc(diffs, func(a, b abiDiff) int {
+		return strings.Compare(abiGroupKey(a.item)+"\x00"+formatABIItem(a.item), abiGroupKey(b.item)+"\x00"+formatABIItem(b.item))
+	})
+	return diffs
+}
+
+// formatABIDiffs renders diffs as log lines. Overloaded names are introduced by a header
+// counting the overloads on each side, and each overload is identified by its canonical
+// signature so it is clear which one differs.
+func formatABIDiffs(diffs []abiDiff, interfaceABI, contractABI []map[string]interface{}) []string {
+	interfaceCounts := countByGroup(interfaceABI)
+	contractCounts := countByGroup(contractABI)
+
+	// Some differences show up as a REMOVE and ADD of items that look alike, such as an event
+	// that differs only in which parameters are indexed, so such a pair is reported as one line.
+	paired := make(map[int]bool)
+	mismatches := make(map[int]string)
+	for i, removed := range diffs {
+		if removed.add {
+			continue
+		}
+		for j, added := range diffs {
+			if !added.add || paired[j] {
+				continue
+			}
+			if mismatch, ok := targetedMismatch(removed.item, added.item); ok {
+				paired[i], paired[j] = true, true
+				mismatches[i] = mismatch
+				break
+			}
+		}
+	}
+
+	var lines []string
+	var lastGroup string
+	for i, diff := range diffs {
+		if paired[i] && mismatches[i] == "" {
+			continue
+		}
+		itemType := getString(diff.item, "type")
+		group := abiGroupKey(diff.item)
+		overloaded := interfaceCounts[group] > 1 || contractCounts[group] > 1
+
+		if overloaded && group != lastGroup {
+			lines = append(lines, fmt.Sprintf("%s %s is overloaded: %d in the interface, %d in the contract",
+				itemType, getString(diff.item, "name"), interfaceCounts[group], contractCounts[group]))
+		}
+		lastGroup = group
+
+		action := "REMOVE %s from interface: %s"
+		if diff.add {
+			action = "ADD %s to interface: %s"
+		}
+		line := fmt.Sprintf(action, itemType, formatABIItem(diff.item))
+		if mismatch, ok := mismatches[i]; ok {
+			line = mismatch
+		}
+		if overloaded {
+			line = fmt.Sprintf("  %s [%s]", line, abiSignature(diff.item))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// targetedMismatch describes the difference between an interface item and a contract item in
+// one line when it is one that a REMOVE and ADD pair would obscure.
+func targetedMismatch(interfaceItem, contractItem map[string]interface{}) (string, bool) {
+	if sameExceptIndexed(interfaceItem, contractItem) {
+		return fmt.Sprintf("INDEXED mismatch on %s: interface indexes {%s}, contract indexes {%s}",
+			abiSignature(interfaceItem), strings.Join(indexedParams(interfaceItem), ","), strings.Join(indexedParams(contractItem), ",")), true
+	}
+	if arrays, ok := arrayMismatches(interfaceItem, contractItem); ok {
+		return fmt.Sprintf("ARRAY mismatch on %s: %s", abiSignature(interfaceItem), strings.Join(arrays, ", ")), true
+	}
+	return fieldMismatch(interfaceItem, contractItem)
+}
+
+// arrayMismatches returns the parameters of two items that differ only in array dimensions,
+// e.g. uint256[] and uint256[3], which encode differently. It reports false when the items differ
+// in any other way, or not at all.
+func arrayMismatches(interfaceItem, contractItem map[string]interface{}) ([]string, bool) {
+	if !reflect.DeepEqual(withoutParams(interfaceItem), withoutParams(contractItem)) {
+		return nil, false
+	}
+
+	var mismatches []string
+	for _, key := range []string{"inputs", "outputs"} {
+		interfaceParams, _ := interfaceItem[key].([]interface{})
+		contractParams, _ := contractItem[key].([]interface{})
+		if len(interfaceParams) != len(contractParams) {
+			return nil, false
+		}
+		for i := range interfaceParams {
+			interfaceParam, _ := interfaceParams[i].(map[string]interface{})
+			contractParam, _ := contractParams[i].(map[string]interface{})
+			interfaceType, contractType := getString(interfaceParam, "type"), getString(contractParam, "type")
+			if interfaceType == contractType {
+				if !reflect.DeepEqual(interfaceParam, contractParam) {
+					return nil, false
+				}
+				continue
+			}
+			stripped := func(param map[string]interface{}) string {
+				rest := maps.Clone(param)
+				rest["type"] = arrayBaseType(getString(param, "type"))
+				rest["internalType"] = arrayBaseType(getString(param, "internalType"))
+				out, _ := json.Marshal(rest)
+				return string(out)
+			}
+			if stripped(interfaceParam) != stripped(contractParam) {
+				return nil, false
+			}
+			mismatches = append(mismatches, fmt.Sprintf("interface %s vs contract %s", interfaceType, contractType))
 		}
 	}
+	return mismatches, len(mismatches) > 0
+}
 
-	return isMatch
+// arrayBaseType strips the array dimensions from an ABI type, e.g. uint256[][3] to uint256.
+func arrayBaseType(abiType string) string {
+	base, _, _ := strings.Cut(abiType, "[")
+	return base
+}
+
+// sameExceptIndexed reports whether two events are identical apart from the indexed flags of
+// their parameters.
+func sameExceptIndexed(a, b map[string]interface{}) bool {
+	strip := func(item map[string]interface{}) string {
+		params, _ := item["inputs"].([]interface{})
+		stripped := make([]map[string]interface{}, 0, len(params))
+		for _, p := range params {
+			paramMap, _ := p.(map[string]interface{})
+			copied := maps.Clone(paramMap)
+			delete(copied, "indexed")
+			stripped = append(stripped, copied)
+		}
+		rest := maps.Clone(item)
+		rest["inputs"] = stripped
+		out, _ := json.Marshal(rest)
+		return string(out)
+	}
+	return getString(a, "type") == "event" && getString(b, "type") == "event" && strip(a) == strip(b)
+}
+
+// indexedParams lists an event's indexed parameters by name, or by position when unnamed.
+func indexedParams(item map[string]interface{}) []string {
+	params, _ := item["inputs"].([]interface{})
+	var out []string
+	for i, p := range params {
+		paramMap, _ := p.(map[string]interface{})
+		if indexed, _ := paramMap["indexed"].(bool); !indexed {
+			continue
+		}
+		if name := getString(paramMap, "name"); name != "" {
+			out = append(out, name)
+		} else {
+			out = append(out, strconv.Itoa(i))
+		}
+	}
+	return out
+}
+
+// abiGroupKey groups ABI items that share a type and name, i.e. overloads.
+func abiGroupKey(item map[string]interface{}) string {
+	return getString(item, "type") + " " + getString(item, "name")
+}
+
+func countByGroup(items []map[string]interface{}) map[string]int {
+	counts := make(map[string]int)
+	for _, item := range items {
+		if isComparedType(getString(item, "type")) {
+			counts[abiGroupKey(item)]++
+		}
+	}
+	return counts
+}
+
+// abiItemTypes are the ABI item types that compareABIs can be restricted to.
+var abiItemTypes = []string{"function", "event", "error", "constructor", "fallback", "receive"}
+
+// selectorsOnly makes compareInterfaceABI compare only the sets of function selectors, which is
+// what determines whether calls through the interface reach the contract.
+var selectorsOnly bool
+
+// comparedTypes restricts compareABIs to the listed ABI item types. Nil compares every type.
+var comparedTypes []string
+
+// parseCompareTypes parses a comma-separated --compare-types value. An empty value selects
+// every type.
+func parseCompareTypes(value string) ([]string, error) {
+	var types []string
+	for _, itemType := range splitList(value) {
+		if !slices.Contains(abiItemTypes, itemType) {
+			return nil, fmt.Errorf("unknown ABI item type %q (expected one of %s)", itemType, strings.Join(abiItemTypes, ", "))
+		}
+		types = append(types, itemType)
+	}
+	return types, nil
+}
+
+// splitList splits a comma-separated flag value, dropping blank entries.
+func splitList(value string) []string {
+	var out []string
+	for _, elem := range strings.Split(value, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			out = append(out, elem)
+		}
+	}
+	return out
+}
+
+func isComparedType(itemType string) bool {
+	return comparedTypes == nil || slices.Contains(comparedTypes, itemType)
 }
 
 func formatABIItem(item map[string]interface{}) string {
@@ -315,6 +1789,30 @@ func formatABIItem(item map[string]interface{}) string {
 	}
 }
 
+// abiSignature returns the canonical signature of an ABI item, e.g. "transfer(address,uint256)".
+func abiSignature(item map[string]interface{}) string {
+	return fmt.Sprintf("%s(%s)", getString(item, "name"), strings.Join(canonicalABITypes(item["inputs"]), ","))
+}
+
+// canonicalABITypes returns the canonical types of a parameter list, expanding tuples into
+// their component types.
+func canonicalABITypes(raw interface{}) []string {
+	params, _ := raw.([]interface{})
+	out := make([]string, 0, len(params))
+	for _, p := range params {
+		paramMap, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		paramType := getString(paramMap, "type")
+		if suffix, ok := strings.CutPrefix(paramType, "tuple"); ok {
+			paramType = "(" + strings.Join(canonicalABITypes(paramMap["components"]), ",") + ")" + suffix
+		}
+		out = append(out, paramType)
+	}
+	return out
+}
+
 func formatABIParams(raw interface{}) []string {
 	params, _ := raw.([]interface{})
 	out := make([]string, 0, len(params))
@@ -1,9 +1,18 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/base/contracts/scripts/checks/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -231,3 +240,404 @@ func TestNormalizeInternalType(t *testing.T) {
 		})
 	}
 }
+
+func TestABISignature(t *testing.T) {
+	tests := []struct {
+		name string
+		item string
+		want string
+	}{
+		{"No params", `{"type":"function","name":"pause","inputs":[]}`, "pause()"},
+		{"Simple params", `{"type":"function","name":"transfer","inputs":[{"type":"address"},{"type":"uint256"}]}`, "transfer(address,uint256)"},
+		{
+			name: "Tuple array param",
+			item: `{"type":"function","name":"f","inputs":[{"type":"tuple[]","components":[{"type":"address"},{"type":"tuple","components":[{"type":"bytes"}]}]}]}`,
+			want: "f((address,(bytes))[])",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var item map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.item), &item))
+			require.Equal(t, tt.want, abiSignature(item))
+		})
+	}
+}
+
+func TestCompareABIsTypeFilter(t *testing.T) {
+	// The function matches on both sides; the event and error differ.
+	interfaceABI := `[
+		{"type":"function","name":"f","inputs":[],"outputs":[]},
+		{"type":"event","name":"E","inputs":[{"type":"uint256","indexed":false}]},
+		{"type":"error","name":"Err","inputs":[]}
+	]`
+	contractABI := `[
+		{"type":"function","name":"f","inputs":[],"outputs":[]},
+		{"type":"event","name":"E","inputs":[{"type":"uint128","indexed":false}]},
+		{"type":"error","name":"Other","inputs":[]}
+	]`
+
+	tests := []struct {
+		name  string
+		types string
+		want  bool
+	}{
+		{"All types", "", false},
+		{"Functions only", "function", true},
+		{"Events only", "event", false},
+		{"Errors only", "error", false},
+		{"Functions and events", "function,event", false},
+		{"Functions and errors", "function,error", false},
+		{"Events and errors", "event,error", false},
+		{"Explicit all", "function,event,error", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types, err := parseCompareTypes(tt.types)
+			require.NoError(t, err)
+			prev := comparedTypes
+			comparedTypes = types
+			t.Cleanup(func() { comparedTypes = prev })
+
+			var abi1, abi2 []map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(interfaceABI), &abi1))
+			require.NoError(t, json.Unmarshal([]byte(contractABI), &abi2))
+			require.Equal(t, tt.want, compareABIs(abi1, abi2))
+			// Filtering is symmetric, so swapping sides gives the same result.
+			require.Equal(t, tt.want, compareABIs(abi2, abi1))
+		})
+	}
+}
+
+func TestCompareABIsTypeFilterIgnoresMissingItems(t *testing.T) {
+	types, err := parseCompareTypes("function")
+	require.NoError(t, err)
+	prev := comparedTypes
+	comparedTypes = types
+	t.Cleanup(func() { comparedTypes = prev })
+
+	var abi1, abi2 []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`[{"type":"function","name":"f","inputs":[],"outputs":[]}]`), &abi1))
+	require.NoError(t, json.Unmarshal([]byte(`[{"type":"function","name":"f","inputs":[],"outputs":[]},{"type":"event","name":"E","inputs":[]}]`), &abi2))
+	require.True(t, compareABIs(abi1, abi2))
+	require.True(t, compareABIs(abi2, abi1))
+}
+
+func TestParseCompareTypes(t *testing.T) {
+	types, err := parseCompareTypes(" function , error ")
+	require.NoError(t, err)
+	require.Equal(t, []string{"function", "error"}, types)
+
+	types, err = parseCompareTypes("")
+	require.NoError(t, err)
+	require.Nil(t, types)
+
+	_, err = parseCompareTypes("function,modifier")
+	require.Error(t, err)
+}
+
+func TestSplitList(t *testing.T) {
+	require.Equal(t, []string{"interfaces", "size"}, splitList(" interfaces,, size "))
+	require.Nil(t, splitList(""))
+}
+
+func TestProcessFileWithoutAST(t *testing.T) {
+	prev := artifactsDir
+	artifactsDir = filepath.Join("testdata", "no-ast")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	t.Run("interface is still compared", func(t *testing.T) {
+		findings, errs := processFile(filepath.Join(artifactsDir, "IVendored.sol", "IVendored.json"))
+		require.Empty(t, errs)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityInfo, findings[0].Severity)
+		require.Contains(t, findings[0].Message, "artifact has no AST")
+		require.Equal(t, common.SeverityError, findings[1].Severity)
+		require.Equal(t, "IVendored: ABI differs from contract", findings[1].Message)
+	})
+
+	t.Run("contract is skipped", func(t *testing.T) {
+		findings, errs := processFile(filepath.Join(artifactsDir, "Vendored.sol", "Vendored.json"))
+		require.Empty(t, errs)
+		require.Empty(t, findings)
+	})
+}
+
+func TestProcessFileInternalFunction(t *testing.T) {
+	prev := artifactsDir
+	artifactsDir = filepath.Join("testdata", "internal-functions")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	findings, errs := processFile(filepath.Join(artifactsDir, "IVault.sol", "IVault.json"))
+	require.Empty(t, errs)
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityError, findings[0].Severity)
+	require.Equal(t, "IVault: function sweep() is internal on the contract; make it external or remove from interface", findings[0].Message)
+}
+
+func TestPromoteWarnings(t *testing.T) {
+	findings := []common.Finding{
+		{Severity: common.SeverityInfo},
+		{Severity: common.SeverityWarning},
+		{Severity: common.SeverityError},
+	}
+	promoteWarnings(findings)
+	require.Equal(t, common.SeverityInfo, findings[0].Severity)
+	require.Equal(t, common.SeverityError, findings[1].Severity)
+	require.Equal(t, common.SeverityError, findings[2].Severity)
+}
+
+func TestCompareABIsOverloads(t *testing.T) {
+	readABI := func(path string) []map[string]interface{} {
+		artifact, err := readArtifact(path)
+		require.NoError(t, err)
+		abi, err := normalizeABI(artifact.ABI)
+		require.NoError(t, err)
+		return abi
+	}
+	interfaceABI := readABI(filepath.Join("testdata", "overloads", "IOverloaded.sol", "IOverloaded.json"))
+	contractABI := readABI(filepath.Join("testdata", "overloads", "Overloaded.sol", "Overloaded.json"))
+
+	diffs := diffABIs(interfaceABI, contractABI)
+	require.Len(t, diffs, 1)
+	require.Equal(t, []string{
+		"function deposit is overloaded: 2 in the interface, 3 in the contract",
+		"  ADD function to interface: function deposit(address to) [deposit(address)]",
+	}, formatABIDiffs(diffs, interfaceABI, contractABI))
+
+	require.False(t, compareABIs(interfaceABI, contractABI))
+}
+
+func TestProcessFileGroupsABIDiffs(t *testing.T) {
+	prev := artifactsDir
+	artifactsDir = filepath.Join("testdata", "overloads")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	findings, errs := processFile(filepath.Join(artifactsDir, "IOverloaded.sol", "IOverloaded.json"))
+	require.Empty(t, errs)
+	require.Empty(t, logs.String(), "ABI differences are reported with the finding, not logged")
+	idx := slices.IndexFunc(findings, func(f common.Finding) bool { return f.Message == "IOverloaded: ABI differs from contract" })
+	require.GreaterOrEqual(t, idx, 0)
+	require.Equal(t, []string{
+		"function deposit is overloaded: 2 in the interface, 3 in the contract",
+		"  ADD function to interface: function deposit(address to) [deposit(address)]",
+	}, findings[idx].Details)
+
+	var grouped strings.Builder
+	require.NoError(t, common.WriteGroupedFindings(&grouped, findings[idx:idx+1], false))
+	require.Equal(t, "IOverloaded ("+findings[idx].File+")\n"+
+		"  ❌  IOverloaded: ABI differs from contract\n"+
+		"      function deposit is overloaded: 2 in the interface, 3 in the contract\n"+
+		"        ADD function to interface: function deposit(address to) [deposit(address)]\n", grouped.String())
+}
+
+func TestCompareABIsIndexedMismatch(t *testing.T) {
+	readABI := func(path string) []map[string]interface{} {
+		artifact, err := readArtifact(path)
+		require.NoError(t, err)
+		abi, err := normalizeABI(artifact.ABI)
+		require.NoError(t, err)
+		return abi
+	}
+	interfaceABI := readABI(filepath.Join("testdata", "indexed-events", "IEmitter.sol", "IEmitter.json"))
+	contractABI := readABI(filepath.Join("testdata", "indexed-events", "Emitter.sol", "Emitter.json"))
+
+	diffs := diffABIs(interfaceABI, contractABI)
+	require.Len(t, diffs, 3)
+	require.Equal(t, []string{
+		"ADD event to interface: event Approval(address owner, uint256 amount)",
+		"INDEXED mismatch on Transfer(address,address,uint256): interface indexes {from}, contract indexes {from,to}",
+	}, formatABIDiffs(diffs, interfaceABI, contractABI))
+}
+
+func TestCompareABIsArrayMismatch(t *testing.T) {
+	readABI := func(path string) []map[string]interface{} {
+		artifact, err := readArtifact(path)
+		require.NoError(t, err)
+		abi, err := normalizeABI(artifact.ABI)
+		require.NoError(t, err)
+		return abi
+	}
+	interfaceABI := readABI(filepath.Join("testdata", "array-bounds", "IBounded.sol", "IBounded.json"))
+	contractABI := readABI(filepath.Join("testdata", "array-bounds", "Bounded.sol", "Bounded.json"))
+
+	diffs := diffABIs(interfaceABI, contractABI)
+	require.Len(t, diffs, 4)
+	require.Equal(t, []string{
+		"ARRAY mismatch on owner(): interface address vs contract address[]",
+		"ARRAY mismatch on setWeights(uint256[]): interface uint256[] vs contract uint256[3]",
+	}, formatABIDiffs(diffs, interfaceABI, contractABI))
+}
+
+func TestContractArtifactPath(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Bridges.sol/L1Bridge.json":  `{"abi":[]}`,
+		"forge-artifacts/Portal.sol/Portal.json":     `{"abi":[]}`,
+		"forge-artifacts/Portal.sol/Portal.0.8.json": `{"abi":[]}`,
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	path, err := contractArtifactPath("L1Bridge")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(artifactsDir, "Bridges.sol", "L1Bridge.json"), path)
+
+	path, err = contractArtifactPath("Portal")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(artifactsDir, "Portal.sol", "Portal.json"), path)
+
+	path, err = contractArtifactPath("Missing")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(artifactsDir, "Missing.sol", "Missing.json"), path)
+}
+
+func TestInterfacesCheckRegistered(t *testing.T) {
+	var names []string
+	for _, check := range common.RegisteredChecks() {
+		names = append(names, check.Name())
+	}
+	require.Contains(t, names, "interfaces")
+}
+
+func TestCheckNames(t *testing.T) {
+	names := checkNames([]common.Check{&interfacesCheck{}})
+	require.Len(t, names, len(artifactChecks)+1)
+	require.Contains(t, names, "interfaces")
+	require.Contains(t, names, "duplicate-contract-name")
+}
+
+func TestArtifactChecksDescribed(t *testing.T) {
+	for _, check := range artifactChecks {
+		require.NotEmpty(t, check.description, check.name)
+	}
+	info := (&interfacesCheck{}).Info()
+	require.Len(t, info.Rules, len(artifactChecks)+1)
+}
+
+func TestApplyBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	accepted := newFinding("delegatecall", common.SeverityError, "src/A.sol", "A", "A.f uses delegatecall")
+	fresh := newFinding("delegatecall", common.SeverityError, "src/B.sol", "B", "B.f uses delegatecall")
+	require.NoError(t, common.WriteBaseline(path, &common.Baseline{Findings: []common.BaselineEntry{
+		{Check: "delegatecall", File: "src/A.sol", Contract: "A", Message: "A.f uses delegatecall"},
+		{Check: "delegatecall", File: "src/Gone.sol", Contract: "Gone", Message: "Gone.f uses delegatecall"},
+	}}))
+
+	findings, err := applyBaseline(path, []common.Finding{accepted, fresh})
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	require.Equal(t, fresh, findings[0])
+	require.Equal(t, common.SeverityInfo, findings[1].Severity)
+	require.Equal(t, "src/Gone.sol", findings[1].File)
+	require.Contains(t, findings[1].Message, "--baseline-update")
+}
+
+func TestWriteReportOutputSummary(t *testing.T) {
+	prev := artifactsDir
+	artifactsDir = filepath.Join("testdata", "overloads")
+	t.Cleanup(func() { artifactsDir = prev })
+	findings, errs := processFile(filepath.Join(artifactsDir, "IOverloaded.sol", "IOverloaded.json"))
+	require.Empty(t, errs)
+
+	setupSourceFixture(t, map[string]string{
+		"src/Portal.sol": "contract Portal {}\n",
+		"src/Bridge.sol": "contract Bridge {}\n",
+	})
+	setConfig(t, &Config{})
+	missing, err := verifyAllContractsHaveInterfaces([]SourceRoot{{Root: "src", ExpectedInterfaceRoot: "interfaces"}})
+	require.NoError(t, err)
+	findings = append(findings, missing...)
+
+	path := filepath.Join(cwd, "summary.json")
+	require.NoError(t, writeReportOutput(path, "summary-json", common.Shard{}, findings))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var summary common.Summary
+	require.NoError(t, json.Unmarshal(data, &summary))
+	require.False(t, summary.Pass)
+	require.Equal(t, common.SummaryTotals{Errors: 2, Contracts: 2}, summary.Rules[ruleMissingInterface])
+	require.Equal(t, common.SummaryTotals{Errors: 1, Contracts: 1}, summary.Rules[ruleABIMismatch])
+}
+
+func TestRunChecksShardedReportsCrossArtifactChecks(t *testing.T) {
+	contract := func(name, bases, abi string) string {
+		return fmt.Sprintf(`{"abi":%s,"ast":{"absolutePath":"src/%[2]s.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"%[2]s","contractKind":"contract","baseContracts":[%[3]s]}
+		]}}`, abi, name, bases)
+	}
+	// Base is only inherited, by Child on the other shard; Portal has nothing to call.
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Base.sol/Base.json": contract("Base", "", `[]`),
+		"forge-artifacts/Child.sol/Child.json": contract("Child",
+			`{"nodeType":"InheritanceSpecifier","baseName":{"nodeType":"IdentifierPath","name":"Base"}}`,
+			`[{"type":"function","name":"f","inputs":[],"outputs":[],"stateMutability":"nonpayable"}]`),
+		"forge-artifacts/Portal.sol/Portal.json": contract("Portal", "", `[]`),
+	})
+	prevDir, prevShard := artifactsDir, shard
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() {
+		artifactsDir, shard = prevDir, prevShard
+		// The cross-artifact indexes outlive a run; drop what this fixture added.
+		unreachable.candidates, unreachable.bases = make(map[string]string), make(map[string]bool)
+		inheritance.entries = make(map[string]inheritanceEntry)
+	})
+	setConfig(t, &Config{
+		SourceRoots: []SourceRoot{{Root: "missing", ExpectedInterfaceRoot: "interfaces"}},
+		Exclude:     map[string][]string{"stale-exclude": slices.Concat(excludeContracts, excludeSourceContracts)},
+	})
+
+	var unreachableContracts []string
+	for i := 1; i <= 2; i++ {
+		shard = common.Shard{Index: i, Count: 2}
+		findings, err := runChecks(context.Background(), &runTimer{}, false)
+		require.NoError(t, err)
+		for _, finding := range findings {
+			if finding.Check == "unreachable-contract" {
+				unreachableContracts = append(unreachableContracts, fmt.Sprintf("%s on shard %d", finding.Contract, i))
+			}
+		}
+	}
+	require.Equal(t, []string{"Portal on shard 1"}, unreachableContracts)
+}
+
+func TestRunChecksSelectsSingleRule(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Test.sol/Test.json": delegatecallArtifact,
+		"src/Test.sol":                       "contract Test {}\n",
+	})
+	prevDir := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() {
+		artifactsDir = prevDir
+		unreachable.candidates, unreachable.bases = make(map[string]string), make(map[string]bool)
+		inheritance.entries = make(map[string]inheritanceEntry)
+	})
+	setConfig(t, &Config{
+		SourceRoots: []SourceRoot{{Root: "src", ExpectedInterfaceRoot: "interfaces"}},
+		Exclude:     map[string][]string{"stale-exclude": slices.Concat(excludeContracts, excludeSourceContracts)},
+	})
+	run := func(selected, deselected []string) []string {
+		t.Helper()
+		checks, err := common.SelectChecks(common.RegisteredChecks(), selected, deselected)
+		require.NoError(t, err)
+		findings, err := common.RunChecks(context.Background(), checks, common.Options{})
+		require.NoError(t, err)
+		var names []string
+		for _, finding := range findings {
+			names = append(names, finding.Check)
+		}
+		return names
+	}
+
+	require.Equal(t, []string{"delegatecall", "delegatecall"}, run([]string{"delegatecall"}, nil))
+	deselected := run(nil, []string{"delegatecall"})
+	require.NotContains(t, deselected, "delegatecall")
+	require.Contains(t, deselected, "interfaces")
+}
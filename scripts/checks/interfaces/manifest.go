@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// Interface statuses in the manifest.
+const (
+	manifestMatched    = "matched"    // the interface has exactly the contract's function selectors
+	manifestUnmatched  = "unmatched"  // the interface and the contract have different selectors
+	manifestMissing    = "missing"    // the contract has no interface
+	manifestExcluded   = "excluded"   // the contract needs no interface
+	manifestUnverified = "unverified" // the contract or its interface has no artifact
+)
+
+// manifestEntry describes the interface surface of one source contract in the manifest written
+// by --emit-manifest.
+type manifestEntry struct {
+	Contract      string             `json:"contract"`
+	Source        string             `json:"source"`
+	Interface     string             `json:"interface"`
+	InterfacePath string             `json:"interfacePath"`
+	Status        string             `json:"status"`
+	Selectors     []manifestSelector `json:"selectors"`
+}
+
+// manifestSelector is an external function of a contract.
+type manifestSelector struct {
+	Selector  string `json:"selector"`
+	Signature string `json:"signature"`
+}
+
+// buildManifest describes every scanned source contract, in scan order, with its external
+// function selectors sorted by selector, so that the manifest only changes with the surface.
+func buildManifest(contracts []sourceContract) ([]manifestEntry, error) {
+	entries := make([]manifestEntry, 0, len(contracts))
+	for _, contract := range contracts {
+		entry := manifestEntry{
+			Contract:      contract.Name,
+			Source:        filepath.ToSlash(contract.SourcePath),
+			Interface:     "I" + contract.Name,
+			InterfacePath: filepath.ToSlash(contract.InterfacePath),
+			Selectors:     []manifestSelector{},
+		}
+		if rel, err := filepath.Rel(cwd, contract.InterfacePath); err == nil {
+			entry.InterfacePath = filepath.ToSlash(rel)
+		}
+
+		selectors, ok, err := contractSelectors(contract.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, selector := range slices.Sorted(maps.Keys(selectors)) {
+			entry.Selectors = append(entry.Selectors, manifestSelector{Selector: selector, Signature: selectors[selector]})
+		}
+
+		switch {
+		case contract.Excluded:
+			entry.Status = manifestExcluded
+		case !contract.HasInterface:
+			entry.Status = manifestMissing
+		case !ok:
+			entry.Status = manifestUnverified
+		default:
+			interfaceSelectors, ok, err := contractSelectors(entry.Interface)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case !ok:
+				entry.Status = manifestUnverified
+			case maps.Equal(selectors, interfaceSelectors):
+				entry.Status = manifestMatched
+			default:
+				entry.Status = manifestUnmatched
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// contractSelectors returns the function selectors in the ABI of the named contract's artifact,
+// and whether it has one.
+func contractSelectors(name string) (map[string]string, bool, error) {
+	path, ok, err := artifactPathForContract(name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	artifact, err := readArtifact(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read artifact of %s: %w", name, err)
+	}
+	items, err := normalizeABI(artifact.ABI)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse ABI of %s: %w", name, err)
+	}
+	return functionSelectors(items), true, nil
+}
+
+// writeManifest scans the source roots and writes the manifest of their contracts to path.
+func writeManifest(path string) error {
+	contracts, err := scanSourceContracts(sourceRoots())
+	if err != nil {
+		return err
+	}
+	entries, err := buildManifest(contracts)
+	if err != nil {
+		return err
+	}
+	return common.WriteJSONAtomic(entries, path)
+}
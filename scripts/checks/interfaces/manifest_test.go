@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteManifest(t *testing.T) {
+	abi := func(functions ...string) string {
+		items := `{"type":"event","name":"Ping","inputs":[]}`
+		for _, fn := range functions {
+			items += `,{"type":"function","name":"` + fn + `","inputs":[],"outputs":[]}`
+		}
+		return `{"abi":[` + items + `]}`
+	}
+	setupSourceFixture(t, map[string]string{
+		"src/L1/Portal.sol":                        "contract Portal {}\n",
+		"src/L1/Bridge.sol":                        "contract Bridge {}\n",
+		"src/L1/Lonely.sol":                        "contract Lonely {}\n",
+		"src/L2/WETH.sol":                          "contract WETH {}\n",
+		"interfaces/L1/IPortal.sol":                "interface IPortal {}\n",
+		"interfaces/L1/IBridge.sol":                "interface IBridge {}\n",
+		"forge-artifacts/Portal.sol/Portal.json":   abi("version", "deposit"),
+		"forge-artifacts/IPortal.sol/IPortal.json": abi("deposit", "version"),
+		"forge-artifacts/Bridge.sol/Bridge.json":   abi("bridge"),
+		"forge-artifacts/IBridge.sol/IBridge.json": abi(),
+	})
+	setConfig(t, &Config{})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	require.NoError(t, writeManifest("interfaces.json"))
+	data, err := os.ReadFile("interfaces.json")
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"contract":"Bridge","source":"src/L1/Bridge.sol","interface":"IBridge","interfacePath":"interfaces/L1/IBridge.sol",
+			"status":"unmatched","selectors":[{"selector":"0xe78cea92","signature":"bridge()"}]},
+		{"contract":"Lonely","source":"src/L1/Lonely.sol","interface":"ILonely","interfacePath":"interfaces/L1/ILonely.sol",
+			"status":"missing","selectors":[]},
+		{"contract":"Portal","source":"src/L1/Portal.sol","interface":"IPortal","interfacePath":"interfaces/L1/IPortal.sol",
+			"status":"matched","selectors":[{"selector":"0x54fd4d50","signature":"version()"},{"selector":"0xd0e30db0","signature":"deposit()"}]},
+		{"contract":"WETH","source":"src/L2/WETH.sol","interface":"IWETH","interfacePath":"interfaces/L2/IWETH.sol",
+			"status":"excluded","selectors":[]}
+	]`, string(data))
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// ModifierRule requires public and external functions whose names match Functions to apply
+// each of the listed Modifiers.
+type ModifierRule struct {
+	// Functions is a glob pattern over function names, e.g. "upgrade*".
+	Functions string   `json:"functions"`
+	Modifiers []string `json:"modifiers"`
+}
+
+// checkModifierRules fails when a public or external function of a source contract matches a
+// configured modifier rule but does not apply one of the modifiers the rule requires. A
+// function can opt out with ignoreTag.
+func checkModifierRules(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || len(config.ModifierRules) == 0 || config.isExcluded("required-modifier", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	for _, fn := range node.children("nodes") {
+		if fn.nodeType() != "FunctionDefinition" || getString(fn, "kind") != "function" {
+			continue
+		}
+		if visibility := getString(fn, "visibility"); visibility != "public" && visibility != "external" {
+			continue
+		}
+		if hasIgnoreTag(fn, "required-modifier") {
+			continue
+		}
+
+		applied := appliedModifiers(fn)
+		for _, rule := range config.ModifierRules {
+			if ok, err := path.Match(rule.Functions, fn.name()); err != nil {
+				return nil, fmt.Errorf("invalid function pattern %q: %w", rule.Functions, err)
+			} else if !ok {
+				continue
+			}
+			for _, modifier := range rule.Modifiers {
+				if slices.Contains(applied, modifier) {
+					continue
+				}
+				findings = append(findings, newFinding("required-modifier", common.SeverityError, t.sourcePath(), t.name,
+					fmt.Sprintf("%s.%s is missing modifier %s required by rule %q", t.name, fn.name(), modifier, rule.Functions)))
+			}
+		}
+	}
+	return findings, nil
+}
+
+// appliedModifiers returns the names of the modifiers a function invokes, without any
+// qualifying contract name.
+func appliedModifiers(fn astNode) []string {
+	var names []string
+	for _, invocation := range fn.children("modifiers") {
+		name := invocation.child("modifierName").name()
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// modifierArtifact has an upgrade function with the required modifier applied through a
+// qualified name, one without it, an opted-out one and an internal one.
+const modifierArtifact = `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"upgradeTo","kind":"function","visibility":"external","modifiers":[
+			{"nodeType":"ModifierInvocation","modifierName":{"nodeType":"IdentifierPath","name":"ProxyAdminOwned.onlyProxyAdmin"}}]},
+		{"nodeType":"FunctionDefinition","name":"upgradeFee","kind":"function","visibility":"public","modifiers":[
+			{"nodeType":"ModifierInvocation","modifierName":{"nodeType":"IdentifierPath","name":"onlyOwner"}}]},
+		{"nodeType":"FunctionDefinition","name":"upgradeLegacy","kind":"function","visibility":"external","modifiers":[],
+			"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore required-modifier"}},
+		{"nodeType":"FunctionDefinition","name":"upgradeInternal","kind":"function","visibility":"internal","modifiers":[]}
+	]}
+]}}`
+
+func TestCheckModifierRules(t *testing.T) {
+	rules := []ModifierRule{{Functions: "upgrade*", Modifiers: []string{"onlyProxyAdmin"}}}
+
+	t.Run("flags missing modifiers", func(t *testing.T) {
+		setConfig(t, &Config{ModifierRules: rules})
+		findings, err := checkModifierRules(delegatecallTarget(t, modifierArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, `Test.upgradeFee is missing modifier onlyProxyAdmin required by rule "upgrade*"`, findings[0].Message)
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkModifierRules(delegatecallTarget(t, modifierArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{ModifierRules: rules, Exclude: map[string][]string{"required-modifier": {"Test"}}})
+		findings, err := checkModifierRules(delegatecallTarget(t, modifierArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		setConfig(t, &Config{ModifierRules: []ModifierRule{{Functions: "[", Modifiers: []string{"onlyOwner"}}}})
+		_, err := checkModifierRules(delegatecallTarget(t, modifierArtifact))
+		require.ErrorContains(t, err, "invalid function pattern")
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// stateOpcodes lists the storage opcodes that contradict each declared mutability when used in
+// inline assembly. Reading storage is fine in a view function.
+var stateOpcodes = map[string][]string{
+	"view": {"sstore", "tstore"},
+	"pure": {"sstore", "sload", "tstore", "tload"},
+}
+
+// checkAssemblyMutability warns about view and pure functions whose inline assembly touches
+// storage in a way their mutability rules out. The compiler cannot fully verify assembly, so
+// this is advisory; vetted functions can opt out with ignoreTag.
+func checkAssemblyMutability(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("assembly-mutability", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	for _, fn := range node.children("nodes") {
+		mutability := getString(fn, "stateMutability")
+		forbidden, ok := stateOpcodes[mutability]
+		if fn.nodeType() != "FunctionDefinition" || !ok || hasIgnoreTag(fn, "assembly-mutability") {
+			continue
+		}
+
+		var used []string
+		walkAST(fn.child("body"), func(n astNode, _ []astNode) bool {
+			if n.nodeType() != "InlineAssembly" {
+				return true
+			}
+			for _, opcode := range assemblyOpcodes(n) {
+				if slices.Contains(forbidden, opcode) && !slices.Contains(used, opcode) {
+					used = append(used, opcode)
+				}
+			}
+			return false
+		})
+		if len(used) == 0 {
+			continue
+		}
+		slices.Sort(used)
+		findings = append(findings, newFinding("assembly-mutability", common.SeverityWarning, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s is %s but its inline assembly uses %s", t.name, functionLabel(fn), mutability, strings.Join(used, ", "))))
+	}
+	return findings, nil
+}
+
+// assemblyOpcodes returns the names of every builtin or function called in an InlineAssembly
+// node's Yul AST.
+func assemblyOpcodes(assembly astNode) []string {
+	var names []string
+	walkAST(assembly.child("AST"), func(n astNode, _ []astNode) bool {
+		if n.nodeType() == "YulFunctionCall" {
+			names = append(names, n.child("functionName").name())
+		}
+		return true
+	})
+	return names
+}
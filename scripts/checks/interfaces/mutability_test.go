@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// mutabilityArtifact has a view function writing storage, a view function reading it, a pure
+// function reading it and an opted-out view function writing it.
+const mutabilityArtifact = `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"poke","stateMutability":"view","body":{"nodeType":"Block","statements":[
+			{"nodeType":"InlineAssembly","AST":{"nodeType":"YulBlock","statements":[
+				{"nodeType":"YulExpressionStatement","expression":{"nodeType":"YulFunctionCall",
+					"functionName":{"nodeType":"YulIdentifier","name":"sstore"},"arguments":[
+					{"nodeType":"YulLiteral","value":"0"},
+					{"nodeType":"YulFunctionCall","functionName":{"nodeType":"YulIdentifier","name":"sload"},"arguments":[]}]}}
+			]}}
+		]}},
+		{"nodeType":"FunctionDefinition","name":"peek","stateMutability":"view","body":{"nodeType":"Block","statements":[
+			{"nodeType":"InlineAssembly","AST":{"nodeType":"YulBlock","statements":[
+				{"nodeType":"YulFunctionCall","functionName":{"nodeType":"YulIdentifier","name":"sload"},"arguments":[]}
+			]}}
+		]}},
+		{"nodeType":"FunctionDefinition","name":"slot","stateMutability":"pure","body":{"nodeType":"Block","statements":[
+			{"nodeType":"InlineAssembly","AST":{"nodeType":"YulBlock","statements":[
+				{"nodeType":"YulFunctionCall","functionName":{"nodeType":"YulIdentifier","name":"sload"},"arguments":[]}
+			]}}
+		]}},
+		{"nodeType":"FunctionDefinition","name":"vetted","stateMutability":"view",
+			"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore assembly-mutability"},
+			"body":{"nodeType":"Block","statements":[
+			{"nodeType":"InlineAssembly","AST":{"nodeType":"YulBlock","statements":[
+				{"nodeType":"YulFunctionCall","functionName":{"nodeType":"YulIdentifier","name":"sstore"},"arguments":[]}
+			]}}
+		]}}
+	]}
+]}}`
+
+func TestCheckAssemblyMutability(t *testing.T) {
+	t.Run("flags state access", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkAssemblyMutability(delegatecallTarget(t, mutabilityArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.poke is view but its inline assembly uses sstore", findings[0].Message)
+		require.Equal(t, "Test.slot is pure but its inline assembly uses sload", findings[1].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"assembly-mutability": {"Test"}}})
+		findings, err := checkAssemblyMutability(delegatecallTarget(t, mutabilityArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkFunctionEventNames warns when a contract's ABI declares a function and an event with the
+// same name. This is legal Solidity but makes the contract harder to read.
+func checkFunctionEventNames(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("function-event-name", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	functions := make(map[string]struct{})
+	events := make(map[string]struct{})
+	for _, item := range items {
+		switch getString(item, "type") {
+		case "function":
+			functions[getString(item, "name")] = struct{}{}
+		case "event":
+			events[getString(item, "name")] = struct{}{}
+		}
+	}
+
+	var shared []string
+	for name := range functions {
+		if _, ok := events[name]; ok {
+			shared = append(shared, name)
+		}
+	}
+	slices.Sort(shared)
+
+	findings := make([]common.Finding, 0, len(shared))
+	for _, name := range shared {
+		findings = append(findings, newFinding("function-event-name", common.SeverityWarning, t.sourcePath(), t.name,
+			fmt.Sprintf("%s declares both a function and an event named %s", t.name, name)))
+	}
+	return findings, nil
+}
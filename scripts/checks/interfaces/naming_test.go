@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// abiTarget returns a check target for a source contract named Test with the given ABI.
+func abiTarget(t *testing.T, abi string) *checkTarget {
+	t.Helper()
+	artifact := &Artifact{ABI: json.RawMessage(abi), AST: ArtifactAST{AbsolutePath: "src/Test.sol"}}
+	return &checkTarget{
+		path:       "forge-artifacts/Test.sol/Test.json",
+		name:       "Test",
+		artifact:   artifact,
+		definition: &ContractDefinition{ContractKind: "contract", Name: "Test"},
+	}
+}
+
+func TestCheckFunctionEventNames(t *testing.T) {
+	abi := `[
+		{"type":"function","name":"paused","inputs":[],"outputs":[]},
+		{"type":"event","name":"Paused","inputs":[]},
+		{"type":"function","name":"upgrade","inputs":[],"outputs":[]},
+		{"type":"event","name":"upgrade","inputs":[]}
+	]`
+
+	t.Run("shared name", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkFunctionEventNames(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test declares both a function and an event named upgrade", findings[0].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"function-event-name": {"Test"}}})
+		findings, err := checkFunctionEventNames(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// opcodeBuiltins maps the Solidity builtins that compile to a single opcode to that opcode, so
+// that forbidding an opcode also forbids calling it outside assembly.
+var opcodeBuiltins = map[string]string{
+	"selfdestruct": "selfdestruct",
+	"suicide":      "selfdestruct",
+}
+
+// checkForbiddenOpcodes fails when a source contract matching forbiddenOpcodes.contracts uses
+// one of forbiddenOpcodes.opcodes, either in inline assembly or through a builtin such as
+// selfdestruct. The check is disabled when no opcodes are configured.
+func checkForbiddenOpcodes(t *checkTarget) ([]common.Finding, error) {
+	cfg := config.ForbiddenOpcodes
+	if !t.isSource() || len(cfg.Opcodes) == 0 || config.isExcluded("forbidden-opcode", t.name) {
+		return nil, nil
+	}
+	if len(cfg.Contracts) > 0 {
+		matched := false
+		for _, pattern := range cfg.Contracts {
+			ok, err := path.Match(pattern, t.name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid contract pattern %q: %w", pattern, err)
+			}
+			matched = matched || ok
+		}
+		if !matched {
+			return nil, nil
+		}
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+	forbidden := make(map[string]bool, len(cfg.Opcodes))
+	for _, opcode := range cfg.Opcodes {
+		forbidden[strings.ToLower(opcode)] = true
+	}
+
+	type use struct{ function, opcode string }
+	var uses []use
+	record := func(parents []astNode, opcode string) {
+		u := use{functionLabel(enclosingFunction(parents)), opcode}
+		if forbidden[opcode] && !slices.Contains(uses, u) {
+			uses = append(uses, u)
+		}
+	}
+	walkAST(node, func(n astNode, parents []astNode) bool {
+		switch n.nodeType() {
+		case "InlineAssembly":
+			for _, opcode := range assemblyOpcodes(n) {
+				record(parents, opcode)
+			}
+			return false
+		case "FunctionCall":
+			if opcode, ok := opcodeBuiltins[n.child("expression").name()]; ok && n.child("expression").nodeType() == "Identifier" {
+				record(parents, opcode)
+			}
+		}
+		return true
+	})
+
+	findings := make([]common.Finding, 0, len(uses))
+	for _, u := range uses {
+		findings = append(findings, newFinding("forbidden-opcode", common.SeverityError, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s uses forbidden opcode %s", t.name, u.function, u.opcode)))
+	}
+	return findings, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckForbiddenOpcodes(t *testing.T) {
+	artifact := `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+			{"nodeType":"FunctionDefinition","name":"kill","body":{"nodeType":"Block","statements":[
+				{"nodeType":"ExpressionStatement","expression":{"nodeType":"FunctionCall",
+					"expression":{"nodeType":"Identifier","name":"suicide"},"arguments":[]}}
+			]}},
+			{"nodeType":"FunctionDefinition","name":"peek","body":{"nodeType":"Block","statements":[
+				{"nodeType":"InlineAssembly","AST":{"nodeType":"YulBlock","statements":[
+					{"nodeType":"YulExpressionStatement","expression":{"nodeType":"YulFunctionCall",
+						"functionName":{"nodeType":"YulIdentifier","name":"extcodecopy"},"arguments":[]}},
+					{"nodeType":"YulExpressionStatement","expression":{"nodeType":"YulFunctionCall",
+						"functionName":{"nodeType":"YulIdentifier","name":"extcodecopy"},"arguments":[]}}
+				]}}
+			]}}
+		]}
+	]}}`
+
+	t.Run("flags builtins and assembly", func(t *testing.T) {
+		setConfig(t, &Config{ForbiddenOpcodes: ForbiddenOpcodesConfig{Opcodes: []string{"SELFDESTRUCT", "extcodecopy"}}})
+		findings, err := checkForbiddenOpcodes(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, "Test.kill uses forbidden opcode selfdestruct", findings[0].Message)
+		require.Equal(t, "Test.peek uses forbidden opcode extcodecopy", findings[1].Message)
+	})
+
+	t.Run("contract patterns", func(t *testing.T) {
+		setConfig(t, &Config{ForbiddenOpcodes: ForbiddenOpcodesConfig{Opcodes: []string{"selfdestruct"}, Contracts: []string{"L2*"}}})
+		findings, err := checkForbiddenOpcodes(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("disabled without opcodes", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkForbiddenOpcodes(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// ArtifactMetadata is the part of an artifact's compiler metadata the checks use. Forge writes
+// the metadata as an object; solc's own output embeds it as a JSON string, which is accepted too.
+type ArtifactMetadata struct {
+	Compiler struct {
+		Version string `json:"version"`
+	} `json:"compiler"`
+	Settings struct {
+		Optimizer  *OptimizerSettings `json:"optimizer"`
+		EVMVersion string             `json:"evmVersion"`
+		ViaIR      bool               `json:"viaIR"`
+	} `json:"settings"`
+}
+
+type OptimizerSettings struct {
+	Enabled bool `json:"enabled"`
+	Runs    int  `json:"runs"`
+}
+
+func (m *ArtifactMetadata) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var embedded string
+		if err := json.Unmarshal(data, &embedded); err != nil {
+			return err
+		}
+		data = []byte(embedded)
+	}
+	// The alias drops this method so that the object is decoded normally.
+	type metadata ArtifactMetadata
+	return json.Unmarshal(data, (*metadata)(m))
+}
+
+// checkOptimizerSettings warns when an interface was compiled with different optimizer settings
+// than its corresponding contract. The ABI comparison assumes both come from the same build
+// profile, so a difference points at a profile mismatch rather than at the sources.
+func checkOptimizerSettings(t *checkTarget) ([]common.Finding, error) {
+	if t.definition.ContractKind != "interface" || !strings.HasPrefix(t.name, "I") ||
+		config.isExcluded("optimizer-settings", t.name) {
+		return nil, nil
+	}
+	interfaceOptimizer := optimizerSettings(t.artifact)
+	if interfaceOptimizer == nil {
+		return nil, nil
+	}
+
+	contractName := t.name[1:]
+	contractPath, err := contractArtifactPath(contractName)
+	if err != nil {
+		return nil, err
+	}
+	contractArtifact, err := readArtifact(contractPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corresponding contract artifact: %w", err)
+	}
+	contractOptimizer := optimizerSettings(contractArtifact)
+	if contractOptimizer == nil {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	differs := func(setting string, interfaceValue, contractValue any) {
+		findings = append(findings, newFinding("optimizer-settings", common.SeverityWarning, t.sourcePath(), t.name,
+			fmt.Sprintf("%s is compiled with optimizer %s %v but %s with %v; build both with the same profile",
+				t.name, setting, interfaceValue, contractName, contractValue)))
+	}
+	if interfaceOptimizer.Enabled != contractOptimizer.Enabled {
+		differs("enabled", interfaceOptimizer.Enabled, contractOptimizer.Enabled)
+	}
+	if interfaceOptimizer.Runs != contractOptimizer.Runs {
+		differs("runs", interfaceOptimizer.Runs, contractOptimizer.Runs)
+	}
+	return findings, nil
+}
+
+func optimizerSettings(artifact *Artifact) *OptimizerSettings {
+	if artifact.Metadata == nil {
+		return nil
+	}
+	return artifact.Metadata.Settings.Optimizer
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactMetadataEmbedded(t *testing.T) {
+	var metadata ArtifactMetadata
+	require.NoError(t, json.Unmarshal([]byte(`"{\"settings\":{\"optimizer\":{\"enabled\":true,\"runs\":200}}}"`), &metadata))
+	require.Equal(t, &OptimizerSettings{Enabled: true, Runs: 200}, metadata.Settings.Optimizer)
+}
+
+func TestCheckOptimizerSettings(t *testing.T) {
+	artifact := func(optimizer string) string {
+		return `{"abi":[],"metadata":{"settings":{"optimizer":` + optimizer + `}}}`
+	}
+	withMetadata := func(t *testing.T, optimizer string) *checkTarget {
+		target := interfaceTarget(t, `[]`)
+		require.NoError(t, json.Unmarshal([]byte(`{"settings":{"optimizer":`+optimizer+`}}`), &target.artifact.Metadata))
+		return target
+	}
+
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Test.sol/Test.json": artifact(`{"enabled":true,"runs":999999}`),
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+	setConfig(t, &Config{})
+
+	t.Run("same settings", func(t *testing.T) {
+		findings, err := checkOptimizerSettings(withMetadata(t, `{"enabled":true,"runs":999999}`))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("different settings", func(t *testing.T) {
+		findings, err := checkOptimizerSettings(withMetadata(t, `{"enabled":false,"runs":200}`))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "ITest is compiled with optimizer enabled false but Test with true; build both with the same profile", findings[0].Message)
+		require.Equal(t, "ITest is compiled with optimizer runs 200 but Test with 999999; build both with the same profile", findings[1].Message)
+	})
+
+	t.Run("no metadata", func(t *testing.T) {
+		findings, err := checkOptimizerSettings(interfaceTarget(t, `[]`))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
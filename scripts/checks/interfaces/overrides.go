@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+var dataLocationRegex = regexp.MustCompile(` (calldata|memory|storage)( pointer| ref)?\b`)
+
+// functionSignature is the shape of a function definition as declared in the AST.
+type functionSignature struct {
+	contract string
+	name     string
+	params   []string
+	returns  []string
+}
+
+// overridingFunction is a source function that overrides one or more base functions.
+type overridingFunction struct {
+	functionSignature
+	file  string
+	bases []astKey
+}
+
+// astKey identifies an AST node across artifacts. Node ids are only unique within a single
+// compiler run, so they are qualified by the build the artifact came from.
+type astKey struct {
+	build string
+	id    int
+}
+
+// compilerBuild identifies the compiler run an artifact came from as far as its metadata tells:
+// forge compiles the sources for each compiler version and settings profile in a run of its own.
+// Artifacts without metadata share the empty build.
+func compilerBuild(a *Artifact) string {
+	if a.Metadata == nil {
+		return ""
+	}
+	build := fmt.Sprintf("%s evm=%s viaIR=%t", a.Metadata.Compiler.Version, a.Metadata.Settings.EVMVersion, a.Metadata.Settings.ViaIR)
+	if optimizer := a.Metadata.Settings.Optimizer; optimizer != nil {
+		build += fmt.Sprintf(" optimizer=%t/%d", optimizer.Enabled, optimizer.Runs)
+	}
+	return build
+}
+
+// overrideIndex records every function definition by build and AST id so that overrides can be
+// checked against their base declarations, which usually live in other artifacts of the same
+// build.
+type overrideIndex struct {
+	mtx       sync.Mutex
+	functions map[astKey]functionSignature
+	overrides []overridingFunction
+}
+
+func newOverrideIndex() *overrideIndex {
+	return &overrideIndex{functions: make(map[astKey]functionSignature)}
+}
+
+var overriddenFunctions = newOverrideIndex()
+
+func (o *overrideIndex) run(t *checkTarget) ([]common.Finding, error) {
+	tree := t.artifact.tree()
+	if tree == nil {
+		return nil, nil
+	}
+
+	build := compilerBuild(t.artifact)
+	functions := make(map[astKey]functionSignature)
+	var overrides []overridingFunction
+	walkAST(tree, func(n astNode, parents []astNode) bool {
+		if n.nodeType() != "FunctionDefinition" {
+			return true
+		}
+		sig := astFunctionSignature(n, parents)
+		if id, ok := astID(n); ok {
+			functions[astKey{build, id}] = sig
+		}
+
+		var bases []astKey
+		for _, id := range astIDs(n["baseFunctions"]) {
+			bases = append(bases, astKey{build, id})
+		}
+		if t.isSource() && sig.contract == t.name && len(bases) > 0 {
+			overrides = append(overrides, overridingFunction{functionSignature: sig, file: t.sourcePath(), bases: bases})
+		}
+		return false
+	})
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	for key, sig := range functions {
+		o.functions[key] = sig
+	}
+	o.overrides = append(o.overrides, overrides...)
+	return nil, nil
+}
+
+func (o *overrideIndex) finish() []common.Finding {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	var findings []common.Finding
+	for _, fn := range o.overrides {
+		for _, baseKey := range fn.bases {
+			base, ok := o.functions[baseKey]
+			// Builds are told apart by their settings, so two runs with the same settings could
+			// still reuse an id; ignore ids that resolve to a differently named function.
+			if !ok || base.name != fn.name {
+				continue
+			}
+
+			var diffs []string
+			if !slices.Equal(base.params, fn.params) {
+				diffs = append(diffs, fmt.Sprintf("takes (%s) instead of (%s)", strings.Join(fn.params, ", "), strings.Join(base.params, ", ")))
+			}
+			if !slices.Equal(base.returns, fn.returns) {
+				diffs = append(diffs, fmt.Sprintf("returns (%s) instead of (%s)", strings.Join(fn.returns, ", "), strings.Join(base.returns, ", ")))
+			}
+			if len(diffs) == 0 {
+				continue
+			}
+			findings = append(findings, newFinding("override-signature", common.SeverityError, fn.file, fn.contract,
+				fmt.Sprintf("%s.%s overrides %s.%s but %s", fn.contract, fn.name, base.contract, base.name, strings.Join(diffs, " and "))))
+		}
+	}
+	return findings
+}
+
+func astFunctionSignature(fn astNode, parents []astNode) functionSignature {
+	sig := functionSignature{
+		name:    functionLabel(fn),
+		params:  astParamTypes(fn.child("parameters")),
+		returns: astParamTypes(fn.child("returnParameters")),
+	}
+	for i := len(parents) - 1; i >= 0; i-- {
+		if parents[i].nodeType() == "ContractDefinition" {
+			sig.contract = parents[i].name()
+			break
+		}
+	}
+	return sig
+}
+
+// astParamTypes returns the types of a ParameterList with data locations stripped, since an
+// override may legitimately change calldata to memory.
+func astParamTypes(list astNode) []string {
+	params := list.children("parameters")
+	out := make([]string, 0, len(params))
+	for _, param := range params {
+		typeString := getString(param.child("typeDescriptions"), "typeString")
+		out = append(out, dataLocationRegex.ReplaceAllString(typeString, ""))
+	}
+	return out
+}
+
+func astID(n astNode) (int, bool) {
+	id, ok := n["id"].(float64)
+	return int(id), ok
+}
+
+func astIDs(raw interface{}) []int {
+	list, _ := raw.([]interface{})
+	out := make([]int, 0, len(list))
+	for _, elem := range list {
+		if id, ok := elem.(float64); ok {
+			out = append(out, int(id))
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+const overrideBaseArtifact = `{"abi":[],"ast":{"absolutePath":"src/Base.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Base","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","id":10,"name":"version",
+			"parameters":{"nodeType":"ParameterList","parameters":[]},
+			"returnParameters":{"nodeType":"ParameterList","parameters":[{"nodeType":"VariableDeclaration","typeDescriptions":{"typeString":"string memory"}}]}},
+		{"nodeType":"FunctionDefinition","id":11,"name":"data",
+			"parameters":{"nodeType":"ParameterList","parameters":[{"nodeType":"VariableDeclaration","typeDescriptions":{"typeString":"bytes calldata"}}]},
+			"returnParameters":{"nodeType":"ParameterList","parameters":[]}}
+	]}
+]}}`
+
+const overrideChildArtifact = `{"abi":[],"ast":{"absolutePath":"src/Child.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Child","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","id":20,"name":"version","baseFunctions":[10],
+			"parameters":{"nodeType":"ParameterList","parameters":[]},
+			"returnParameters":{"nodeType":"ParameterList","parameters":[{"nodeType":"VariableDeclaration","typeDescriptions":{"typeString":"bytes32"}}]}},
+		{"nodeType":"FunctionDefinition","id":21,"name":"data","baseFunctions":[11],
+			"parameters":{"nodeType":"ParameterList","parameters":[{"nodeType":"VariableDeclaration","typeDescriptions":{"typeString":"bytes memory"}}]},
+			"returnParameters":{"nodeType":"ParameterList","parameters":[]}},
+		{"nodeType":"FunctionDefinition","id":22,"name":"other","baseFunctions":[11],
+			"parameters":{"nodeType":"ParameterList","parameters":[]},
+			"returnParameters":{"nodeType":"ParameterList","parameters":[]}}
+	]}
+]}}`
+
+func TestOverrideIndex(t *testing.T) {
+	index := newOverrideIndex()
+	for name, src := range map[string]string{"Base": overrideBaseArtifact, "Child": overrideChildArtifact} {
+		var artifact Artifact
+		require.NoError(t, json.Unmarshal([]byte(src), &artifact))
+		_, err := index.run(&checkTarget{name: name, artifact: &artifact})
+		require.NoError(t, err)
+	}
+
+	findings := index.finish()
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityError, findings[0].Severity)
+	require.Equal(t, "src/Child.sol", findings[0].File)
+	require.Equal(t, "Child.version overrides Base.version but returns (bytes32) instead of (string)", findings[0].Message)
+}
+
+func TestOverrideIndexSeparatesBuilds(t *testing.T) {
+	withCompiler := func(src, version string) string {
+		return strings.Replace(src, `{"abi":[],`, `{"abi":[],"metadata":{"compiler":{"version":"`+version+`"}},`, 1)
+	}
+	// The child is compiled with a base whose version() matches it. Another build reuses the base's
+	// AST ids for the old base, which must not be mistaken for the child's.
+	matchingBase := strings.Replace(overrideBaseArtifact, `"string memory"`, `"bytes32"`, 1)
+	index := newOverrideIndex()
+	for name, src := range map[string]string{
+		"Base":    withCompiler(matchingBase, "0.8.15"),
+		"Child":   withCompiler(overrideChildArtifact, "0.8.15"),
+		"OldBase": withCompiler(strings.ReplaceAll(overrideBaseArtifact, "Base", "OldBase"), "0.8.19"),
+	} {
+		var artifact Artifact
+		require.NoError(t, json.Unmarshal([]byte(src), &artifact))
+		_, err := index.run(&checkTarget{name: name, artifact: &artifact})
+		require.NoError(t, err)
+	}
+	require.Empty(t, index.finish())
+}
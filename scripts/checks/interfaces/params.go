@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// defaultMaxParameters is used when the config does not set a parameter limit.
+const defaultMaxParameters = 6
+
+// checkParameterCount warns about functions declared by a source contract that take more
+// parameters than the configured limit, which usually means a struct parameter is missing. A
+// function can opt out with ignoreTag.
+func checkParameterCount(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("parameter-count", t.name) {
+		return nil, nil
+	}
+
+	limit := config.Parameters.Max
+	if limit <= 0 {
+		limit = defaultMaxParameters
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	declared := declaredFunctions(t.artifact.contractNode(t.name))
+
+	var findings []common.Finding
+	for _, item := range items {
+		if getString(item, "type") != "function" {
+			continue
+		}
+		inputs, _ := item["inputs"].([]interface{})
+		if len(inputs) <= limit {
+			continue
+		}
+		fn, ok := declared[abiParamNamesKey(item)]
+		if declared != nil && (!ok || hasIgnoreTag(fn, "parameter-count")) {
+			continue
+		}
+		findings = append(findings, newFinding("parameter-count", common.SeverityWarning, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s takes %d parameters (limit %d); consider grouping them into a struct",
+				t.name, getString(item, "name"), len(inputs), limit)))
+	}
+	return findings, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// parameterArtifact declares a three-parameter function, an opted-out three-parameter function
+// and a one-parameter function.
+const parameterArtifact = `{"abi":[
+	{"type":"function","name":"initialize","inputs":[
+		{"name":"_a","type":"address"},{"name":"_b","type":"address"},{"name":"_c","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"legacy","inputs":[
+		{"name":"_a","type":"address"},{"name":"_b","type":"address"},{"name":"_c","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"set","inputs":[{"name":"_a","type":"address"}],"outputs":[]}
+],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"initialize","parameters":{"nodeType":"ParameterList","parameters":[
+			{"nodeType":"VariableDeclaration","name":"_a"},{"nodeType":"VariableDeclaration","name":"_b"},{"nodeType":"VariableDeclaration","name":"_c"}]}},
+		{"nodeType":"FunctionDefinition","name":"legacy",
+			"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore parameter-count"},
+			"parameters":{"nodeType":"ParameterList","parameters":[
+			{"nodeType":"VariableDeclaration","name":"_a"},{"nodeType":"VariableDeclaration","name":"_b"},{"nodeType":"VariableDeclaration","name":"_c"}]}},
+		{"nodeType":"FunctionDefinition","name":"set","parameters":{"nodeType":"ParameterList","parameters":[
+			{"nodeType":"VariableDeclaration","name":"_a"}]}}
+	]}
+]}}`
+
+func TestCheckParameterCount(t *testing.T) {
+	t.Run("under default limit", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkParameterCount(delegatecallTarget(t, parameterArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("over configured limit", func(t *testing.T) {
+		setConfig(t, &Config{Parameters: ParametersConfig{Max: 2}})
+		findings, err := checkParameterCount(delegatecallTarget(t, parameterArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.initialize takes 3 parameters (limit 2); consider grouping them into a struct", findings[0].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Parameters: ParametersConfig{Max: 2}, Exclude: map[string][]string{"parameter-count": {"Test"}}})
+		findings, err := checkParameterCount(delegatecallTarget(t, parameterArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
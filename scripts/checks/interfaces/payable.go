@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// receivesETHMarkerRegex matches the `// @checks:receives-eth` comment that an interface must
+// carry when its contract can receive ETH, since interfaces cannot declare receive().
+var receivesETHMarkerRegex = regexp.MustCompile(`(?m)^\s*//+\s*@checks:receives-eth\b`)
+
+// checkReceivesETHMarker fails when a source contract has a receive function or a payable
+// fallback but its interface does not document that with the receives-eth marker.
+func checkReceivesETHMarker(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || t.definition.ContractKind != "contract" || config.isExcluded("receives-eth", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	entry := ""
+	for _, item := range items {
+		switch itemType := getString(item, "type"); {
+		case itemType == "receive":
+			entry = "a receive function"
+		case itemType == "fallback" && getString(item, "stateMutability") == "payable" && entry == "":
+			entry = "a payable fallback"
+		}
+	}
+	if entry == "" {
+		return nil, nil
+	}
+
+	// A missing interface is reported by verifyAllContractsHaveInterfaces.
+	interfacePath, ok := interfaceForSource(t.sourcePath(), t.name)
+	if !ok {
+		return nil, nil
+	}
+	content, err := os.ReadFile(interfacePath)
+	if err != nil {
+		return nil, err
+	}
+	if receivesETHMarkerRegex.Match(content) {
+		return nil, nil
+	}
+	return []common.Finding{newFinding("receives-eth", common.SeverityError, t.sourcePath(), t.name,
+		fmt.Sprintf("%s has %s but %s lacks a `// @checks:receives-eth` comment", t.name, entry, repoRelative(interfacePath)))}, nil
+}
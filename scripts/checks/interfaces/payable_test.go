@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReceivesETHMarker(t *testing.T) {
+	receive := `[{"type":"receive","stateMutability":"payable"}]`
+	payableFallback := `[{"type":"fallback","stateMutability":"payable"}]`
+	fallback := `[{"type":"fallback","stateMutability":"nonpayable"}]`
+
+	setupSourceFixture(t, map[string]string{"interfaces/ITest.sol": "interface ITest {}\n"})
+	setConfig(t, &Config{})
+
+	findings, err := checkReceivesETHMarker(abiTarget(t, receive))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityError, findings[0].Severity)
+	require.Equal(t, "Test has a receive function but interfaces/ITest.sol lacks a `// @checks:receives-eth` comment", findings[0].Message)
+
+	findings, err = checkReceivesETHMarker(abiTarget(t, payableFallback))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Contains(t, findings[0].Message, "a payable fallback")
+
+	findings, err = checkReceivesETHMarker(abiTarget(t, fallback))
+	require.NoError(t, err)
+	require.Empty(t, findings)
+
+	require.NoError(t, os.WriteFile("interfaces/ITest.sol", []byte("// @checks:receives-eth\ninterface ITest {}\n"), 0644))
+	findings, err = checkReceivesETHMarker(abiTarget(t, receive))
+	require.NoError(t, err)
+	require.Empty(t, findings)
+
+	require.NoError(t, os.Remove("interfaces/ITest.sol"))
+	findings, err = checkReceivesETHMarker(abiTarget(t, receive))
+	require.NoError(t, err)
+	require.Empty(t, findings)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// lcovCoverage maps source files, as named by SF records, to the hit counts of their functions,
+// keyed by the names of FNDA records such as "OptimismPortal2.depositTransaction".
+type lcovCoverage map[string]map[string]int
+
+// payableCoverageCache holds the report loaded from PayableCoverageConfig.Report.
+type payableCoverageCache struct {
+	once     sync.Once
+	coverage lcovCoverage
+	err      error
+}
+
+var payableCoverage = &payableCoverageCache{}
+
+// loadPayableCoverage reads the coverage report once per run. A report that does not exist,
+// e.g. because coverage was not collected for this run, yields nil without an error.
+func loadPayableCoverage() (lcovCoverage, error) {
+	payableCoverage.once.Do(func() {
+		path := config.PayableCoverage.Report
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cwd, path)
+		}
+		file, err := os.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			if verbose {
+				log.Printf("payable-coverage: no coverage report at %s, skipping", path)
+			}
+			return
+		}
+		if err != nil {
+			payableCoverage.err = fmt.Errorf("failed to read coverage report: %w", err)
+			return
+		}
+		defer file.Close()
+		if payableCoverage.coverage, err = parseLcov(file); err != nil {
+			payableCoverage.err = fmt.Errorf("failed to parse coverage report %s: %w", path, err)
+		}
+	})
+	return payableCoverage.coverage, payableCoverage.err
+}
+
+// parseLcov reads the function hit counts of an lcov tracefile, as written by forge coverage
+// --report lcov. Hits of functions that share a name, such as overloads, are added up.
+func parseLcov(r io.Reader) (lcovCoverage, error) {
+	coverage := make(lcovCoverage)
+	var functions map[string]int
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		record, value, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		switch record {
+		case "SF":
+			functions = coverage[filepath.ToSlash(value)]
+			if functions == nil {
+				functions = make(map[string]int)
+				coverage[filepath.ToSlash(value)] = functions
+			}
+		case "FNDA":
+			hits, name, ok := strings.Cut(value, ",")
+			count, err := strconv.Atoi(hits)
+			if !ok || err != nil || functions == nil {
+				return nil, fmt.Errorf("line %d: invalid FNDA record %q", line, value)
+			}
+			functions[name] += count
+		case "end_of_record":
+			functions = nil
+		}
+	}
+	return coverage, scanner.Err()
+}
+
+// checkPayableCoverage warns about payable functions of a source contract that the coverage
+// report lists with no hits, since ETH-handling paths without tests are high-risk. Files and
+// functions the report does not list are not reported, and the check is disabled without a
+// report. A function can opt out with ignoreTag.
+func checkPayableCoverage(t *checkTarget) ([]common.Finding, error) {
+	if config.PayableCoverage.Report == "" || !t.isSource() || t.definition.ContractKind != "contract" ||
+		config.isExcluded("payable-coverage", t.name) {
+		return nil, nil
+	}
+
+	coverage, err := loadPayableCoverage()
+	if err != nil {
+		return nil, err
+	}
+	functions, ok := coverage[t.sourcePath()]
+	if !ok {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	for _, fn := range t.artifact.contractNode(t.name).children("nodes") {
+		if fn.nodeType() != "FunctionDefinition" || getString(fn, "stateMutability") != "payable" ||
+			getString(fn, "kind") == "constructor" || hasIgnoreTag(fn, "payable-coverage") {
+			continue
+		}
+		name := fn.name()
+		if name == "" {
+			name = getString(fn, "kind") // receive or fallback
+		}
+		hits, ok := functions[t.name+"."+name]
+		if !ok {
+			hits, ok = functions[name]
+		}
+		if !ok || hits > 0 {
+			continue
+		}
+		findings = append(findings, newFinding("payable-coverage", common.SeverityWarning, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s is payable but no test reaches it according to %s",
+				t.name, name, filepath.ToSlash(config.PayableCoverage.Report))))
+	}
+	return findings, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+const payableArtifact = `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"","kind":"constructor","stateMutability":"payable"},
+		{"nodeType":"FunctionDefinition","name":"deposit","kind":"function","stateMutability":"payable"},
+		{"nodeType":"FunctionDefinition","name":"donate","kind":"function","stateMutability":"payable"},
+		{"nodeType":"FunctionDefinition","name":"sweep","kind":"function","stateMutability":"payable",
+			"documentation":{"text":"@custom:interfaces-ignore payable-coverage"}},
+		{"nodeType":"FunctionDefinition","name":"withdraw","kind":"function","stateMutability":"nonpayable"},
+		{"nodeType":"FunctionDefinition","name":"","kind":"receive","stateMutability":"payable"}
+	]}
+]}}`
+
+const payableLcov = `TN:
+SF:src/Test.sol
+FN:10,Test.deposit
+FNDA:3,Test.deposit
+FN:20,Test.donate
+FNDA:0,Test.donate
+FN:30,Test.sweep
+FNDA:0,Test.sweep
+FN:40,Test.withdraw
+FNDA:0,Test.withdraw
+FN:50,Test.receive
+FNDA:0,Test.receive
+end_of_record
+`
+
+func TestCheckPayableCoverage(t *testing.T) {
+	setupSourceFixture(t, map[string]string{"lcov.info": payableLcov})
+	reset := func() {
+		payableCoverage = &payableCoverageCache{}
+	}
+	reset()
+	t.Cleanup(reset)
+
+	t.Run("flags uncovered payable functions", func(t *testing.T) {
+		setConfig(t, &Config{PayableCoverage: PayableCoverageConfig{Report: "lcov.info"}})
+		findings, err := checkPayableCoverage(delegatecallTarget(t, payableArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.donate is payable but no test reaches it according to lcov.info", findings[0].Message)
+		require.Equal(t, "Test.receive is payable but no test reaches it according to lcov.info", findings[1].Message)
+	})
+
+	t.Run("missing report", func(t *testing.T) {
+		reset()
+		t.Cleanup(reset)
+		setConfig(t, &Config{PayableCoverage: PayableCoverageConfig{Report: "missing.info"}})
+		findings, err := checkPayableCoverage(delegatecallTarget(t, payableArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{PayableCoverage: PayableCoverageConfig{Report: "lcov.info"},
+			Exclude: map[string][]string{"payable-coverage": {"Test"}}})
+		findings, err := checkPayableCoverage(delegatecallTarget(t, payableArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
+
+func TestParseLcov(t *testing.T) {
+	coverage, err := parseLcov(strings.NewReader("SF:src/A.sol\nFNDA:1,A.f\nFNDA:2,A.f\nend_of_record\n"))
+	require.NoError(t, err)
+	require.Equal(t, lcovCoverage{"src/A.sol": {"A.f": 3}}, coverage)
+
+	_, err = parseLcov(strings.NewReader("SF:src/A.sol\nFNDA:many,A.f\n"))
+	require.EqualError(t, err, `line 2: invalid FNDA record "many,A.f"`)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// requiredInterfacePragma is the pragma every interface must declare, as the concatenated
+// literals of its PragmaDirective.
+const requiredInterfacePragma = "solidity^0.8.0"
+
+// PragmaExemption lets interfaces whose names match Interfaces declare Pragma instead of
+// requiredInterfacePragma, e.g. vendored interfaces that must compile with older compilers.
+type PragmaExemption struct {
+	// Interfaces is a glob pattern over interface names, e.g. "IERC20".
+	Interfaces string `json:"interfaces"`
+	// Pragma is the version pragma the interfaces must declare, e.g. ">=0.6.2 <0.9.0". Whitespace
+	// and the "pragma solidity" prefix are optional.
+	Pragma string `json:"pragma"`
+}
+
+// checkInterfacePragma returns a message when the pragma of the named interface, given as the
+// concatenated literals of its PragmaDirective, is neither requiredInterfacePragma nor the one
+// allowed by the first matching pragmaExempt entry.
+func checkInterfacePragma(name, pragma string) (string, error) {
+	for _, exemption := range config.PragmaExempt {
+		ok, err := path.Match(exemption.Interfaces, name)
+		if err != nil {
+			return "", fmt.Errorf("invalid pragmaExempt pattern %q: %w", exemption.Interfaces, err)
+		}
+		if !ok {
+			continue
+		}
+		allowed := normalizePragma(exemption.Pragma)
+		if pragma == allowed {
+			return "", nil
+		}
+		return fmt.Sprintf("%s: interface declares pragma %s but pragmaExempt allows only %s", name, pragma, allowed), nil
+	}
+	if pragma != requiredInterfacePragma {
+		return fmt.Sprintf("%s: interface does not have correct compiler version (MUST be exactly solidity ^0.8.0)", name), nil
+	}
+	return "", nil
+}
+
+// normalizePragma rewrites a configured pragma into the form getContractSemver returns, e.g.
+// "pragma solidity >=0.6.2 <0.9.0;" to "solidity>=0.6.2<0.9.0".
+func normalizePragma(pragma string) string {
+	pragma = strings.TrimSuffix(strings.TrimSpace(pragma), ";")
+	pragma = strings.TrimPrefix(pragma, "pragma ")
+	pragma = strings.Join(strings.Fields(pragma), "")
+	if !strings.HasPrefix(pragma, "solidity") {
+		pragma = "solidity" + pragma
+	}
+	return pragma
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInterfacePragma(t *testing.T) {
+	setConfig(t, &Config{PragmaExempt: []PragmaExemption{
+		{Interfaces: "IERC20*", Pragma: "pragma solidity >=0.6.2 <0.9.0;"},
+		{Interfaces: "ILegacy", Pragma: "^0.7.0"},
+	}})
+
+	for name, pragma := range map[string]string{"ITest": "solidity^0.8.0", "IERC20Metadata": "solidity>=0.6.2<0.9.0", "ILegacy": "solidity^0.7.0"} {
+		msg, err := checkInterfacePragma(name, pragma)
+		require.NoError(t, err)
+		require.Empty(t, msg, name)
+	}
+
+	msg, err := checkInterfacePragma("ITest", "solidity^0.8.15")
+	require.NoError(t, err)
+	require.Equal(t, "ITest: interface does not have correct compiler version (MUST be exactly solidity ^0.8.0)", msg)
+
+	msg, err = checkInterfacePragma("IERC20", "solidity^0.8.0")
+	require.NoError(t, err)
+	require.Equal(t, "IERC20: interface declares pragma solidity^0.8.0 but pragmaExempt allows only solidity>=0.6.2<0.9.0", msg)
+
+	setConfig(t, &Config{PragmaExempt: []PragmaExemption{{Interfaces: "[", Pragma: "^0.7.0"}}})
+	_, err = checkInterfacePragma("ITest", "solidity^0.8.0")
+	require.ErrorContains(t, err, `invalid pragmaExempt pattern "["`)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// defaultPredeploysLibrary is the library whose address constants are the canonical predeploy
+// addresses.
+const defaultPredeploysLibrary = "Predeploys"
+
+// predeployNamespacePrefix is the first 37 hex digits shared by the 2048 addresses of the
+// predeploy namespace, 0x4200...0000 to 0x4200...07ff, as in Predeploys.isPredeployNamespace.
+const predeployNamespacePrefix = "0x4200000000000000000000000000000000000"
+
+// predeployTagRegex matches the address in a `@custom:predeploy` or `@custom:predeployed` tag.
+var predeployTagRegex = regexp.MustCompile(`@custom:predeploy(?:ed)?\s+(0x[0-9a-fA-F]{40})\b`)
+
+// predeployConstants caches the address constants of predeploy libraries, keyed by artifacts
+// directory and library name.
+var predeployConstants sync.Map
+
+// checkPredeployAddresses fails when a source contract spells out a predeploy address that
+// differs from the canonical one: an address constant named like a constant of the predeploys
+// library with another value, or an address in the predeploy namespace, in code or in a
+// `@custom:predeploy` tag, that is not a predeploy at all. The library itself is the source of
+// truth and is not checked.
+func checkPredeployAddresses(t *checkTarget) ([]common.Finding, error) {
+	library := config.Predeploys.Library
+	if library == "" {
+		library = defaultPredeploysLibrary
+	}
+	if !t.isSource() || t.name == library || config.isExcluded("predeploy-address", t.name) {
+		return nil, nil
+	}
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	canonical, err := canonicalPredeploys(library)
+	if err != nil {
+		return nil, err
+	}
+	if len(canonical) == 0 {
+		return nil, nil
+	}
+	predeploys := make(map[string]bool, len(canonical))
+	for _, address := range canonical {
+		predeploys[strings.ToLower(address)] = true
+	}
+	// unknown reports whether address is in the predeploy namespace without being a predeploy.
+	unknown := func(address string) bool {
+		address = strings.ToLower(address)
+		return strings.HasPrefix(address, predeployNamespacePrefix) && address[len(predeployNamespacePrefix)] < '8' &&
+			!predeploys[address]
+	}
+
+	var findings []common.Finding
+	report := func(msg string) {
+		findings = append(findings, newFinding("predeploy-address", common.SeverityError, t.sourcePath(), t.name, msg))
+	}
+	if match := predeployTagRegex.FindStringSubmatch(getString(node.child("documentation"), "text")); match != nil && unknown(match[1]) {
+		report(fmt.Sprintf("%s is documented as predeploy %s, which is not a predeploy in %s", t.name, match[1], library))
+	}
+	walkAST(node, func(n astNode, parents []astNode) bool {
+		if found, ok := addressConstant(n); ok {
+			if expected, ok := canonical[n.name()]; ok && !strings.EqualFold(expected, found) {
+				report(fmt.Sprintf("%s.%s is %s but %s.%s is %s", t.name, n.name(), found, library, n.name(), expected))
+			} else if !ok && unknown(found) {
+				report(fmt.Sprintf("%s.%s is %s, which is not a predeploy in %s", t.name, n.name(), found, library))
+			}
+			return false
+		}
+		if n.nodeType() == "Literal" && getString(n, "kind") == "number" && isAddressLiteral(getString(n, "value")) &&
+			unknown(getString(n, "value")) {
+			report(fmt.Sprintf("%s.%s uses %s, which is not a predeploy in %s",
+				t.name, functionLabel(enclosingFunction(parents)), getString(n, "value"), library))
+		}
+		return true
+	})
+	return findings, nil
+}
+
+// canonicalPredeploys returns the canonical predeploy addresses by constant name: the address
+// constants of library, overridden by predeploys.addresses.
+func canonicalPredeploys(library string) (map[string]string, error) {
+	key := artifactsDir + "\x00" + library
+	cached, ok := predeployConstants.Load(key)
+	if !ok {
+		constants := make(map[string]string)
+		path, found, err := artifactPathForContract(library)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if found {
+			artifact, err := readArtifact(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read artifact of %s: %w", library, err)
+			}
+			for _, decl := range artifact.contractNode(library).children("nodes") {
+				if address, ok := addressConstant(decl); ok {
+					constants[decl.name()] = address
+				}
+			}
+		}
+		cached, _ = predeployConstants.LoadOrStore(key, constants)
+	}
+
+	canonical := make(map[string]string)
+	for name, address := range cached.(map[string]string) {
+		canonical[name] = address
+	}
+	for name, address := range config.Predeploys.Addresses {
+		canonical[name] = address
+	}
+	return canonical, nil
+}
+
+func isAddressLiteral(value string) bool {
+	return len(value) == 42 && strings.HasPrefix(value, "0x")
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPredeployAddresses(t *testing.T) {
+	constant := func(name, value string) string {
+		return `{"nodeType":"VariableDeclaration","name":"` + name + `","constant":true,
+			"typeName":{"nodeType":"ElementaryTypeName","name":"address"},
+			"value":{"nodeType":"Literal","kind":"number","value":"` + value + `"}}`
+	}
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Predeploys.sol/Predeploys.json": `{"abi":[],"ast":{"absolutePath":"src/libraries/Predeploys.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"Predeploys","contractKind":"library","nodes":[
+				` + constant("WETH", "0x4200000000000000000000000000000000000006") + `,
+				` + constant("L2_STANDARD_BRIDGE", "0x4200000000000000000000000000000000000010") + `
+			]}
+		]}}`,
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	artifact := `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract",
+			"documentation":{"text":"@custom:predeploy 0x4200000000000000000000000000000000000042"},"nodes":[
+			` + constant("WETH", "0x4200000000000000000000000000000000000006") + `,
+			` + constant("L2_STANDARD_BRIDGE", "0x4200000000000000000000000000000000000011") + `,
+			` + constant("OWNER", "0x4200000000000000000000000000000000000800") + `,
+			{"nodeType":"FunctionDefinition","name":"bridge","body":{"nodeType":"Block","statements":[
+				{"nodeType":"Return","expression":{"nodeType":"Literal","kind":"number","value":"0x420000000000000000000000000000000000001A"}}
+			]}}
+		]}
+	]}}`
+
+	t.Run("flags mismatches", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkPredeployAddresses(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 3)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, "Test is documented as predeploy 0x4200000000000000000000000000000000000042, which is not a predeploy in Predeploys",
+			findings[0].Message)
+		require.Equal(t, "Test.L2_STANDARD_BRIDGE is 0x4200000000000000000000000000000000000011 but Predeploys.L2_STANDARD_BRIDGE is 0x4200000000000000000000000000000000000010",
+			findings[1].Message)
+		require.Equal(t, "Test.bridge uses 0x420000000000000000000000000000000000001A, which is not a predeploy in Predeploys",
+			findings[2].Message)
+	})
+
+	t.Run("config addresses", func(t *testing.T) {
+		setConfig(t, &Config{Predeploys: PredeploysConfig{Addresses: map[string]string{
+			"L2_STANDARD_BRIDGE": "0x4200000000000000000000000000000000000011",
+			"L1_FEE_VAULT":       "0x420000000000000000000000000000000000001a",
+			"TEST":               "0x4200000000000000000000000000000000000042",
+		}}})
+		findings, err := checkPredeployAddresses(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("missing library", func(t *testing.T) {
+		setConfig(t, &Config{Predeploys: PredeploysConfig{Library: "L2Predeploys"}})
+		findings, err := checkPredeployAddresses(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// defaultProxyAdminPatterns match the names of proxy admin functions.
+var defaultProxyAdminPatterns = []string{"admin", "implementation", "upgradeTo*", "changeAdmin"}
+
+// defaultProxyContracts are the contracts allowed to declare proxy admin functions.
+var defaultProxyContracts = []string{"Proxy"}
+
+// checkProxyAdminFunctions flags source contracts that are not proxies but declare functions
+// named like proxy admin functions. Even when the interface agrees, such functions are confusing
+// behind a proxy and may be shadowed by it.
+func checkProxyAdminFunctions(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || t.definition.ContractKind != "contract" {
+		return nil, nil
+	}
+
+	proxies := config.ProxyAdmin.Proxies
+	if len(proxies) == 0 {
+		proxies = defaultProxyContracts
+	}
+	if slices.Contains(proxies, t.name) {
+		return nil, nil
+	}
+	patterns := config.ProxyAdmin.Patterns
+	if len(patterns) == 0 {
+		patterns = defaultProxyAdminPatterns
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []common.Finding
+	for _, item := range items {
+		if getString(item, "type") != "function" {
+			continue
+		}
+		pattern, err := matchingPattern(patterns, getString(item, "name"))
+		if err != nil {
+			return nil, err
+		}
+		if pattern == "" {
+			continue
+		}
+		findings = append(findings, newFinding("proxy-admin-function", common.SeverityError, t.sourcePath(), t.name,
+			fmt.Sprintf("%s declares %s, which looks like a proxy admin function (%q); add %s to the proxy allowlist if it is a proxy",
+				t.name, abiSignature(item), pattern, t.name)))
+	}
+	return findings, nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckProxyAdminFunctions(t *testing.T) {
+	abi := `[
+		{"type":"function","name":"implementation","inputs":[],"outputs":[{"type":"address"}]},
+		{"type":"function","name":"upgradeToAndCall","inputs":[{"type":"address"},{"type":"bytes"}],"outputs":[]},
+		{"type":"function","name":"upgrade","inputs":[{"type":"address"}],"outputs":[]}
+	]`
+
+	t.Run("flags admin functions", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkProxyAdminFunctions(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, `Test declares implementation(), which looks like a proxy admin function ("implementation"); add Test to the proxy allowlist if it is a proxy`, findings[0].Message)
+		require.Contains(t, findings[1].Message, `upgradeToAndCall(address,bytes)`)
+	})
+
+	t.Run("allowlisted proxy", func(t *testing.T) {
+		setConfig(t, &Config{ProxyAdmin: ProxyAdminConfig{Proxies: []string{"Test"}}})
+		findings, err := checkProxyAdminFunctions(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("configured patterns", func(t *testing.T) {
+		setConfig(t, &Config{ProxyAdmin: ProxyAdminConfig{Patterns: []string{"upgrade"}}})
+		findings, err := checkProxyAdminFunctions(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Contains(t, findings[0].Message, "upgrade(address)")
+	})
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// ignoreTag opts a single function out of a check, e.g.
+// `/// @custom:interfaces-ignore raw-bytes-param`.
+const ignoreTag = "@custom:interfaces-ignore"
+
+// genericBytesNames are parameter names that say nothing about what an untyped bytes payload
+// holds. Leading and trailing underscores are ignored.
+var genericBytesNames = []string{"", "data", "payload", "input", "args", "params", "bytes"}
+
+// checkRawBytesParams flags public functions that take a generically named bytes parameter, as a
+// prompt to consider whether it should be a typed struct. Opaque payloads are legitimate, so the
+// finding is informational unless --strict is set, and a function can opt out with ignoreTag.
+func checkRawBytesParams(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("raw-bytes-param", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	// The ABI includes inherited functions, so only report those the contract itself declares.
+	declared := declaredFunctions(t.artifact.contractNode(t.name))
+
+	severity := common.SeverityInfo
+	if strict {
+		severity = common.SeverityWarning
+	}
+
+	var findings []common.Finding
+	for _, item := range items {
+		if getString(item, "type") != "function" {
+			continue
+		}
+		fn, ok := declared[abiParamNamesKey(item)]
+		if declared != nil && (!ok || hasIgnoreTag(fn, "raw-bytes-param")) {
+			continue
+		}
+
+		inputs, _ := item["inputs"].([]interface{})
+		for _, input := range inputs {
+			param, ok := input.(map[string]interface{})
+			if !ok || getString(param, "type") != "bytes" || !isGenericBytesName(getString(param, "name")) {
+				continue
+			}
+			findings = append(findings, newFinding("raw-bytes-param", severity, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s takes untyped bytes parameter %q; consider whether it should be a typed struct",
+					t.name, abiSignature(item), getString(param, "name"))))
+		}
+	}
+	return findings, nil
+}
+
+func isGenericBytesName(name string) bool {
+	name = strings.ToLower(strings.Trim(name, "_"))
+	for _, generic := range genericBytesNames {
+		if name == generic {
+			return true
+		}
+	}
+	return false
+}
+
+// declaredFunctions indexes the function definitions directly inside contract by name and
+// parameter names, which is enough to tell overloads apart in practice without resolving
+// struct types. It returns nil when the contract node is unavailable.
+func declaredFunctions(contract astNode) map[string]astNode {
+	if contract == nil {
+		return nil
+	}
+	functions := make(map[string]astNode)
+	for _, node := range contract.children("nodes") {
+		if node.nodeType() != "FunctionDefinition" {
+			continue
+		}
+		var names []string
+		for _, param := range node.child("parameters").children("parameters") {
+			names = append(names, param.name())
+		}
+		functions[node.name()+"("+strings.Join(names, ",")+")"] = node
+	}
+	return functions
+}
+
+// abiParamNamesKey returns the declaredFunctions key for an ABI function item.
+func abiParamNamesKey(item map[string]interface{}) string {
+	inputs, _ := item["inputs"].([]interface{})
+	names := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		if param, ok := input.(map[string]interface{}); ok {
+			names = append(names, getString(param, "name"))
+		}
+	}
+	return getString(item, "name") + "(" + strings.Join(names, ",") + ")"
+}
+
+// hasIgnoreTag reports whether a definition's NatSpec opts it out of check via ignoreTag.
+func hasIgnoreTag(node astNode, check string) bool {
+	text := getString(node.child("documentation"), "text")
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == ignoreTag && fields[1] == check {
+			return true
+		}
+	}
+	return false
+}
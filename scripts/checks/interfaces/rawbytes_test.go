@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// rawBytesArtifact declares a generic bytes parameter, a descriptive one and an opted-out one,
+// and inherits a fourth function with a generic bytes parameter.
+const rawBytesArtifact = `{"abi":[
+	{"type":"function","name":"execute","inputs":[{"name":"_data","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"relay","inputs":[{"name":"message","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"forward","inputs":[{"name":"data","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"inherited","inputs":[{"name":"data","type":"bytes"}],"outputs":[]}
+],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"execute","parameters":{"nodeType":"ParameterList","parameters":[
+			{"nodeType":"VariableDeclaration","name":"_data"}]}},
+		{"nodeType":"FunctionDefinition","name":"relay","parameters":{"nodeType":"ParameterList","parameters":[
+			{"nodeType":"VariableDeclaration","name":"message"}]}},
+		{"nodeType":"FunctionDefinition","name":"forward",
+			"documentation":{"nodeType":"StructuredDocumentation","text":"@notice Forwards a call.\n @custom:interfaces-ignore raw-bytes-param"},
+			"parameters":{"nodeType":"ParameterList","parameters":[
+			{"nodeType":"VariableDeclaration","name":"data"}]}}
+	]}
+]}}`
+
+func TestCheckRawBytesParams(t *testing.T) {
+	t.Run("flags generic names", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkRawBytesParams(delegatecallTarget(t, rawBytesArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityInfo, findings[0].Severity)
+		require.Equal(t, `Test.execute(bytes) takes untyped bytes parameter "_data"; consider whether it should be a typed struct`, findings[0].Message)
+	})
+
+	t.Run("strict escalates", func(t *testing.T) {
+		setConfig(t, &Config{})
+		prev := strict
+		strict = true
+		t.Cleanup(func() { strict = prev })
+
+		findings, err := checkRawBytesParams(delegatecallTarget(t, rawBytesArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"raw-bytes-param": {"Test"}}})
+		findings, err := checkRawBytesParams(delegatecallTarget(t, rawBytesArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
+
+func TestIsGenericBytesName(t *testing.T) {
+	for _, name := range []string{"", "data", "_data", "data_", "Payload", "_params"} {
+		require.True(t, isGenericBytesName(name), name)
+	}
+	for _, name := range []string{"message", "signature", "initCode"} {
+		require.False(t, isGenericBytesName(name), name)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// releaseIncompatibleFlags are the flags that would let a --release run skip checks, skip
+// contracts or accept findings, keyed by flag name with the reason they are refused.
+var releaseIncompatibleFlags = map[string]string{
+	"select":          "every registered check runs",
+	"deselect":        "every registered check runs",
+	"selectors-only":  "interfaces are compared in full",
+	"compare-types":   "interfaces are compared in full",
+	"changed-only":    "the scan is never incremental",
+	"since":           "the scan is never incremental",
+	"staged":          "the scan is never incremental",
+	"shard":           "the scan is never sharded",
+	"merge":           "the scan is never sharded",
+	"contracts-list":  "the source roots are scanned in full",
+	"artifact":        "every artifact is checked",
+	"baseline":        "no baseline is applied",
+	"baseline-update": "no baseline is applied",
+}
+
+// releaseProfileError returns an error naming the flags set on fs that the --release profile
+// does not allow, or nil when there are none.
+func releaseProfileError(fs *flag.FlagSet) error {
+	var conflicts []string
+	fs.Visit(func(f *flag.Flag) {
+		if reason, ok := releaseIncompatibleFlags[f.Name]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("--%s (%s)", f.Name, reason))
+		}
+	})
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("--release cannot be combined with %s", strings.Join(conflicts, ", "))
+}
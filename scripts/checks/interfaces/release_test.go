@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseProfileError(t *testing.T) {
+	newFlags := func(args ...string) *flag.FlagSet {
+		fs := flag.NewFlagSet("interfaces", flag.ContinueOnError)
+		fs.Bool("release", false, "")
+		fs.Bool("staged", false, "")
+		fs.String("baseline", "", "")
+		fs.String("out", "", "")
+		require.NoError(t, fs.Parse(args))
+		return fs
+	}
+
+	require.NoError(t, releaseProfileError(newFlags("--release", "--out", "report.json")))
+
+	err := releaseProfileError(newFlags("--release", "--staged", "--baseline", "baseline.json"))
+	require.EqualError(t, err, "--release cannot be combined with --baseline (no baseline is applied), --staged (the scan is never incremental)")
+}
+
+func TestReleaseProfileErrorRefusesEachFlag(t *testing.T) {
+	readme, err := os.ReadFile("../README.md")
+	require.NoError(t, err)
+
+	for name, reason := range releaseIncompatibleFlags {
+		t.Run(name, func(t *testing.T) {
+			fs := flag.NewFlagSet("interfaces", flag.ContinueOnError)
+			fs.Bool("release", false, "")
+			fs.String(name, "", "")
+			require.NoError(t, fs.Parse([]string{"--release", "--" + name, "value"}))
+
+			err := releaseProfileError(fs)
+			require.EqualError(t, err, fmt.Sprintf("--release cannot be combined with --%s (%s)", name, reason))
+			require.Contains(t, string(readme), "`--"+name+"`", "the README release profile section should list --%s", name)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// RequiredEventsRule requires contracts whose names match Contracts to emit each of Events,
+// e.g. the messaging events that off-chain relayers index.
+type RequiredEventsRule struct {
+	// Contracts is a glob pattern over contract names, e.g. "*CrossDomainMessenger".
+	Contracts string `json:"contracts"`
+	// Events are canonical event signatures, e.g. "RelayedMessage(bytes32)".
+	Events []string `json:"events"`
+}
+
+// checkRequiredEvents fails when a source contract matching a required events rule has no event
+// with one of the rule's signatures in its ABI, including events it inherits.
+func checkRequiredEvents(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || len(config.RequiredEvents) == 0 || config.isExcluded("required-event", t.name) {
+		return nil, nil
+	}
+
+	var rules []RequiredEventsRule
+	for _, rule := range config.RequiredEvents {
+		if ok, err := path.Match(rule.Contracts, t.name); err != nil {
+			return nil, fmt.Errorf("invalid contract pattern %q: %w", rule.Contracts, err)
+		} else if ok {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	signatures := make(map[string]bool)
+	byName := make(map[string][]string)
+	for _, item := range items {
+		if getString(item, "type") != "event" {
+			continue
+		}
+		signature := abiSignature(item)
+		signatures[signature] = true
+		byName[getString(item, "name")] = append(byName[getString(item, "name")], signature)
+	}
+
+	var findings []common.Finding
+	for _, rule := range rules {
+		for _, required := range rule.Events {
+			required = strings.Join(strings.Fields(required), "")
+			if signatures[required] {
+				continue
+			}
+			msg := fmt.Sprintf("%s is missing required event %s (rule %q)", t.name, required, rule.Contracts)
+			name, _, _ := strings.Cut(required, "(")
+			if declared := byName[name]; len(declared) > 0 {
+				msg = fmt.Sprintf("%s declares %s but rule %q requires %s", t.name, strings.Join(declared, ", "), rule.Contracts, required)
+			}
+			findings = append(findings, newFinding("required-event", common.SeverityError, t.sourcePath(), t.name, msg))
+		}
+	}
+	return findings, nil
+}
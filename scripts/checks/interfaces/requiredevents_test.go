@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRequiredEvents(t *testing.T) {
+	abi := `[
+		{"type":"event","name":"SentMessage","inputs":[
+			{"name":"target","type":"address","internalType":"address","indexed":true},
+			{"name":"message","type":"bytes","internalType":"bytes","indexed":false}
+		]},
+		{"type":"event","name":"RelayedMessage","inputs":[
+			{"name":"msgHash","type":"bytes32","internalType":"bytes32","indexed":true}
+		]}
+	]`
+
+	t.Run("present", func(t *testing.T) {
+		setConfig(t, &Config{RequiredEvents: []RequiredEventsRule{{Contracts: "Te*", Events: []string{"SentMessage(address, bytes)", "RelayedMessage(bytes32)"}}}})
+		findings, err := checkRequiredEvents(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("missing and mismatched", func(t *testing.T) {
+		setConfig(t, &Config{RequiredEvents: []RequiredEventsRule{{Contracts: "Test", Events: []string{"SentMessage(address,bytes,uint256)", "FailedRelayedMessage(bytes32)"}}}})
+		findings, err := checkRequiredEvents(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, `Test declares SentMessage(address,bytes) but rule "Test" requires SentMessage(address,bytes,uint256)`, findings[0].Message)
+		require.Equal(t, `Test is missing required event FailedRelayedMessage(bytes32) (rule "Test")`, findings[1].Message)
+	})
+
+	t.Run("other contracts", func(t *testing.T) {
+		setConfig(t, &Config{RequiredEvents: []RequiredEventsRule{{Contracts: "L1*", Events: []string{"Missing()"}}}})
+		findings, err := checkRequiredEvents(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// defaultMaxReturnFields matches the number of stack slots the EVM can address, which is where
+// consumers decoding return values onto the stack start to fail.
+const defaultMaxReturnFields = 16
+
+// checkReturnSize warns about functions declared by a source contract whose outputs flatten to
+// more fields than the configured limit, counting every leaf of nested tuples. Deeply nested
+// return structs can hit stack-depth limits in consumers even though ABI coder v2 encodes them
+// fine. A function can opt out with ignoreTag.
+func checkReturnSize(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("return-size", t.name) {
+		return nil, nil
+	}
+
+	limit := config.ReturnValues.Max
+	if limit <= 0 {
+		limit = defaultMaxReturnFields
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	declared := declaredFunctions(t.artifact.contractNode(t.name))
+
+	var findings []common.Finding
+	for _, item := range items {
+		if getString(item, "type") != "function" {
+			continue
+		}
+		fields, depth := flattenParams(item["outputs"])
+		if fields <= limit {
+			continue
+		}
+		fn, ok := declared[abiParamNamesKey(item)]
+		if declared != nil && (!ok || hasIgnoreTag(fn, "return-size")) {
+			continue
+		}
+		findings = append(findings, newFinding("return-size", common.SeverityWarning, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s returns %d flattened fields nested %d deep (limit %d); consider splitting the return value",
+				t.name, getString(item, "name"), fields, depth, limit)))
+	}
+	return findings, nil
+}
+
+// flattenParams returns the number of leaf fields in a parameter list, expanding tuple
+// components, and the deepest tuple nesting. A flat list has depth 0.
+func flattenParams(raw interface{}) (fields, depth int) {
+	params, _ := raw.([]interface{})
+	for _, p := range params {
+		param, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		components, ok := param["components"]
+		if !ok {
+			fields++
+			continue
+		}
+		nestedFields, nestedDepth := flattenParams(components)
+		fields += nestedFields
+		depth = max(depth, nestedDepth+1)
+	}
+	return fields, depth
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// returnArtifact declares a function returning a nested struct with four leaf fields, an
+// opted-out function returning the same struct and one returning a single value.
+const returnArtifact = `{"abi":[
+	{"type":"function","name":"game","inputs":[],"outputs":[{"name":"","type":"tuple","components":[
+		{"name":"id","type":"uint256"},
+		{"name":"claim","type":"tuple","components":[{"name":"parent","type":"uint32"},{"name":"value","type":"bytes32"}]},
+		{"name":"creator","type":"address"}]}]},
+	{"type":"function","name":"legacyGame","inputs":[],"outputs":[{"name":"","type":"tuple","components":[
+		{"name":"id","type":"uint256"},
+		{"name":"claim","type":"tuple","components":[{"name":"parent","type":"uint32"},{"name":"value","type":"bytes32"}]},
+		{"name":"creator","type":"address"}]}]},
+	{"type":"function","name":"version","inputs":[],"outputs":[{"name":"","type":"string"}]}
+],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"game","parameters":{"nodeType":"ParameterList","parameters":[]}},
+		{"nodeType":"FunctionDefinition","name":"legacyGame",
+			"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore return-size"},
+			"parameters":{"nodeType":"ParameterList","parameters":[]}},
+		{"nodeType":"FunctionDefinition","name":"version","parameters":{"nodeType":"ParameterList","parameters":[]}}
+	]}
+]}}`
+
+func TestCheckReturnSize(t *testing.T) {
+	t.Run("under default limit", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkReturnSize(delegatecallTarget(t, returnArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("over configured limit", func(t *testing.T) {
+		setConfig(t, &Config{ReturnValues: ReturnValuesConfig{Max: 3}})
+		findings, err := checkReturnSize(delegatecallTarget(t, returnArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.game returns 4 flattened fields nested 2 deep (limit 3); consider splitting the return value", findings[0].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{ReturnValues: ReturnValuesConfig{Max: 3}, Exclude: map[string][]string{"return-size": {"Test"}}})
+		findings, err := checkReturnSize(delegatecallTarget(t, returnArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
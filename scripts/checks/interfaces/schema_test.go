@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigSchemaIsCommitted(t *testing.T) {
+	generated, err := json.MarshalIndent(configSchema(), "", "  ")
+	require.NoError(t, err)
+	committed, err := os.ReadFile(configSchemaPath)
+	require.NoError(t, err)
+	require.JSONEq(t, string(generated), string(committed), "regenerate with: go run ./scripts/checks/interfaces --config-schema > scripts/checks/interfaces/%s", configSchemaPath)
+}
+
+func TestDefaultConfigMatchesSchema(t *testing.T) {
+	data, err := os.ReadFile(filepath.Base(defaultConfigPath))
+	require.NoError(t, err)
+	var cfg any
+	require.NoError(t, json.Unmarshal(data, &cfg))
+	requireMatchesSchema(t, "config", configSchema(), cfg)
+}
+
+// requireMatchesSchema checks value against the subset of JSON Schema that configSchema emits.
+func requireMatchesSchema(t *testing.T, path string, schema map[string]any, value any) {
+	t.Helper()
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		require.True(t, ok, "%s must be an object", path)
+		properties, _ := schema["properties"].(map[string]any)
+		for key, elem := range object {
+			if properties == nil {
+				requireMatchesSchema(t, path+"."+key, schema["additionalProperties"].(map[string]any), elem)
+				continue
+			}
+			property, ok := properties[key].(map[string]any)
+			require.True(t, ok, "%s.%s is not in the schema", path, key)
+			requireMatchesSchema(t, path+"."+key, property, elem)
+		}
+	case "array":
+		array, ok := value.([]any)
+		require.True(t, ok, "%s must be an array", path)
+		for _, elem := range array {
+			requireMatchesSchema(t, path+"[]", schema["items"].(map[string]any), elem)
+		}
+	case "string":
+		require.IsType(t, "", value, path)
+	case "integer", "number":
+		require.IsType(t, float64(0), value, path)
+	case "boolean":
+		require.IsType(t, false, value, path)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// defaultReservedSelectors are the admin functions of the transparent Proxy, which shadow any
+// implementation function with the same selector.
+var defaultReservedSelectors = []string{
+	"upgradeTo(address)",
+	"upgradeToAndCall(address,bytes)",
+	"changeAdmin(address)",
+	"admin()",
+	"implementation()",
+}
+
+// checkSelectorClashes reports source contract functions whose selector equals that of a
+// reserved proxy function. Behind a transparent proxy such a function can never be reached by
+// the admin, and may be reached unexpectedly by everyone else.
+func checkSelectorClashes(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || t.definition.ContractKind != "contract" || config.isExcluded("selector-clash", t.name) {
+		return nil, nil
+	}
+
+	reserved := config.SelectorClash.Reserved
+	if len(reserved) == 0 {
+		reserved = defaultReservedSelectors
+	}
+	reservedBySelector := make(map[string]string, len(reserved))
+	for _, signature := range reserved {
+		reservedBySelector[functionSelector(signature)] = signature
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []common.Finding
+	for _, item := range items {
+		if getString(item, "type") != "function" {
+			continue
+		}
+		signature := abiSignature(item)
+		selector := functionSelector(signature)
+		clash, ok := reservedBySelector[selector]
+		if !ok {
+			continue
+		}
+		findings = append(findings, newFinding("selector-clash", common.SeverityError, t.sourcePath(), t.name,
+			fmt.Sprintf("%s.%s has selector %s, which clashes with reserved proxy function %s",
+				t.name, signature, selector, clash)))
+	}
+	return findings, nil
+}
+
+// functionSelector returns the 0x-prefixed 4-byte selector of a canonical function signature.
+func functionSelector(signature string) string {
+	return "0x" + hex.EncodeToString(crypto.Keccak256([]byte(signature))[:4])
+}
+
+// functionSelectors maps the selector of every function in an ABI to its signature.
+func functionSelectors(abi []map[string]interface{}) map[string]string {
+	selectors := make(map[string]string)
+	for _, item := range abi {
+		if getString(item, "type") == "function" {
+			signature := abiSignature(item)
+			selectors[functionSelector(signature)] = signature
+		}
+	}
+	return selectors
+}
+
+// diffSelectors describes the function selectors present in only one of the two ABIs, sorted
+// by selector. Parameter names, outputs and mutability do not affect a selector, so ABIs that
+// differ only in those have no selector differences.
+func diffSelectors(interfaceABI, contractABI []map[string]interface{}) []string {
+	interfaceSelectors := functionSelectors(interfaceABI)
+	contractSelectors := functionSelectors(contractABI)
+
+	var lines []string
+	for selector, signature := range contractSelectors {
+		if _, ok := interfaceSelectors[selector]; !ok {
+			lines = append(lines, fmt.Sprintf("%s: interface is missing %s", selector, signature))
+		}
+	}
+	for selector, signature := range interfaceSelectors {
+		if _, ok := contractSelectors[selector]; !ok {
+			lines = append(lines, fmt.Sprintf("%s: interface has %s, which the contract does not", selector, signature))
+		}
+	}
+	slices.Sort(lines)
+	return lines
+}
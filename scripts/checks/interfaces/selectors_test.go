@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionSelector(t *testing.T) {
+	require.Equal(t, "0xa9059cbb", functionSelector("transfer(address,uint256)"))
+	require.Equal(t, "0xf851a440", functionSelector("admin()"))
+}
+
+func TestCheckSelectorClashes(t *testing.T) {
+	abi := `[
+		{"type":"function","name":"admin","inputs":[],"outputs":[{"type":"address"}]},
+		{"type":"function","name":"owner","inputs":[],"outputs":[{"type":"address"}]}
+	]`
+
+	t.Run("default reserved set", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkSelectorClashes(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, "Test.admin() has selector 0xf851a440, which clashes with reserved proxy function admin()", findings[0].Message)
+	})
+
+	t.Run("configured reserved set", func(t *testing.T) {
+		setConfig(t, &Config{SelectorClash: SelectorClashConfig{Reserved: []string{"owner()"}}})
+		findings, err := checkSelectorClashes(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Contains(t, findings[0].Message, "Test.owner()")
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"selector-clash": {"Test"}}})
+		findings, err := checkSelectorClashes(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
+
+func TestDiffSelectors(t *testing.T) {
+	var interfaceABI, contractABI []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"type":"function","name":"deposit","inputs":[{"name":"to","type":"address"}],"outputs":[]},
+		{"type":"function","name":"legacy","inputs":[],"outputs":[]},
+		{"type":"event","name":"Deposited","inputs":[]}
+	]`), &interfaceABI))
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"type":"function","name":"deposit","inputs":[{"name":"_to","type":"address"}],"outputs":[{"name":"","type":"bool"}]},
+		{"type":"function","name":"withdraw","inputs":[{"name":"amount","type":"uint256"}],"outputs":[]}
+	]`), &contractABI))
+
+	require.Equal(t, []string{
+		"0x2e1a7d4d: interface is missing withdraw(uint256)",
+		"0xa7f3f0d2: interface has legacy(), which the contract does not",
+	}, diffSelectors(interfaceABI, contractABI))
+	require.Empty(t, diffSelectors(contractABI[:1], interfaceABI[:1]))
+}
+
+func TestCompareInterfaceABISelectorsOnly(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"interfaces/IThing.sol": "interface IThing {}\n",
+		"forge-artifacts/Thing.sol/Thing.json": `{"abi":[
+			{"type":"function","name":"thing","inputs":[{"name":"_value","type":"uint256"}],"outputs":[]}
+		]}`,
+	})
+	prevDir := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	prevMode := selectorsOnly
+	selectorsOnly = true
+	t.Cleanup(func() {
+		artifactsDir = prevDir
+		selectorsOnly = prevMode
+	})
+
+	target := func(abi string) *checkTarget {
+		return &checkTarget{
+			path:     "forge-artifacts/IThing.sol/IThing.json",
+			name:     "IThing",
+			artifact: &Artifact{ABI: json.RawMessage(abi), AST: ArtifactAST{AbsolutePath: "interfaces/IThing.sol"}},
+		}
+	}
+
+	findings, err := compareInterfaceABI(target(`[{"type":"function","name":"thing","inputs":[{"name":"value","type":"uint256"}],"outputs":[]}]`))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityInfo, findings[0].Severity)
+	require.Equal(t, "IThing: function selectors match the contract; the 2 remaining ABI differences are cosmetic", findings[0].Message)
+
+	findings, err = compareInterfaceABI(target(`[{"type":"function","name":"thing","inputs":[{"name":"value","type":"uint128"}],"outputs":[]}]`))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityError, findings[0].Severity)
+	require.Equal(t, "IThing: function selectors differ from contract", findings[0].Message)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// EventNamingRule requires state-changing functions whose names match Functions to have an event
+// named after them, e.g. setFoo emitting FooSet.
+type EventNamingRule struct {
+	// Functions is a regular expression over function names, e.g. "^set([A-Z]\\w*)$".
+	Functions string `json:"functions"`
+	// Events are templates for the expected event names, expanded from the Functions match like
+	// regexp.Expand, e.g. "${1}Set". The function passes if any of them is in the ABI.
+	Events []string `json:"events"`
+}
+
+// checkSetterEvents warns about state-changing functions declared by a source contract that
+// match a configured event naming rule but have no correspondingly named event in the contract's
+// ABI, which usually means the setter forgot to emit. A function can opt out with ignoreTag.
+func checkSetterEvents(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || len(config.EventNaming) == 0 || config.isExcluded("setter-event", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	events := make(map[string]bool)
+	for _, item := range items {
+		if getString(item, "type") == "event" {
+			events[getString(item, "name")] = true
+		}
+	}
+	declared := declaredFunctions(t.artifact.contractNode(t.name))
+
+	var findings []common.Finding
+	for _, rule := range config.EventNaming {
+		pattern, err := regexp.Compile(rule.Functions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event naming pattern %q: %w", rule.Functions, err)
+		}
+		for _, item := range items {
+			if getString(item, "type") != "function" {
+				continue
+			}
+			if mutability := getString(item, "stateMutability"); mutability == "view" || mutability == "pure" {
+				continue
+			}
+			name := getString(item, "name")
+			match := pattern.FindStringSubmatchIndex(name)
+			if match == nil {
+				continue
+			}
+			fn, ok := declared[abiParamNamesKey(item)]
+			if declared != nil && (!ok || hasIgnoreTag(fn, "setter-event")) {
+				continue
+			}
+
+			expected := make([]string, 0, len(rule.Events))
+			for _, template := range rule.Events {
+				expected = append(expected, string(pattern.ExpandString(nil, template, name, match)))
+			}
+			if slices.ContainsFunc(expected, func(event string) bool { return events[event] }) {
+				continue
+			}
+			findings = append(findings, newFinding("setter-event", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s has no matching event; expected one of %s (rule %q)",
+					t.name, name, strings.Join(expected, ", "), rule.Functions)))
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// setterEventArtifact has a setter with a matching event, one without, an opted-out one, a view
+// function matching the pattern and an inherited setter missing from the AST.
+const setterEventArtifact = `{"abi":[
+	{"type":"function","name":"setOwner","inputs":[{"name":"owner","type":"address"}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"function","name":"setFee","inputs":[{"name":"fee","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"function","name":"setLegacy","inputs":[],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"function","name":"settings","inputs":[],"outputs":[],"stateMutability":"view"},
+	{"type":"function","name":"setInherited","inputs":[],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"event","name":"OwnerUpdated","inputs":[],"anonymous":false}
+],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"setOwner","kind":"function","parameters":{"parameters":[{"name":"owner"}]}},
+		{"nodeType":"FunctionDefinition","name":"setFee","kind":"function","parameters":{"parameters":[{"name":"fee"}]}},
+		{"nodeType":"FunctionDefinition","name":"setLegacy","kind":"function","parameters":{"parameters":[]},
+			"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore setter-event"}},
+		{"nodeType":"FunctionDefinition","name":"settings","kind":"function","parameters":{"parameters":[]}}
+	]}
+]}}`
+
+func TestCheckSetterEvents(t *testing.T) {
+	rules := []EventNamingRule{{Functions: `^set([A-Z]\w*)$`, Events: []string{"${1}Set", "${1}Updated"}}}
+
+	t.Run("flags setters without events", func(t *testing.T) {
+		setConfig(t, &Config{EventNaming: rules})
+		findings, err := checkSetterEvents(delegatecallTarget(t, setterEventArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, `Test.setFee has no matching event; expected one of FeeSet, FeeUpdated (rule "^set([A-Z]\\w*)$")`, findings[0].Message)
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkSetterEvents(delegatecallTarget(t, setterEventArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{EventNaming: rules, Exclude: map[string][]string{"setter-event": {"Test"}}})
+		findings, err := checkSetterEvents(delegatecallTarget(t, setterEventArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		setConfig(t, &Config{EventNaming: []EventNamingRule{{Functions: "(", Events: []string{"Set"}}}})
+		_, err := checkSetterEvents(delegatecallTarget(t, setterEventArtifact))
+		require.ErrorContains(t, err, "invalid event naming pattern")
+	})
+}
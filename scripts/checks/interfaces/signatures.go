@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// encodedSignature is a string-literal signature passed to an abi encoding call.
+type encodedSignature struct {
+	signature string
+	file      string
+	contract  string
+	function  string
+}
+
+// signatureIndex collects the function signatures declared across all artifacts and the
+// signatures encoded by source contracts, so the two can be compared once every artifact has
+// been read.
+type signatureIndex struct {
+	mtx     sync.Mutex
+	known   map[string]struct{}
+	encoded []encodedSignature
+}
+
+func newSignatureIndex() *signatureIndex {
+	return &signatureIndex{known: make(map[string]struct{})}
+}
+
+var encodedSignatures = newSignatureIndex()
+
+// readABI records the functions the artifact's ABI declares. Every artifact has an ABI, so
+// functions are known even when they come from an artifact built without an AST.
+func (s *signatureIndex) readABI(t *checkTarget) error {
+	abiItems, err := t.abiItems()
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, item := range abiItems {
+		if getString(item, "type") == "function" {
+			s.known[abiSignature(item)] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func (s *signatureIndex) run(t *checkTarget) ([]common.Finding, error) {
+	var encoded []encodedSignature
+	if t.isSource() {
+		if node := t.artifact.contractNode(t.name); node != nil {
+			walkAST(node, func(n astNode, parents []astNode) bool {
+				if n.nodeType() != "FunctionCall" {
+					return true
+				}
+				if sig, ok := encodedSignatureLiteral(n); ok {
+					encoded = append(encoded, encodedSignature{
+						signature: sig,
+						file:      t.sourcePath(),
+						contract:  t.name,
+						function:  functionLabel(enclosingFunction(parents)),
+					})
+				}
+				return true
+			})
+		}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.encoded = append(s.encoded, encoded...)
+	return nil, nil
+}
+
+func (s *signatureIndex) finish() []common.Finding {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var findings []common.Finding
+	for _, enc := range s.encoded {
+		if _, ok := s.known[enc.signature]; ok {
+			continue
+		}
+		findings = append(findings, newFinding("encode-signature", common.SeverityWarning, enc.file, enc.contract,
+			fmt.Sprintf("%s.%s encodes signature %q which does not match any known function", enc.contract, enc.function, enc.signature)))
+	}
+	return findings
+}
+
+// encodedSignatureLiteral returns the signature string encoded by call when it is either
+// `abi.encodeWithSignature("sig", ...)` or `abi.encodeWithSelector(bytes4(keccak256("sig")), ...)`.
+func encodedSignatureLiteral(call astNode) (string, bool) {
+	callee := call.child("expression")
+	if callee.nodeType() != "MemberAccess" || callee.child("expression").name() != "abi" {
+		return "", false
+	}
+	args := call.children("arguments")
+	if len(args) == 0 {
+		return "", false
+	}
+
+	switch getString(callee, "memberName") {
+	case "encodeWithSignature":
+		return stringLiteral(args[0])
+	case "encodeWithSelector":
+		return hashedStringLiteral(args[0], false)
+	}
+	return "", false
+}
+
+// hashedStringLiteral unwraps type conversions such as bytes4(...) or bytes(...) and a
+// keccak256(...) call around a string literal.
+func hashedStringLiteral(expr astNode, hashed bool) (string, bool) {
+	if expr.nodeType() == "Literal" {
+		if !hashed {
+			return "", false
+		}
+		return stringLiteral(expr)
+	}
+	if expr.nodeType() != "FunctionCall" {
+		return "", false
+	}
+	args := expr.children("arguments")
+	if len(args) != 1 {
+		return "", false
+	}
+	callee := expr.child("expression")
+	switch {
+	case callee.nodeType() == "ElementaryTypeNameExpression":
+		return hashedStringLiteral(args[0], hashed)
+	case callee.nodeType() == "Identifier" && callee.name() == "keccak256":
+		return hashedStringLiteral(args[0], true)
+	}
+	return "", false
+}
+
+func stringLiteral(expr astNode) (string, bool) {
+	if expr.nodeType() != "Literal" || getString(expr, "kind") != "string" {
+		return "", false
+	}
+	return getString(expr, "value"), true
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// signatureCallerArtifact encodes a valid signature, a typo'd signature and a hashed selector.
+const signatureCallerArtifact = `{"abi":[],"ast":{"absolutePath":"src/Caller.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Caller","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"run","body":{"nodeType":"Block","statements":[
+			{"nodeType":"FunctionCall","expression":{"nodeType":"MemberAccess","memberName":"encodeWithSignature","expression":{"nodeType":"Identifier","name":"abi"}},
+				"arguments":[{"nodeType":"Literal","kind":"string","value":"transfer(address,uint256)"}]},
+			{"nodeType":"FunctionCall","expression":{"nodeType":"MemberAccess","memberName":"encodeWithSignature","expression":{"nodeType":"Identifier","name":"abi"}},
+				"arguments":[{"nodeType":"Literal","kind":"string","value":"tranfer(address,uint256)"}]},
+			{"nodeType":"FunctionCall","expression":{"nodeType":"MemberAccess","memberName":"encodeWithSelector","expression":{"nodeType":"Identifier","name":"abi"}},
+				"arguments":[{"nodeType":"FunctionCall","expression":{"nodeType":"ElementaryTypeNameExpression"},"arguments":[
+					{"nodeType":"FunctionCall","expression":{"nodeType":"Identifier","name":"keccak256"},"arguments":[
+						{"nodeType":"Literal","kind":"string","value":"approve(address,uint256)"}
+					]}
+				]}]}
+		]}}
+	]}
+]}}`
+
+const signatureTokenArtifact = `{"abi":[
+	{"type":"function","name":"transfer","inputs":[{"type":"address"},{"type":"uint256"}]},
+	{"type":"event","name":"approve","inputs":[{"type":"address"},{"type":"uint256"}]}
+],"ast":{"absolutePath":"lib/Token.sol","nodes":[{"nodeType":"ContractDefinition","name":"Token","contractKind":"contract"}]}}`
+
+func signatureTarget(t *testing.T, name, src string) *checkTarget {
+	t.Helper()
+	var artifact Artifact
+	require.NoError(t, json.Unmarshal([]byte(src), &artifact))
+	return &checkTarget{name: name, artifact: &artifact, definition: getContractDefinition(&artifact, name)}
+}
+
+func TestSignatureIndex(t *testing.T) {
+	index := newSignatureIndex()
+	for name, src := range map[string]string{"Caller": signatureCallerArtifact, "Token": signatureTokenArtifact} {
+		target := signatureTarget(t, name, src)
+		require.NoError(t, index.readABI(target))
+		findings, err := index.run(target)
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	}
+
+	findings := index.finish()
+	require.Len(t, findings, 2)
+	sortFindings(findings)
+	require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	require.Equal(t, "src/Caller.sol", findings[0].File)
+	require.Contains(t, findings[0].Message, `"approve(address,uint256)"`)
+	require.Contains(t, findings[1].Message, `Caller.run encodes signature "tranfer(address,uint256)"`)
+}
+
+func TestSignatureIndexKnowsArtifactsWithoutAST(t *testing.T) {
+	index := newSignatureIndex()
+	caller := signatureTarget(t, "Caller", signatureCallerArtifact)
+	require.NoError(t, index.readABI(caller))
+	_, err := index.run(caller)
+	require.NoError(t, err)
+	// A vendored token built without an AST still declares transfer in its ABI.
+	require.NoError(t, index.readABI(signatureTarget(t, "Token", `{"abi":[
+		{"type":"function","name":"transfer","inputs":[{"type":"address"},{"type":"uint256"}]}
+	]}`)))
+
+	findings := index.finish()
+	require.Len(t, findings, 2)
+	for _, finding := range findings {
+		require.NotContains(t, finding.Message, `"transfer(address,uint256)"`)
+	}
+}
+
+func TestEncodedSignatureLiteral(t *testing.T) {
+	tests := []struct {
+		name   string
+		call   string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "encodeWithSignature",
+			call:   `{"nodeType":"FunctionCall","expression":{"nodeType":"MemberAccess","memberName":"encodeWithSignature","expression":{"nodeType":"Identifier","name":"abi"}},"arguments":[{"nodeType":"Literal","kind":"string","value":"f()"}]}`,
+			want:   "f()",
+			wantOK: true,
+		},
+		{
+			name: "non-literal signature",
+			call: `{"nodeType":"FunctionCall","expression":{"nodeType":"MemberAccess","memberName":"encodeWithSignature","expression":{"nodeType":"Identifier","name":"abi"}},"arguments":[{"nodeType":"Identifier","name":"sig"}]}`,
+		},
+		{
+			name: "encodeWithSelector without hash",
+			call: `{"nodeType":"FunctionCall","expression":{"nodeType":"MemberAccess","memberName":"encodeWithSelector","expression":{"nodeType":"Identifier","name":"abi"}},"arguments":[{"nodeType":"Literal","kind":"string","value":"f()"}]}`,
+		},
+		{
+			name: "not abi",
+			call: `{"nodeType":"FunctionCall","expression":{"nodeType":"MemberAccess","memberName":"encodeWithSignature","expression":{"nodeType":"Identifier","name":"other"}},"arguments":[{"nodeType":"Literal","kind":"string","value":"f()"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := encodedSignatureLiteral(parseAST(t, tt.call))
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
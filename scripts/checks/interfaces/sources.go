@@ -0,0 +1,272 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+var contractNameRegex = regexp.MustCompile(`(?m)^\s*(?:abstract\s+)?contract\s+(\w+)`)
+
+// repoRelative returns path relative to the working directory, with forward slashes, so that
+// messages and the baselines keyed on them are the same on every checkout. Paths outside the
+// working directory are returned as they are.
+func repoRelative(path string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// declaredContractNames returns the names of the contracts declared in a Solidity source file,
+// ignoring declarations that are commented out.
+func declaredContractNames(content []byte) []string {
+	var names []string
+	for _, match := range contractNameRegex.FindAllSubmatch(stripComments(content), -1) {
+		names = append(names, string(match[1]))
+	}
+	return names
+}
+
+// stripComments blanks out the // and /* */ comments of Solidity source, keeping newlines so
+// that line starts stay where they were. String literals are copied as they are, so a "//" in a
+// string does not start a comment.
+func stripComments(content []byte) []byte {
+	out := make([]byte, 0, len(content))
+	for i := 0; i < len(content); i++ {
+		switch c := content[i]; {
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(content) && content[end] != c && content[end] != '\n' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end, len(content)-1)
+			out = append(out, content[i:end+1]...)
+			i = end
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if i < len(content) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			i += 2
+			for i < len(content) && !(content[i] == '*' && i+1 < len(content) && content[i+1] == '/') {
+				if content[i] == '\n' {
+					out = append(out, '\n')
+				}
+				i++
+			}
+			i++
+			out = append(out, ' ')
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// SourceRoot is a source tree whose contracts must have interfaces under
+// ExpectedInterfaceRoot, mirroring their directory layout.
+type SourceRoot struct {
+	Root                  string   `json:"root"`
+	ExpectedInterfaceRoot string   `json:"expectedInterfaceRoot"`
+	Exclude               []string `json:"exclude,omitempty"`
+}
+
+// defaultSourceRoots is used when the config does not list any source roots.
+var defaultSourceRoots = []SourceRoot{
+	{
+		Root:                  "src",
+		ExpectedInterfaceRoot: "interfaces",
+		Exclude:               []string{"src/libraries/**", "src/vendor/**"},
+	},
+}
+
+// interfaceSearchPaths are extra roots, such as vendored or symlinked packages, searched for an
+// interface when it is not at its expected path. Each is laid out like ExpectedInterfaceRoot.
+var interfaceSearchPaths []string
+
+// sourceContract is a contract declared in one of the scanned source roots.
+type sourceContract struct {
+	Name          string
+	SourcePath    string
+	InterfacePath string
+	// InterfaceRoot is the root the interface was found under, when it has one.
+	InterfaceRoot string
+	HasInterface  bool
+	Excluded      bool
+}
+
+func sourceRoots() []SourceRoot {
+	if len(config.SourceRoots) > 0 {
+		return config.SourceRoots
+	}
+	return defaultSourceRoots
+}
+
+// scanSourceContracts finds every contract declared under the given roots and works out where
+// its interface is expected to live.
+func scanSourceContracts(roots []SourceRoot) ([]sourceContract, error) {
+	var contracts []sourceContract
+	for _, root := range roots {
+		results, err := common.ProcessFilesGlob(
+			[]string{filepath.ToSlash(filepath.Join(root.Root, "**/*.sol"))},
+			root.Exclude,
+			func(path string) ([]sourceContract, []error) {
+				return scanSourceFile(root, path)
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root.Root, err)
+		}
+		for _, fileContracts := range results {
+			contracts = append(contracts, fileContracts...)
+		}
+	}
+
+	slices.SortFunc(contracts, func(a, b sourceContract) int {
+		return strings.Compare(a.SourcePath+"\x00"+a.Name, b.SourcePath+"\x00"+b.Name)
+	})
+	return contracts, nil
+}
+
+func scanSourceFile(root SourceRoot, path string) ([]sourceContract, []error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	relDir, err := filepath.Rel(root.Root, filepath.Dir(path))
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var contracts []sourceContract
+	for _, name := range declaredContractNames(content) {
+		contract := sourceContract{
+			Name:          name,
+			SourcePath:    path,
+			InterfacePath: filepath.Join(cwd, root.ExpectedInterfaceRoot, relDir, "I"+name+".sol"),
+			Excluded:      isExcludedSourceContract(name, path),
+		}
+		if interfacePath, interfaceRoot, ok := findInterface(root, relDir, name); ok {
+			contract.InterfacePath = interfacePath
+			contract.InterfaceRoot = interfaceRoot
+			contract.HasInterface = true
+		}
+		contracts = append(contracts, contract)
+	}
+	return contracts, nil
+}
+
+// findInterface looks for the interface of the contract name declared in relDir of root, first
+// at its expected path and then under each interface search path. It returns the absolute path
+// and the interface root it was found under.
+func findInterface(root SourceRoot, relDir, name string) (string, string, bool) {
+	for _, interfaceRoot := range append([]string{root.ExpectedInterfaceRoot}, interfaceSearchPaths...) {
+		candidate := filepath.Join(interfaceRoot, relDir, "I"+name+".sol")
+		if !filepath.IsAbs(candidate) {
+			candidate = filepath.Join(cwd, candidate)
+		}
+		if _, err := os.Stat(candidate); !errors.Is(err, os.ErrNotExist) {
+			return candidate, interfaceRoot, true
+		}
+	}
+	return "", "", false
+}
+
+// interfaceForSource finds the interface of contract name declared in the source file at
+// sourcePath, using whichever source root contains it.
+func interfaceForSource(sourcePath, name string) (string, bool) {
+	for _, root := range sourceRoots() {
+		relDir, err := filepath.Rel(root.Root, filepath.Dir(sourcePath))
+		if err != nil || strings.HasPrefix(relDir, "..") {
+			continue
+		}
+		path, _, ok := findInterface(root, relDir, name)
+		return path, ok
+	}
+	return "", false
+}
+
+// verifyAllContractsHaveInterfaces reports every non-excluded contract in the source roots
+// that has no interface at its expected path.
+func verifyAllContractsHaveInterfaces(roots []SourceRoot) ([]common.Finding, error) {
+	contracts, err := scanSourceContracts(roots)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []common.Finding
+	for _, contract := range contracts {
+		if verbose && contract.HasInterface {
+			log.Printf("%s: interface found under %s", contract.Name, contract.InterfaceRoot)
+		}
+		if contract.Excluded || contract.HasInterface || !shard.Contains(contract.Name) {
+			continue
+		}
+		if relInterface, err := filepath.Rel(cwd, contract.InterfacePath); err == nil && !isChanged(contract.SourcePath) && !isChanged(relInterface) {
+			continue
+		}
+		finding := newFinding("interfaces", common.SeverityError, contract.SourcePath, contract.Name,
+			fmt.Sprintf("%s: contract in %s has no corresponding interface at %s",
+				contract.Name, repoRelative(contract.SourcePath), repoRelative(contract.InterfacePath)))
+		finding.Rule = ruleMissingInterface
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// checkDuplicateContractNames reports contract names declared in more than one file under
+// srcDir. Duplicates make artifact paths ambiguous, so the wrong artifact can be picked up
+// when looking for a corresponding contract.
+func checkDuplicateContractNames(srcDir string) ([]common.Finding, error) {
+	results, err := common.ProcessFilesGlob(
+		[]string{filepath.ToSlash(filepath.Join(srcDir, "**/*.sol"))},
+		[]string{},
+		func(path string) ([]string, []error) {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, []error{err}
+			}
+			return declaredContractNames(content), nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	declarations := make(map[string][]string)
+	for path, names := range results {
+		for _, name := range names {
+			declarations[name] = append(declarations[name], path)
+		}
+	}
+
+	var findings []common.Finding
+	for name, paths := range declarations {
+		if len(paths) < 2 || config.isExcluded("duplicate-contract-name", name) {
+			continue
+		}
+		slices.Sort(paths)
+		findings = append(findings, newFinding("duplicate-contract-name", common.SeverityError, paths[0], name,
+			fmt.Sprintf("%s is declared in multiple files: %s", name, strings.Join(paths, ", "))))
+	}
+	return findings, nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSourceFixture creates a small repo layout in a temp dir and makes it the working dir.
+func setupSourceFixture(t *testing.T, files map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	prevCwd := cwd
+	cwd = dir
+	t.Cleanup(func() { cwd = prevCwd })
+
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0644))
+	}
+}
+
+func TestVerifyAllContractsHaveInterfaces(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"src/L1/Portal.sol":            "contract Portal {}\n",
+		"src/L1/Missing.sol":           "/// contract Commented\nabstract contract Missing {}\n",
+		"src/L1/Retired.sol":           "/*\ncontract Retired {}\n*/\n// contract AlsoRetired {}\n",
+		"src/L1/skip/Skipped.sol":      "contract Skipped {}\n",
+		"src/dispute/Game.sol":         "contract Game {}\n",
+		"src/dispute/Excluded.sol":     "contract Excluded {}\n",
+		"interfaces/L1/IPortal.sol":    "interface IPortal {}\n",
+		"interfaces/dispute/IGame.sol": "interface IGame {}\n",
+	})
+
+	roots := []SourceRoot{
+		{Root: "src/L1", ExpectedInterfaceRoot: "interfaces/L1", Exclude: []string{"src/L1/skip/**"}},
+		{Root: "src/dispute", ExpectedInterfaceRoot: "interfaces/dispute", Exclude: []string{"src/dispute/Excluded.sol"}},
+	}
+
+	findings, err := verifyAllContractsHaveInterfaces(roots)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, "Missing", findings[0].Contract)
+	require.Equal(t, "src/L1/Missing.sol", findings[0].File)
+}
+
+func TestDeclaredContractNames(t *testing.T) {
+	source := `// SPDX-License-Identifier: MIT
+/**
+ * contract InDocComment is not declared.
+contract AtLineStartInBlock {}
+ */
+contract A { string constant URL = "https://example.com/*"; }
+  // contract Commented {}
+abstract contract B {} /* contract Trailing {} */
+contract C {}
+`
+	require.Equal(t, []string{"A", "B", "C"}, declaredContractNames([]byte(source)))
+}
+
+func TestScanSourceContracts(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"src/A.sol":                "contract A {}\ncontract B {}\n",
+		"src/nested/C.sol":         "contract C {}\n",
+		"interfaces/nested/IC.sol": "interface IC {}\n",
+	})
+
+	contracts, err := scanSourceContracts([]SourceRoot{{Root: "src", ExpectedInterfaceRoot: "interfaces"}})
+	require.NoError(t, err)
+	require.Len(t, contracts, 3)
+	require.Equal(t, "A", contracts[0].Name)
+	require.Equal(t, "B", contracts[1].Name)
+	require.Equal(t, "C", contracts[2].Name)
+	require.True(t, contracts[2].HasInterface)
+	require.Equal(t, filepath.Join(cwd, "interfaces", "nested", "IC.sol"), contracts[2].InterfacePath)
+}
+
+func TestInterfaceSearchPaths(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"src/L1/Portal.sol":                      "contract Portal {}\n",
+		"src/L1/Bridge.sol":                      "contract Bridge {}\n",
+		"src/L1/Missing.sol":                     "contract Missing {}\n",
+		"interfaces/L1/IPortal.sol":              "interface IPortal {}\n",
+		"vendor/pkg-a/L1/IPortal.sol":            "interface IPortal {}\n",
+		"vendor/pkg-a/L1/IBridge.sol":            "interface IBridge {}\n",
+		"vendor/pkg-b/interfaces/L1/IBridge.sol": "interface IBridge {}\n",
+	})
+
+	prev := interfaceSearchPaths
+	interfaceSearchPaths = []string{"vendor/pkg-a", "vendor/pkg-b/interfaces"}
+	t.Cleanup(func() { interfaceSearchPaths = prev })
+
+	contracts, err := scanSourceContracts([]SourceRoot{{Root: "src", ExpectedInterfaceRoot: "interfaces"}})
+	require.NoError(t, err)
+
+	roots := make(map[string]string)
+	for _, contract := range contracts {
+		roots[contract.Name] = contract.InterfaceRoot
+	}
+	require.Equal(t, map[string]string{"Portal": "interfaces", "Bridge": "vendor/pkg-a", "Missing": ""}, roots)
+
+	findings, err := verifyAllContractsHaveInterfaces([]SourceRoot{{Root: "src", ExpectedInterfaceRoot: "interfaces"}})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, "Missing", findings[0].Contract)
+	require.Contains(t, findings[0].Message, "at interfaces/L1/IMissing.sol")
+}
+
+func TestCheckDuplicateContractNames(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"src/L1/Portal.sol":      "contract Portal {}\n",
+		"src/L2/Portal.sol":      "contract Portal {}\n",
+		"src/L1/Bridge.sol":      "contract Bridge {}\n",
+		"src/test/MockERC20.sol": "contract MockERC20 {}\n",
+		"src/L2/MockERC20.sol":   "contract MockERC20 {}\n",
+	})
+
+	setConfig(t, &Config{Exclude: map[string][]string{"duplicate-contract-name": {"MockERC20"}}})
+	findings, err := checkDuplicateContractNames("src")
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, "src/L1/Portal.sol", findings[0].File)
+	require.Equal(t, "Portal is declared in multiple files: src/L1/Portal.sol, src/L2/Portal.sol", findings[0].Message)
+}
+
+func TestVerifyAllContractsHaveInterfacesSharded(t *testing.T) {
+	files := map[string]string{}
+	for _, name := range []string{"Alpha", "Beta", "Gamma", "Delta", "Epsilon"} {
+		files["src/"+name+".sol"] = "contract " + name + " {}\n"
+	}
+	setupSourceFixture(t, files)
+
+	prev := shard
+	t.Cleanup(func() { shard = prev })
+
+	seen := make(map[string]int)
+	for i := 1; i <= 2; i++ {
+		shard = common.Shard{Index: i, Count: 2}
+		findings, err := verifyAllContractsHaveInterfaces([]SourceRoot{{Root: "src", ExpectedInterfaceRoot: "interfaces"}})
+		require.NoError(t, err)
+		for _, finding := range findings {
+			seen[finding.Contract]++
+		}
+	}
+	require.Equal(t, map[string]int{"Alpha": 1, "Beta": 1, "Gamma": 1, "Delta": 1, "Epsilon": 1}, seen)
+}
+
+func TestBaselineMatchesAcrossCheckouts(t *testing.T) {
+	files := map[string]string{
+		"src/L1/Missing.sol":   "contract Missing {}\n",
+		"interfaces/ITest.sol": "interface ITest {}\n",
+	}
+	roots := []SourceRoot{{Root: "src", ExpectedInterfaceRoot: "interfaces"}}
+	run := func() []common.Finding {
+		findings, err := verifyAllContractsHaveInterfaces(roots)
+		require.NoError(t, err)
+		payable, err := checkReceivesETHMarker(abiTarget(t, `[{"type":"receive","stateMutability":"payable"}]`))
+		require.NoError(t, err)
+		return append(findings, payable...)
+	}
+
+	setupSourceFixture(t, files)
+	setConfig(t, &Config{})
+	first := run()
+	require.Len(t, first, 2)
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, common.WriteBaseline(path, common.NewBaseline(first)))
+
+	// The same tree checked out elsewhere, as in CI.
+	setupSourceFixture(t, files)
+	baseline, err := common.ReadBaseline(path)
+	require.NoError(t, err)
+	remaining, stale := baseline.Filter(run())
+	require.Empty(t, remaining)
+	require.Empty(t, stale)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+var (
+	storageGapTypeRegex = regexp.MustCompile(`^uint256\[\d+\]$`)
+	// erc7201Regex matches the NatSpec tag that marks a struct as ERC-7201 namespaced storage.
+	erc7201Regex = regexp.MustCompile(`@custom:storage-location\s+erc7201:\S+`)
+)
+
+// checkUpgradeableStorage fails when a source contract in the configured upgradeable set has
+// neither a trailing uint256[N] __gap state variable nor an ERC-7201 namespaced storage struct,
+// since either one is needed for later versions to add state without shifting the layout.
+func checkUpgradeableStorage(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || !slices.Contains(config.Upgradeable.Contracts, t.name) || config.isExcluded("upgradeable-storage", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	var stateVariables []astNode
+	for _, member := range node.children("nodes") {
+		switch member.nodeType() {
+		case "StructDefinition":
+			if erc7201Regex.MatchString(getString(member.child("documentation"), "text")) {
+				return nil, nil
+			}
+		case "VariableDeclaration":
+			if mutability := getString(member, "mutability"); mutability != "constant" && mutability != "immutable" {
+				stateVariables = append(stateVariables, member)
+			}
+		}
+	}
+
+	gap := slices.IndexFunc(stateVariables, func(v astNode) bool { return v.name() == "__gap" })
+	var message string
+	switch {
+	case gap < 0:
+		message = fmt.Sprintf("%s is upgradeable but has neither a trailing uint256[N] __gap nor an ERC-7201 namespaced storage struct", t.name)
+	case !storageGapTypeRegex.MatchString(strings.TrimSpace(getString(stateVariables[gap].child("typeDescriptions"), "typeString"))):
+		message = fmt.Sprintf("%s declares __gap as %s; the storage gap must be a uint256[N] array", t.name,
+			getString(stateVariables[gap].child("typeDescriptions"), "typeString"))
+	case gap != len(stateVariables)-1:
+		message = fmt.Sprintf("%s declares __gap before %s; the storage gap must be the last state variable", t.name,
+			stateVariables[len(stateVariables)-1].name())
+	default:
+		return nil, nil
+	}
+	return []common.Finding{newFinding("upgradeable-storage", common.SeverityError, t.sourcePath(), t.name, message)}, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// storageArtifact wraps contract-level AST nodes in a Test contract.
+func storageArtifact(nodes string) string {
+	return fmt.Sprintf(`{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[%s]}
+]}}`, nodes)
+}
+
+func stateVariable(name, typeString, mutability string) string {
+	return fmt.Sprintf(`{"nodeType":"VariableDeclaration","name":%q,"stateVariable":true,"mutability":%q,"typeDescriptions":{"typeString":%q}}`,
+		name, mutability, typeString)
+}
+
+func TestCheckUpgradeableStorage(t *testing.T) {
+	owner := stateVariable("owner", "address", "mutable")
+	version := stateVariable("VERSION", "uint256", "constant")
+	gap := stateVariable("__gap", "uint256[49]", "mutable")
+	namespaced := `{"nodeType":"StructDefinition","name":"TestStorage","members":[],
+		"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:storage-location erc7201:base.storage.Test"}}`
+
+	tests := []struct {
+		name    string
+		nodes   string
+		message string
+	}{
+		{name: "trailing gap", nodes: owner + "," + gap + "," + version},
+		{name: "namespaced storage", nodes: namespaced + "," + owner},
+		{name: "missing", nodes: owner, message: "Test is upgradeable but has neither a trailing uint256[N] __gap nor an ERC-7201 namespaced storage struct"},
+		{name: "gap not last", nodes: gap + "," + owner, message: "Test declares __gap before owner; the storage gap must be the last state variable"},
+		{name: "gap wrong type", nodes: owner + "," + stateVariable("__gap", "bytes32[10]", "mutable"), message: "Test declares __gap as bytes32[10]; the storage gap must be a uint256[N] array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, &Config{Upgradeable: UpgradeableConfig{Contracts: []string{"Test"}}})
+			findings, err := checkUpgradeableStorage(delegatecallTarget(t, storageArtifact(tt.nodes)))
+			require.NoError(t, err)
+			if tt.message == "" {
+				require.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			require.Equal(t, common.SeverityError, findings[0].Severity)
+			require.Equal(t, tt.message, findings[0].Message)
+		})
+	}
+
+	t.Run("not upgradeable", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkUpgradeableStorage(delegatecallTarget(t, storageArtifact(owner)))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{
+			Upgradeable: UpgradeableConfig{Contracts: []string{"Test"}},
+			Exclude:     map[string][]string{"upgradeable-storage": {"Test"}},
+		})
+		findings, err := checkUpgradeableStorage(delegatecallTarget(t, storageArtifact(owner)))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
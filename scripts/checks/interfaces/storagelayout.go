@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// StorageLayout is solc's storageLayout output, which forge writes when asked to via
+// extra_output.
+type StorageLayout struct {
+	Storage []StorageEntry             `json:"storage"`
+	Types   map[string]StorageTypeInfo `json:"types"`
+}
+
+// StorageEntry is a state variable in a StorageLayout. Slot is a decimal string since slots can
+// exceed 64 bits.
+type StorageEntry struct {
+	Label  string `json:"label"`
+	Offset int    `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+type StorageTypeInfo struct {
+	Label         string `json:"label"`
+	NumberOfBytes string `json:"numberOfBytes"`
+}
+
+// storageRange is the bytes a storage entry occupies, counted from the start of slot 0.
+type storageRange struct {
+	entry      StorageEntry
+	start, end *big.Int
+}
+
+// checkStorageLayout fails when the storage layout of a source contract is internally
+// inconsistent: a state variable without a label or with the label of another one, two variables
+// sharing bytes, or slots skipped between consecutive variables. Packing puts variables at
+// different offsets of one slot and is not an overlap.
+func checkStorageLayout(t *checkTarget) ([]common.Finding, error) {
+	layout := t.artifact.StorageLayout
+	if !t.isSource() || layout == nil || config.isExcluded("storage-layout", t.name) {
+		return nil, nil
+	}
+
+	var findings []common.Finding
+	report := func(format string, args ...any) {
+		findings = append(findings, newFinding("storage-layout", common.SeverityError, t.sourcePath(), t.name,
+			fmt.Sprintf("%s %s", t.name, fmt.Sprintf(format, args...))))
+	}
+
+	slots := make(map[string]string) // label -> slot of its first entry
+	ranges := make([]storageRange, 0, len(layout.Storage))
+	for _, entry := range layout.Storage {
+		switch first, ok := slots[entry.Label]; {
+		case entry.Label == "":
+			report("has a storage variable without a label at slot %s", entry.Slot)
+		case ok:
+			report("declares storage label %s twice, at slots %s and %s", entry.Label, first, entry.Slot)
+		default:
+			slots[entry.Label] = entry.Slot
+		}
+
+		slot, ok := new(big.Int).SetString(entry.Slot, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid storage slot %q for %s", entry.Slot, entry.Label)
+		}
+		size, ok := new(big.Int).SetString(layout.Types[entry.Type].NumberOfBytes, 10)
+		if !ok {
+			return nil, fmt.Errorf("unknown size of storage type %q for %s", entry.Type, entry.Label)
+		}
+		start := new(big.Int).Add(new(big.Int).Mul(slot, big.NewInt(32)), big.NewInt(int64(entry.Offset)))
+		ranges = append(ranges, storageRange{entry: entry, start: start, end: new(big.Int).Add(start, size)})
+	}
+
+	slices.SortStableFunc(ranges, func(a, b storageRange) int { return a.start.Cmp(b.start) })
+	for i := 1; i < len(ranges); i++ {
+		prev, next := ranges[i-1], ranges[i]
+		// The first slot after prev, which is where next starts when it is not packed into prev's
+		// last slot.
+		slotAfter := new(big.Int).Div(new(big.Int).Add(prev.end, big.NewInt(31)), big.NewInt(32))
+		nextSlot := new(big.Int).Div(next.start, big.NewInt(32))
+		switch {
+		case next.start.Cmp(prev.end) < 0:
+			report("stores %s (slot %s, offset %d) in bytes used by %s (slot %s, offset %d)",
+				describeStorage(next.entry, layout), next.entry.Slot, next.entry.Offset,
+				describeStorage(prev.entry, layout), prev.entry.Slot, prev.entry.Offset)
+		case nextSlot.Cmp(slotAfter) > 0:
+			report("skips slots %s to %s between %s (slot %s) and %s (slot %s)",
+				slotAfter, new(big.Int).Sub(nextSlot, big.NewInt(1)),
+				describeStorage(prev.entry, layout), prev.entry.Slot, describeStorage(next.entry, layout), next.entry.Slot)
+		}
+	}
+	return findings, nil
+}
+
+// describeStorage returns an entry's label and type, e.g. "owner (address)".
+func describeStorage(entry StorageEntry, layout *StorageLayout) string {
+	label := layout.Types[entry.Type].Label
+	if label == "" {
+		label = strings.TrimPrefix(entry.Type, "t_")
+	}
+	return entry.Label + " (" + label + ")"
+}
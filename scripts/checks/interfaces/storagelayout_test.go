@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStorageLayout(t *testing.T) {
+	types := map[string]StorageTypeInfo{
+		"t_address":                      {Label: "address", NumberOfBytes: "20"},
+		"t_bool":                         {Label: "bool", NumberOfBytes: "1"},
+		"t_uint256":                      {Label: "uint256", NumberOfBytes: "32"},
+		"t_array(t_uint256)2_storage":    {Label: "uint256[2]", NumberOfBytes: "64"},
+		"t_mapping(t_address,t_uint256)": {Label: "mapping(address => uint256)", NumberOfBytes: "32"},
+	}
+	check := func(t *testing.T, storage ...StorageEntry) []common.Finding {
+		t.Helper()
+		target := delegatecallTarget(t, `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[]}}`)
+		target.artifact.StorageLayout = &StorageLayout{Storage: storage, Types: types}
+		findings, err := checkStorageLayout(target)
+		require.NoError(t, err)
+		return findings
+	}
+	setConfig(t, &Config{})
+
+	t.Run("packed layout", func(t *testing.T) {
+		require.Empty(t, check(t,
+			StorageEntry{Label: "owner", Slot: "0", Offset: 0, Type: "t_address"},
+			StorageEntry{Label: "paused", Slot: "0", Offset: 20, Type: "t_bool"},
+			StorageEntry{Label: "values", Slot: "1", Type: "t_array(t_uint256)2_storage"},
+			StorageEntry{Label: "balances", Slot: "3", Type: "t_mapping(t_address,t_uint256)"},
+		))
+	})
+
+	t.Run("duplicate and missing labels", func(t *testing.T) {
+		findings := check(t,
+			StorageEntry{Label: "owner", Slot: "0", Type: "t_address"},
+			StorageEntry{Label: "", Slot: "1", Type: "t_uint256"},
+			StorageEntry{Label: "owner", Slot: "2", Type: "t_address"},
+		)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, "Test has a storage variable without a label at slot 1", findings[0].Message)
+		require.Equal(t, "Test declares storage label owner twice, at slots 0 and 2", findings[1].Message)
+	})
+
+	t.Run("overlaps and gaps", func(t *testing.T) {
+		findings := check(t,
+			StorageEntry{Label: "owner", Slot: "0", Offset: 0, Type: "t_address"},
+			StorageEntry{Label: "paused", Slot: "0", Offset: 19, Type: "t_bool"},
+			StorageEntry{Label: "values", Slot: "1", Type: "t_array(t_uint256)2_storage"},
+			StorageEntry{Label: "total", Slot: "2", Type: "t_uint256"},
+			StorageEntry{Label: "last", Slot: "6", Type: "t_uint256"},
+		)
+		require.Len(t, findings, 3)
+		require.Equal(t, "Test stores paused (bool) (slot 0, offset 19) in bytes used by owner (address) (slot 0, offset 0)", findings[0].Message)
+		require.Equal(t, "Test stores total (uint256) (slot 2, offset 0) in bytes used by values (uint256[2]) (slot 1, offset 0)", findings[1].Message)
+		require.Equal(t, "Test skips slots 3 to 5 between total (uint256) (slot 2) and last (uint256) (slot 6)", findings[2].Message)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		target := delegatecallTarget(t, `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[]}}`)
+		target.artifact.StorageLayout = &StorageLayout{Storage: []StorageEntry{{Label: "x", Slot: "0", Type: "t_struct"}}}
+		_, err := checkStorageLayout(target)
+		require.ErrorContains(t, err, `unknown size of storage type "t_struct" for x`)
+	})
+}
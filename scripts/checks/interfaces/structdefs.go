@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkStructDefinitions fails when an interface declares a struct with the same name as a
+// struct in its corresponding contract but with different fields. The ABI comparison only sees
+// structs that appear in function signatures, so this catches drift in the others. Fields are
+// compared in order, since order determines the encoding.
+func checkStructDefinitions(t *checkTarget) ([]common.Finding, error) {
+	if t.definition.ContractKind != "interface" || !strings.HasPrefix(t.name, "I") ||
+		config.isExcluded("struct-definition", t.name) {
+		return nil, nil
+	}
+
+	interfaceStructs := structDefinitions(t.artifact.contractNode(t.name))
+	if len(interfaceStructs) == 0 {
+		return nil, nil
+	}
+
+	contractName := t.name[1:]
+	contractPath, err := contractArtifactPath(contractName)
+	if err != nil {
+		return nil, err
+	}
+	contractArtifact, err := readArtifact(contractPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corresponding contract artifact: %w", err)
+	}
+	contractStructs := structDefinitions(contractArtifact.contractNode(contractName))
+
+	var findings []common.Finding
+	for _, def := range t.artifact.contractNode(t.name).children("nodes") {
+		if def.nodeType() != "StructDefinition" {
+			continue
+		}
+		contractFields, ok := contractStructs[def.name()]
+		if !ok {
+			continue
+		}
+		interfaceFields := interfaceStructs[def.name()]
+		for i := range max(len(interfaceFields), len(contractFields)) {
+			interfaceField, contractField := "missing", "missing"
+			if i < len(interfaceFields) {
+				interfaceField = interfaceFields[i]
+			}
+			if i < len(contractFields) {
+				contractField = contractFields[i]
+			}
+			if interfaceField == contractField {
+				continue
+			}
+			findings = append(findings, newFinding("struct-definition", common.SeverityError, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s field %d differs from %s.%s: interface has %q, contract has %q",
+					t.name, def.name(), i, contractName, def.name(), interfaceField, contractField)))
+		}
+	}
+	return findings, nil
+}
+
+// structDefinitions maps the name of every struct declared in a contract node to its fields,
+// formatted as "<type> <name>". Types are normalized to their interface form so that a struct
+// referencing Foo.Inner matches one referencing IFoo.Inner.
+func structDefinitions(contract astNode) map[string][]string {
+	structs := make(map[string][]string)
+	for _, def := range contract.children("nodes") {
+		if def.nodeType() != "StructDefinition" {
+			continue
+		}
+		var fields []string
+		for _, member := range def.children("members") {
+			typeString := getString(member.child("typeDescriptions"), "typeString")
+			fields = append(fields, normalizeInternalType(typeString)+" "+member.name())
+		}
+		structs[def.name()] = fields
+	}
+	return structs
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStructDefinitions(t *testing.T) {
+	member := func(name, typeString string) string {
+		return `{"nodeType":"VariableDeclaration","name":"` + name + `","typeDescriptions":{"typeString":"` + typeString + `"}}`
+	}
+	structDef := func(name string, members ...string) string {
+		out := `{"nodeType":"StructDefinition","name":"` + name + `","members":[`
+		for i, m := range members {
+			if i > 0 {
+				out += ","
+			}
+			out += m
+		}
+		return out + `]}`
+	}
+	artifact := func(path, name, kind string, structs ...string) string {
+		out := `{"abi":[],"ast":{"absolutePath":"` + path + `","nodes":[{"nodeType":"ContractDefinition","name":"` + name +
+			`","contractKind":"` + kind + `","nodes":[`
+		for i, s := range structs {
+			if i > 0 {
+				out += ","
+			}
+			out += s
+		}
+		return out + `]}]}}`
+	}
+
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/Test.sol/Test.json": artifact("src/Test.sol", "Test", "contract",
+			structDef("Same", member("game", "contract Game"), member("inner", "struct Test.Inner")),
+			structDef("Drifted", member("amount", "uint128"), member("to", "address")),
+			structDef("Unshared", member("x", "uint256"))),
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+
+	interfaceArtifact := artifact("interfaces/ITest.sol", "ITest", "interface",
+		structDef("Same", member("game", "contract IGame"), member("inner", "struct ITest.Inner")),
+		structDef("Drifted", member("amount", "uint256")),
+		structDef("InterfaceOnly", member("y", "uint256")))
+	target := func(t *testing.T) *checkTarget {
+		var a Artifact
+		require.NoError(t, json.Unmarshal([]byte(interfaceArtifact), &a))
+		return &checkTarget{path: "forge-artifacts/ITest.sol/ITest.json", name: "ITest", artifact: &a, definition: getContractDefinition(&a, "ITest")}
+	}
+
+	t.Run("flags drifted fields", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkStructDefinitions(target(t))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityError, findings[0].Severity)
+		require.Equal(t, `ITest.Drifted field 0 differs from Test.Drifted: interface has "uint256 amount", contract has "uint128 amount"`, findings[0].Message)
+		require.Equal(t, `ITest.Drifted field 1 differs from Test.Drifted: interface has "missing", contract has "address to"`, findings[1].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"struct-definition": {"ITest"}}})
+		findings, err := checkStructDefinitions(target(t))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// defaultMaxStructReturnFields is used when the config does not set a struct return limit.
+const defaultMaxStructReturnFields = 5
+
+// checkStructReturns warns about public functions that return structs with many fields, since
+// these are expensive to return by value and widen the ABI surface.
+func checkStructReturns(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("struct-return", t.name) {
+		return nil, nil
+	}
+
+	maxFields := config.StructReturn.MaxFields
+	if maxFields <= 0 {
+		maxFields = defaultMaxStructReturnFields
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []common.Finding
+	for _, item := range items {
+		if getString(item, "type") != "function" {
+			continue
+		}
+		outputs, _ := item["outputs"].([]interface{})
+		for _, output := range outputs {
+			param, ok := output.(map[string]interface{})
+			if !ok || getString(param, "type") != "tuple" {
+				continue
+			}
+			components, _ := param["components"].([]interface{})
+			if len(components) <= maxFields {
+				continue
+			}
+			findings = append(findings, newFinding("struct-return", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s returns %s with %d fields (limit %d); confirm returning it by value is intended",
+					t.name, getString(item, "name"), structName(param), len(components), maxFields)))
+		}
+	}
+	return findings, nil
+}
+
+// structName returns the struct name from a tuple parameter's internalType.
+func structName(param map[string]interface{}) string {
+	if name := getString(param, "internalType"); name != "" {
+		return name
+	}
+	return "tuple"
+}
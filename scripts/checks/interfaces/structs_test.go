@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStructReturns(t *testing.T) {
+	abi := `[
+		{"type":"function","name":"small","inputs":[],"outputs":[{"type":"tuple","internalType":"struct Test.Small","components":[{"type":"uint256"},{"type":"address"}]}]},
+		{"type":"function","name":"large","inputs":[],"outputs":[{"type":"tuple","internalType":"struct Test.Large","components":[
+			{"type":"uint256"},{"type":"uint256"},{"type":"uint256"},{"type":"uint256"},{"type":"uint256"},{"type":"uint256"}
+		]}]},
+		{"type":"event","name":"E","inputs":[]}
+	]`
+
+	t.Run("default limit", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkStructReturns(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Contains(t, findings[0].Message, "Test.large returns struct Test.Large with 6 fields (limit 5)")
+	})
+
+	t.Run("configured limit", func(t *testing.T) {
+		setConfig(t, &Config{StructReturn: StructReturnConfig{MaxFields: 1}})
+		findings, err := checkStructReturns(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"struct-return": {"Test"}}})
+		findings, err := checkStructReturns(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
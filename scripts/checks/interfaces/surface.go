@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// defaultMaxExternalFunctions is used when the config does not set an external function limit.
+const defaultMaxExternalFunctions = 50
+
+// checkExternalFunctionCount warns about source contracts whose ABI exposes more functions than
+// the configured limit, as a nudge towards decomposing them.
+func checkExternalFunctionCount(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || t.definition.ContractKind != "contract" || config.isExcluded("external-function-count", t.name) {
+		return nil, nil
+	}
+
+	limit := config.ExternalFunctions.Max
+	if limit <= 0 {
+		limit = defaultMaxExternalFunctions
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+
+	count := 0
+	for _, item := range items {
+		if getString(item, "type") == "function" {
+			count++
+		}
+	}
+	if count <= limit {
+		return nil, nil
+	}
+	return []common.Finding{newFinding("external-function-count", common.SeverityWarning, t.sourcePath(), t.name,
+		fmt.Sprintf("%s exposes %d external functions (limit %d); consider splitting it up", t.name, count, limit))}, nil
+}
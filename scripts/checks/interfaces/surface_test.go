@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckExternalFunctionCount(t *testing.T) {
+	abi := `[
+		{"type":"function","name":"a","inputs":[],"outputs":[]},
+		{"type":"function","name":"b","inputs":[],"outputs":[]},
+		{"type":"function","name":"c","inputs":[],"outputs":[]},
+		{"type":"event","name":"E","inputs":[]}
+	]`
+
+	t.Run("under default limit", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkExternalFunctionCount(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("over configured limit", func(t *testing.T) {
+		setConfig(t, &Config{ExternalFunctions: ExternalFunctionsConfig{Max: 2}})
+		findings, err := checkExternalFunctionCount(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test exposes 3 external functions (limit 2); consider splitting it up", findings[0].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{
+			ExternalFunctions: ExternalFunctionsConfig{Max: 2},
+			Exclude:           map[string][]string{"external-function-count": {"Test"}},
+		})
+		findings, err := checkExternalFunctionCount(abiTarget(t, abi))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
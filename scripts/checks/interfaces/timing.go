@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// phaseTiming is the wall-clock duration of one phase of a run.
+type phaseTiming struct {
+	name     string
+	duration time.Duration
+}
+
+// runTimer records phase durations and per-file processing stats for --timing.
+type runTimer struct {
+	mtx     sync.Mutex
+	phases  []phaseTiming
+	files   int
+	busy    time.Duration
+	slowest string
+	slowAt  time.Duration
+}
+
+// phase starts timing a phase and returns a function that ends it.
+func (r *runTimer) phase(name string) func() {
+	start := time.Now()
+	return func() {
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		r.phases = append(r.phases, phaseTiming{name: name, duration: time.Since(start)})
+	}
+}
+
+// wrap instruments an artifact processor so that time spent per file is recorded.
+func (r *runTimer) wrap(processor common.FileProcessor[[]common.Finding]) common.FileProcessor[[]common.Finding] {
+	return func(path string) ([]common.Finding, []error) {
+		start := time.Now()
+		findings, errs := processor(path)
+		elapsed := time.Since(start)
+
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		r.files++
+		r.busy += elapsed
+		if elapsed > r.slowAt {
+			r.slowest, r.slowAt = path, elapsed
+		}
+		return findings, errs
+	}
+}
+
+// print writes the collected timings as a table.
+func (r *runTimer) print(w io.Writer) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PHASE\tDURATION")
+	var total time.Duration
+	for _, p := range r.phases {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\n", p.name, p.duration.Round(time.Microsecond))
+		total += p.duration
+	}
+	_, _ = fmt.Fprintf(tw, "total\t%s\n", total.Round(time.Microsecond))
+	_ = tw.Flush()
+
+	if r.files == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\nworkers: %d, artifacts: %d, busy: %s, avg/artifact: %s\n",
+		runtime.NumCPU(), r.files, r.busy.Round(time.Microsecond), (r.busy / time.Duration(r.files)).Round(time.Microsecond))
+	_, _ = fmt.Fprintf(w, "slowest: %s (%s)\n", r.slowest, r.slowAt.Round(time.Microsecond))
+}
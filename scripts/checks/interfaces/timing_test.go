@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTimer(t *testing.T) {
+	var timer runTimer
+	end := timer.phase("discover artifacts")
+	end()
+
+	processor := timer.wrap(func(path string) ([]common.Finding, []error) {
+		return nil, nil
+	})
+	_, _ = processor("a.json")
+	_, _ = processor("b.json")
+
+	var out bytes.Buffer
+	timer.print(&out)
+	require.Contains(t, out.String(), "discover artifacts")
+	require.Contains(t, out.String(), "total")
+	require.Contains(t, out.String(), "artifacts: 2")
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+var (
+	solidityCommentRegex = regexp.MustCompile(`//[^\n]*|/\*[\s\S]*?\*/`)
+	todoMarkerRegex      = regexp.MustCompile(`\b(TODO|FIXME|XXX)\b`)
+)
+
+// checkPrivilegedTodos warns about TODO, FIXME and XXX comments inside functions of source
+// contracts that apply a modifier or match a privileged name pattern, since unfinished
+// privileged logic is a deploy risk. Other TODOs are left alone to keep the check quiet.
+func checkPrivilegedTodos(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("privileged-todo", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+
+	patterns := config.AccessControl.Patterns
+	if len(patterns) == 0 {
+		patterns = defaultPrivilegedPatterns
+	}
+
+	var source []byte
+	var findings []common.Finding
+	for _, fn := range node.children("nodes") {
+		if fn.nodeType() != "FunctionDefinition" || fn.child("body") == nil {
+			continue
+		}
+		pattern, err := matchingPattern(patterns, fn.name())
+		if err != nil {
+			return nil, err
+		}
+		if len(fn.children("modifiers")) == 0 && pattern == "" {
+			continue
+		}
+		start, length, ok := srcRange(fn)
+		if !ok {
+			continue
+		}
+		if source == nil {
+			if source, err = readSource(t.sourcePath()); err != nil {
+				return nil, err
+			}
+		}
+		if start+length > len(source) {
+			continue
+		}
+
+		body := source[start : start+length]
+		for _, span := range solidityCommentRegex.FindAllIndex(body, -1) {
+			comment := string(body[span[0]:span[1]])
+			if !todoMarkerRegex.MatchString(comment) {
+				continue
+			}
+			line := bytes.Count(source[:start+span[0]], []byte("\n")) + 1
+			findings = append(findings, newFinding("privileged-todo", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s is privileged but has an unfinished-work comment at %s:%d: %s",
+					t.name, functionLabel(fn), t.sourcePath(), line, strings.Join(strings.Fields(comment), " "))))
+		}
+	}
+	return findings, nil
+}
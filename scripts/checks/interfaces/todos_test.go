@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPrivilegedTodos(t *testing.T) {
+	source := `contract Test {
+    function setFee(uint256 fee) external {
+        // TODO: cap the fee
+        _fee = fee;
+    }
+
+    function rescue() external onlyOwner {
+        /* FIXME handle
+           partial transfers */
+        _rescue();
+    }
+
+    function deposit() external {
+        // TODO: batch deposits
+    }
+
+    function pause() external onlyOwner {
+        // Stop everything; no TODOs left here.
+        _pause();
+    }
+}
+`
+	setupSourceFixture(t, map[string]string{"src/Test.sol": source})
+
+	span := func(start, end string) string {
+		from := strings.Index(source, start)
+		to := strings.Index(source[from:], end) + from + len(end)
+		return fmt.Sprintf("%d:%d:0", from, to-from)
+	}
+	modifier := `[{"nodeType":"ModifierInvocation","modifierName":{"nodeType":"IdentifierPath","name":"onlyOwner"}}]`
+	artifact := fmt.Sprintf(`{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+			{"nodeType":"FunctionDefinition","name":"setFee","src":%q,"modifiers":[],"body":{"nodeType":"Block"}},
+			{"nodeType":"FunctionDefinition","name":"rescue","src":%q,"modifiers":%s,"body":{"nodeType":"Block"}},
+			{"nodeType":"FunctionDefinition","name":"deposit","src":%q,"modifiers":[],"body":{"nodeType":"Block"}},
+			{"nodeType":"FunctionDefinition","name":"pause","src":%q,"modifiers":%s,"body":{"nodeType":"Block"}}
+		]}
+	]}}`, span("function setFee", "}"), span("function rescue", "}"), modifier,
+		span("function deposit", "}"), span("function pause", "}"), modifier)
+
+	t.Run("flags privileged functions", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkPrivilegedTodos(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.setFee is privileged but has an unfinished-work comment at src/Test.sol:3: // TODO: cap the fee", findings[0].Message)
+		require.Equal(t, "Test.rescue is privileged but has an unfinished-work comment at src/Test.sol:8: /* FIXME handle partial transfers */", findings[1].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"privileged-todo": {"Test"}}})
+		findings, err := checkPrivilegedTodos(delegatecallTarget(t, artifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkUnnamedParams warns about unnamed parameters of functions declared by a source contract or
+// an interface. Names are part of the ABI that generated clients and docs are built from. A
+// function can opt out with ignoreTag.
+func checkUnnamedParams(t *checkTarget) ([]common.Finding, error) {
+	if (!t.isSource() && !strings.HasPrefix(t.sourcePath(), "interfaces/")) || config.isExcluded("unnamed-param", t.name) {
+		return nil, nil
+	}
+
+	items, err := t.abiItems()
+	if err != nil {
+		return nil, err
+	}
+	declared := declaredFunctions(t.artifact.contractNode(t.name))
+
+	var findings []common.Finding
+	for _, item := range items {
+		if getString(item, "type") != "function" {
+			continue
+		}
+		fn, ok := declared[abiParamNamesKey(item)]
+		if declared != nil && (!ok || hasIgnoreTag(fn, "unnamed-param")) {
+			continue
+		}
+		inputs, _ := item["inputs"].([]interface{})
+		for i, input := range inputs {
+			param, _ := input.(map[string]interface{})
+			if getString(param, "name") != "" {
+				continue
+			}
+			findings = append(findings, newFinding("unnamed-param", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s parameter %d (%s) has no name", t.name, abiSignature(item), i, getString(param, "type"))))
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+// unnamedParamArtifact has a function with an unnamed second parameter, a fully named one, an
+// opted-out one and an inherited one missing from the AST.
+const unnamedParamArtifact = `{"abi":[
+	{"type":"function","name":"relay","inputs":[{"name":"target","type":"address"},{"name":"","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"pause","inputs":[{"name":"reason","type":"string"}],"outputs":[]},
+	{"type":"function","name":"legacy","inputs":[{"name":"","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"inherited","inputs":[{"name":"","type":"uint256"}],"outputs":[]}
+],"ast":{"absolutePath":"src/Test.sol","nodes":[
+	{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+		{"nodeType":"FunctionDefinition","name":"relay","kind":"function","parameters":{"parameters":[{"name":"target"},{"name":""}]}},
+		{"nodeType":"FunctionDefinition","name":"pause","kind":"function","parameters":{"parameters":[{"name":"reason"}]}},
+		{"nodeType":"FunctionDefinition","name":"legacy","kind":"function","parameters":{"parameters":[{"name":""}]},
+			"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore unnamed-param"}}
+	]}
+]}}`
+
+func TestCheckUnnamedParams(t *testing.T) {
+	t.Run("flags unnamed parameters", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkUnnamedParams(delegatecallTarget(t, unnamedParamArtifact))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, common.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "Test.relay(address,bytes) parameter 1 (bytes) has no name", findings[0].Message)
+	})
+
+	t.Run("interfaces", func(t *testing.T) {
+		setConfig(t, &Config{})
+		findings, err := checkUnnamedParams(interfaceTarget(t, `[{"type":"function","name":"f","inputs":[{"name":"","type":"uint256"}],"outputs":[]}]`))
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, "ITest.f(uint256) parameter 0 (uint256) has no name", findings[0].Message)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		setConfig(t, &Config{Exclude: map[string][]string{"unnamed-param": {"Test"}}})
+		findings, err := checkUnnamedParams(delegatecallTarget(t, unnamedParamArtifact))
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// externalEntryTypes are the ABI item types that can be called on a deployed contract.
+var externalEntryTypes = []string{"function", "receive", "fallback"}
+
+// unreachableIndex records concrete source contracts with no external entry points, and the
+// bases of every contract, so that contracts that are only ever inherited are not reported.
+type unreachableIndex struct {
+	mtx        sync.Mutex
+	candidates map[string]string // contract -> source file
+	bases      map[string]bool
+}
+
+func newUnreachableIndex() *unreachableIndex {
+	return &unreachableIndex{candidates: make(map[string]string), bases: make(map[string]bool)}
+}
+
+var unreachable = newUnreachableIndex()
+
+func (idx *unreachableIndex) run(t *checkTarget) ([]common.Finding, error) {
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+	bases := baseContractNames(node)
+
+	candidate := false
+	if t.isSource() && t.definition.ContractKind == "contract" && node["abstract"] != true &&
+		!config.isExcluded("unreachable-contract", t.name) {
+		items, err := t.abiItems()
+		if err != nil {
+			return nil, err
+		}
+		candidate = !slices.ContainsFunc(items, func(item map[string]interface{}) bool {
+			return slices.Contains(externalEntryTypes, getString(item, "type"))
+		})
+	}
+
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+	for _, base := range bases {
+		idx.bases[base] = true
+	}
+	if candidate {
+		idx.candidates[t.name] = t.sourcePath()
+	}
+	return nil, nil
+}
+
+// finish warns about the candidates that no other contract inherits from. Such a contract can
+// be deployed but has nothing to call, which usually means it is leftover scaffolding.
+func (idx *unreachableIndex) finish() []common.Finding {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	names := make([]string, 0, len(idx.candidates))
+	for name := range idx.candidates {
+		if !idx.bases[name] {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	findings := make([]common.Finding, 0, len(names))
+	for _, name := range names {
+		findings = append(findings, newFinding("unreachable-contract", common.SeverityWarning, idx.candidates[name], name,
+			fmt.Sprintf("%s is a concrete contract with no external functions and is not inherited by any contract; remove it or make it abstract", name)))
+	}
+	return findings
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnreachableIndex(t *testing.T) {
+	withABI := func(target *checkTarget, abi string) *checkTarget {
+		target.artifact.ABI = json.RawMessage(abi)
+		return target
+	}
+	abstract := inheritanceArtifact(t, "src/Abstract.sol", "Abstract", "contract")
+	abstract.artifact.contractNode("Abstract")["abstract"] = true
+
+	targets := []*checkTarget{
+		inheritanceArtifact(t, "src/Scaffold.sol", "Scaffold", "contract"),
+		inheritanceArtifact(t, "src/Base.sol", "Base", "contract"),
+		withABI(inheritanceArtifact(t, "src/Child.sol", "Child", "contract", "Base"),
+			`[{"type":"function","name":"run","inputs":[],"outputs":[]}]`),
+		withABI(inheritanceArtifact(t, "src/Vault.sol", "Vault", "contract"),
+			`[{"type":"receive","stateMutability":"payable"},{"type":"event","name":"Received","inputs":[]}]`),
+		abstract,
+		inheritanceArtifact(t, "src/Lib.sol", "Lib", "library"),
+		inheritanceArtifact(t, "src/Ignored.sol", "Ignored", "contract"),
+		inheritanceArtifact(t, "test/Helper.sol", "Helper", "contract"),
+	}
+
+	setConfig(t, &Config{Exclude: map[string][]string{"unreachable-contract": {"Ignored"}}})
+	index := newUnreachableIndex()
+	for _, target := range targets {
+		_, err := index.run(target)
+		require.NoError(t, err)
+	}
+
+	findings := index.finish()
+	require.Len(t, findings, 1)
+	require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	require.Equal(t, "src/Scaffold.sol", findings[0].File)
+	require.Equal(t, "Scaffold is a concrete contract with no external functions and is not inherited by any contract; remove it or make it abstract", findings[0].Message)
+}
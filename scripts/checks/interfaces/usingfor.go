@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/base/contracts/scripts/checks/common"
+)
+
+// checkUsingForCollisions warns about functions of a source contract that share a name with a
+// function the contract attaches to a type with `using ... for`. Calls like `x.f()` and `f(x)`
+// then reach different code, which is easy to misread. A function can opt out with ignoreTag.
+func checkUsingForCollisions(t *checkTarget) ([]common.Finding, error) {
+	if !t.isSource() || config.isExcluded("using-for-collision", t.name) {
+		return nil, nil
+	}
+
+	node := t.artifact.contractNode(t.name)
+	if node == nil {
+		return nil, nil
+	}
+	functions := make(map[string]astNode)
+	for _, fn := range node.children("nodes") {
+		if fn.nodeType() == "FunctionDefinition" && fn.name() != "" {
+			functions[fn.name()] = fn
+		}
+	}
+	if len(functions) == 0 {
+		return nil, nil
+	}
+
+	// Directives at the top level of the file apply to the contract as well.
+	directives := slices.Concat(node.children("nodes"), t.artifact.tree().children("nodes"))
+
+	var findings []common.Finding
+	for _, directive := range directives {
+		if directive.nodeType() != "UsingForDirective" {
+			continue
+		}
+		target := "*"
+		if typeName := directive.child("typeName"); typeName != nil {
+			target = getString(typeName.child("typeDescriptions"), "typeString")
+		}
+
+		var attached []string // qualified names, e.g. SafeCall.call
+		if library := usingForName(directive.child("libraryName")); library != "" {
+			names, err := libraryFunctions(t.artifact, library)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				attached = append(attached, library+"."+name)
+			}
+		}
+		for _, entry := range directive.children("functionList") {
+			if name := usingForName(entry.child("function")); name != "" {
+				attached = append(attached, name)
+			}
+		}
+
+		for _, qualified := range attached {
+			name := qualified[strings.LastIndex(qualified, ".")+1:]
+			fn, ok := functions[name]
+			if !ok || hasIgnoreTag(fn, "using-for-collision") {
+				continue
+			}
+			findings = append(findings, newFinding("using-for-collision", common.SeverityWarning, t.sourcePath(), t.name,
+				fmt.Sprintf("%s.%s has the same name as %s, attached to %s with a using for directive",
+					t.name, name, qualified, target)))
+		}
+	}
+	return findings, nil
+}
+
+// usingForName returns the name an identifier path or identifier in a using for directive refers
+// to, e.g. "Lib" or "Lib.f".
+func usingForName(n astNode) string {
+	if n == nil {
+		return ""
+	}
+	if name := n.name(); name != "" {
+		return name
+	}
+	return n.child("pathNode").name()
+}
+
+// libraryFunctionNames caches the function names of the libraries looked up by
+// libraryFunctions, keyed by artifacts directory and library name.
+var libraryFunctionNames sync.Map
+
+// libraryFunctions returns the names of the functions the named library declares, looking in
+// artifact first since libraries are often declared next to the contracts that use them. A
+// library without an artifact has no functions as far as this check is concerned.
+func libraryFunctions(artifact *Artifact, library string) ([]string, error) {
+	library = library[strings.LastIndex(library, ".")+1:]
+	node := artifact.contractNode(library)
+	if node == nil {
+		key := artifactsDir + "\x00" + library
+		if cached, ok := libraryFunctionNames.Load(key); ok {
+			return cached.([]string), nil
+		}
+		path, ok, err := artifactPathForContract(library)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if ok {
+			libraryArtifact, err := readArtifact(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read artifact of %s: %w", library, err)
+			}
+			node = libraryArtifact.contractNode(library)
+		}
+		names := functionNames(node)
+		libraryFunctionNames.Store(key, names)
+		return names, nil
+	}
+	return functionNames(node), nil
+}
+
+func functionNames(contract astNode) []string {
+	var names []string
+	for _, fn := range contract.children("nodes") {
+		if fn.nodeType() == "FunctionDefinition" && fn.name() != "" {
+			names = append(names, fn.name())
+		}
+	}
+	return names
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/base/contracts/scripts/checks/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUsingForCollisions(t *testing.T) {
+	setupSourceFixture(t, map[string]string{
+		"forge-artifacts/SafeCall.sol/SafeCall.json": `{"abi":[],"ast":{"absolutePath":"src/libraries/SafeCall.sol","nodes":[
+			{"nodeType":"ContractDefinition","name":"SafeCall","contractKind":"library","nodes":[
+				{"nodeType":"FunctionDefinition","name":"send"},
+				{"nodeType":"FunctionDefinition","name":"call"}
+			]}
+		]}}`,
+	})
+	prev := artifactsDir
+	artifactsDir = filepath.Join(cwd, "forge-artifacts")
+	t.Cleanup(func() { artifactsDir = prev })
+	setConfig(t, &Config{})
+
+	artifact := `{"abi":[],"ast":{"absolutePath":"src/Test.sol","nodes":[
+		{"nodeType":"UsingForDirective","global":false,
+			"functionList":[{"function":{"nodeType":"IdentifierPath","name":"clamp"}}],
+			"typeName":{"nodeType":"ElementaryTypeName","typeDescriptions":{"typeString":"uint256"}}},
+		{"nodeType":"ContractDefinition","name":"Test","contractKind":"contract","nodes":[
+			{"nodeType":"UsingForDirective","libraryName":{"nodeType":"IdentifierPath","name":"SafeCall"},
+				"typeName":{"nodeType":"ElementaryTypeName","typeDescriptions":{"typeString":"address"}}},
+			{"nodeType":"UsingForDirective","libraryName":{"nodeType":"IdentifierPath","name":"Local"}},
+			{"nodeType":"FunctionDefinition","name":"send"},
+			{"nodeType":"FunctionDefinition","name":"clamp"},
+			{"nodeType":"FunctionDefinition","name":"scale",
+				"documentation":{"nodeType":"StructuredDocumentation","text":" @custom:interfaces-ignore using-for-collision"}},
+			{"nodeType":"FunctionDefinition","name":"relay"}
+		]},
+		{"nodeType":"ContractDefinition","name":"Local","contractKind":"library","nodes":[
+			{"nodeType":"FunctionDefinition","name":"scale"}
+		]}
+	]}}`
+
+	findings, err := checkUsingForCollisions(delegatecallTarget(t, artifact))
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	require.Equal(t, common.SeverityWarning, findings[0].Severity)
+	require.Equal(t, "Test.send has the same name as SafeCall.send, attached to address with a using for directive", findings[0].Message)
+	require.Equal(t, "Test.clamp has the same name as clamp, attached to uint256 with a using for directive", findings[1].Message)
+}